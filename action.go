@@ -0,0 +1,202 @@
+package lawbench
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// actionJSON is the flat wire form of an Action.
+type actionJSON struct {
+	Type            ActionType        `json:"type"`
+	CurrentR        *float64          `json:"current_r"`
+	ReasonSummary   string            `json:"reason_summary"`
+	ReasonFields    map[string]string `json:"reason_fields,omitempty"`
+	ReasonNotes     []string          `json:"reason_notes,omitempty"`
+	MitigationSteps []string          `json:"mitigation_steps,omitempty"`
+	ShedFraction    float64           `json:"shed_fraction"`
+	Deploy          *deployJSON       `json:"deploy,omitempty"`
+	Timestamp       time.Time         `json:"timestamp"`
+}
+
+// deployJSON holds an ActionBlockDeploy's gate fields. A ratio of ∞ (no
+// core work) encodes as null.
+type deployJSON struct {
+	ViolatedRatio                *float64 `json:"violated_ratio"`
+	RatioLimit                   float64  `json:"ratio_limit"`
+	DeltaCore                    float64  `json:"delta_core"`
+	DeltaComplexity              float64  `json:"delta_complexity"`
+	SuggestedCoreIncrease        float64  `json:"suggested_core_increase"`
+	SuggestedComplexityReduction float64  `json:"suggested_complexity_reduction"`
+}
+
+// deploy returns the gate fields for ActionBlockDeploy, nil otherwise.
+func (a Action) deploy() *deployJSON {
+	if a.Type != ActionBlockDeploy {
+		return nil
+	}
+	return &deployJSON{
+		ViolatedRatio:                finiteOrNil(a.ViolatedRatio),
+		RatioLimit:                   a.RatioLimit,
+		DeltaCore:                    a.DeltaCore,
+		DeltaComplexity:              a.DeltaComplexity,
+		SuggestedCoreIncrease:        a.SuggestedCoreIncrease,
+		SuggestedComplexityReduction: a.SuggestedComplexityReduction,
+	}
+}
+
+// MarshalJSON encodes the action as a flat object for structured logs:
+//
+//	{
+//	  "type": "THROTTLE",
+//	  "current_r": 3.2,
+//	  "reason_summary": "SATURATION DETECTED: r=3.2000 ≥ 3.0 (boundary)",
+//	  "reason_fields": {"saturation_depth": "0.2000", ...},
+//	  "reason_notes": ["System entered period-doubling cascade", ...],
+//	  "mitigation_steps": ["THROTTLE: Shed 50-70% of traffic immediately", ...],
+//	  "shed_fraction": 0.54,
+//	  "timestamp": "2025-01-02T15:04:05Z"
+//	}
+//
+// The multi-line Reason is split into its first line (the summary),
+// "Key: value" lines (fields, keyed in snake_case) and any other lines
+// (notes). Mitigation lines become steps with their numbering and section
+// headers dropped. current_r is Metrics.EstimatedCoupling, null when not
+// finite (ActionUnknown). A BLOCK_DEPLOY
+// action also carries a "deploy" object with its ratio, limit, deltas and
+// suggested fixes.
+func (a Action) MarshalJSON() ([]byte, error) {
+	summary, fields, notes := parseReason(a.Reason)
+	return json.Marshal(actionJSON{
+		Type:            a.Type,
+		CurrentR:        finiteOrNil(a.Metrics.EstimatedCoupling),
+		ReasonSummary:   summary,
+		ReasonFields:    fields,
+		ReasonNotes:     notes,
+		MitigationSteps: mitigationSteps(a.Mitigation),
+		ShedFraction:    a.ShedFraction,
+		Deploy:          a.deploy(),
+		Timestamp:       a.Timestamp,
+	})
+}
+
+// LogValue implements slog.LogValuer with the same fields as MarshalJSON,
+// so an action can be logged directly:
+//
+//	logger.Warn("governor decision", "action", action)
+func (a Action) LogValue() slog.Value {
+	summary, fields, notes := parseReason(a.Reason)
+
+	attrs := []slog.Attr{
+		slog.String("type", string(a.Type)),
+		slog.Float64("current_r", a.Metrics.EstimatedCoupling),
+		slog.String("reason_summary", summary),
+	}
+	if len(fields) > 0 {
+		reason := make([]any, 0, len(fields))
+		for _, k := range sortedKeys(fields) {
+			reason = append(reason, slog.String(k, fields[k]))
+		}
+		attrs = append(attrs, slog.Group("reason_fields", reason...))
+	}
+	if len(notes) > 0 {
+		attrs = append(attrs, slog.Any("reason_notes", notes))
+	}
+	if steps := mitigationSteps(a.Mitigation); len(steps) > 0 {
+		attrs = append(attrs, slog.Any("mitigation_steps", steps))
+	}
+	attrs = append(attrs, slog.Float64("shed_fraction", a.ShedFraction))
+	if a.Type == ActionBlockDeploy {
+		attrs = append(attrs, slog.Group("deploy",
+			slog.Float64("violated_ratio", a.ViolatedRatio),
+			slog.Float64("ratio_limit", a.RatioLimit),
+			slog.Float64("delta_core", a.DeltaCore),
+			slog.Float64("delta_complexity", a.DeltaComplexity),
+			slog.Float64("suggested_core_increase", a.SuggestedCoreIncrease),
+			slog.Float64("suggested_complexity_reduction", a.SuggestedComplexityReduction),
+		))
+	}
+	if !a.Timestamp.IsZero() {
+		attrs = append(attrs, slog.Time("timestamp", a.Timestamp))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// parseReason splits a governor Reason into its first line, "Key: value"
+// lines and free-text lines.
+func parseReason(reason string) (summary string, fields map[string]string, notes []string) {
+	lines := strings.Split(strings.TrimSpace(reason), "\n")
+	summary = strings.TrimSpace(lines[0])
+
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok || strings.ContainsAny(key, "=:") {
+			notes = append(notes, line)
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]string)
+		}
+		fields[snakeCase(key)] = strings.TrimSpace(value)
+	}
+
+	return summary, fields, notes
+}
+
+// mitigationSteps returns the non-empty lines of a Mitigation, without
+// section headers ("IMMEDIATE ACTIONS:") or "1. " numbering.
+func mitigationSteps(mitigation string) []string {
+	var steps []string
+	for _, line := range strings.Split(mitigation, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasSuffix(line, ":") {
+			continue
+		}
+		if number, rest, ok := strings.Cut(line, ". "); ok && isDigits(number) {
+			line = rest
+		}
+		steps = append(steps, line)
+	}
+	return steps
+}
+
+// snakeCase lowercases s and joins its runs of letters and digits with "_":
+// "Velocity (Δr/Δt)" → "velocity_δr_δt".
+func snakeCase(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.ToLower(strings.Join(words, "_"))
+}
+
+// isDigits reports whether s is a non-empty run of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedKeys returns m's keys in order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,127 @@
+package lawbench
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestAction_MarshalJSON_Throttle verifies a throttle decision encodes as a
+// flat object with the summary, parsed reason fields and mitigation steps.
+func TestAction_MarshalJSON_Throttle(t *testing.T) {
+	g := NewGovernor(2.0)
+	action := g.Update(3.2, 0.01, 0.001, 8)
+	if action.Type != ActionThrottle {
+		t.Fatalf("Expected THROTTLE at r=3.2, got %s", action.Type)
+	}
+
+	data, err := json.Marshal(action)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	keys := make([]string, 0, len(got))
+	for k := range got {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	want := []string{"current_r", "mitigation_steps", "reason_fields", "reason_notes",
+		"reason_summary", "shed_fraction", "timestamp", "type"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("Keys = %v, want %v", keys, want)
+	}
+
+	if got["type"] != "THROTTLE" || got["current_r"] != 3.2 {
+		t.Errorf("type/current_r = %v/%v, want THROTTLE/3.2", got["type"], got["current_r"])
+	}
+	if summary := got["reason_summary"].(string); !strings.HasPrefix(summary, "SATURATION DETECTED") || strings.Contains(summary, "\n") {
+		t.Errorf("Unexpected summary %q", summary)
+	}
+
+	fields := got["reason_fields"].(map[string]any)
+	if fields["saturation_depth"] != "0.2000" {
+		t.Errorf("saturation_depth = %v, want 0.2000 (fields: %v)", fields["saturation_depth"], fields)
+	}
+	if _, ok := fields["usl"]; !ok {
+		t.Errorf("Expected the Update USL line as a field, got %v", fields)
+	}
+
+	steps := got["mitigation_steps"].([]any)
+	if len(steps) == 0 || steps[0] != "THROTTLE: Shed 50-70% of traffic immediately" {
+		t.Errorf("Unexpected first step in %v", steps)
+	}
+
+	t.Logf("✓ %s", data)
+}
+
+// TestAction_MarshalJSON_BlockDeploy verifies a blocked deployment carries
+// its gate fields, with an infinite ratio encoded as null.
+func TestAction_MarshalJSON_BlockDeploy(t *testing.T) {
+	action := NewGovernor(2.0).CheckStructuralIntegrity(SystemIntegrityMetrics{
+		ImmutableOpsVerified: 100,
+		DeltaComplexity:      120,
+	})
+	if action.Type != ActionBlockDeploy {
+		t.Fatalf("Expected BLOCK_DEPLOY, got %s", action.Type)
+	}
+
+	data, err := json.Marshal(action)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var got struct {
+		Deploy map[string]any `json:"deploy"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got.Deploy == nil {
+		t.Fatalf("Expected a deploy object in %s", data)
+	}
+	if ratio, ok := got.Deploy["violated_ratio"]; !ok || ratio != nil {
+		t.Errorf("violated_ratio = %v, want null", ratio)
+	}
+	if got.Deploy["suggested_complexity_reduction"] != 120.0 || got.Deploy["delta_complexity"] != 120.0 {
+		t.Errorf("Unexpected deploy object %v", got.Deploy)
+	}
+}
+
+// TestAction_LogValue verifies an action logs through slog as a group with
+// the MarshalJSON fields.
+func TestAction_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	action := NewGovernor(2.0).Update(2.95, 0.01, 0.001, 8)
+	logger.Warn("governor decision", "action", action)
+
+	var record struct {
+		Msg    string         `json:"msg"`
+		Action map[string]any `json:"action"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Invalid log line %q: %v", buf.String(), err)
+	}
+
+	if record.Action["type"] != string(ActionPacing) || record.Action["current_r"] != 2.95 {
+		t.Errorf("type/current_r = %v/%v, want PACING/2.95", record.Action["type"], record.Action["current_r"])
+	}
+	if _, ok := record.Action["reason_fields"].(map[string]any)["distance_to_saturation"]; !ok {
+		t.Errorf("Expected reason_fields.distance_to_saturation, got %v", record.Action["reason_fields"])
+	}
+	if steps, _ := record.Action["mitigation_steps"].([]any); len(steps) != 5 {
+		t.Errorf("Expected 5 mitigation steps, got %v", record.Action["mitigation_steps"])
+	}
+
+	t.Logf("✓ %s", strings.TrimSpace(buf.String()))
+}
@@ -0,0 +1,108 @@
+package lawbench
+
+import "math"
+
+// DefaultAdaptiveAlpha is the EWMA weight of one observation in
+// AdaptiveGovernor.Learn: about ten consistent incidents move the learned
+// onset 90% of the way to where they happen.
+const DefaultAdaptiveAlpha = 0.2
+
+// AdaptiveGovernorConfig bounds how an AdaptiveGovernor learns.
+// Zero values select the defaults.
+type AdaptiveGovernorConfig struct {
+	Alpha     float64 // EWMA weight per observation (default: DefaultAdaptiveAlpha)
+	MinOnsetR float64 // Lowest onset it may learn (default: MaxR − 1.0, the throttle exit)
+	MaxOnsetR float64 // Highest onset it may learn (default: MaxR, the standard thresholds)
+}
+
+// AdaptiveGovernor is a Governor whose warning and danger thresholds follow
+// the r at which this system's tail latency actually explodes.
+//
+// The logistic-map boundary (r = 3.0) is where a model system saturates; a
+// real one with a shared pool or a slow dependency may blow up earlier. The
+// governor keeps an EWMA estimate of that onset and places danger 0.1 and
+// warning 0.2 below it, the same offsets the standard 2.9 / 2.8 keep from
+// 3.0. Saturation and throttle exit stay at the stability model's values.
+//
+// Like Governor, it is safe for concurrent use.
+type AdaptiveGovernor struct {
+	*Governor
+
+	alpha              float64
+	minOnset, maxOnset float64
+	onset              float64
+}
+
+// NewAdaptiveGovernor creates a governor that starts with the standard
+// thresholds for its stability model and learns from there.
+func NewAdaptiveGovernor(initialR float64, cfg AdaptiveGovernorConfig, opts ...GovernorOption) *AdaptiveGovernor {
+	g := NewGovernor(initialR, opts...)
+
+	if cfg.Alpha <= 0 || cfg.Alpha > 1 {
+		cfg.Alpha = DefaultAdaptiveAlpha
+	}
+	if cfg.MaxOnsetR <= 0 {
+		cfg.MaxOnsetR = g.model.MaxR
+	}
+	if cfg.MinOnsetR <= 0 {
+		cfg.MinOnsetR = g.throttleExitThreshold
+	}
+
+	a := &AdaptiveGovernor{
+		Governor: g,
+		alpha:    cfg.Alpha,
+		minOnset: cfg.MinOnsetR,
+		maxOnset: math.Max(cfg.MaxOnsetR, cfg.MinOnsetR),
+		onset:    g.saturationThreshold,
+	}
+	a.setOnset(a.onset)
+	return a
+}
+
+// Learn records whether the system blew up (tail divergence exploded) at
+// observedR and nudges the onset estimate by EWMA:
+//
+//   - A blowup below the current onset pulls it down toward observedR.
+//   - Running fine above the current onset lets it relax up toward observedR.
+//
+// Observations that agree with the current estimate (blowups above it,
+// calm below it) leave it unchanged. The onset stays within
+// [MinOnsetR, MaxOnsetR].
+func (a *AdaptiveGovernor) Learn(observedR float64, blewUp bool) {
+	if math.IsNaN(observedR) || math.IsInf(observedR, 0) {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if blewUp == (observedR < a.onset) {
+		a.setOnset(a.onset + a.alpha*(observedR-a.onset))
+	}
+}
+
+// LearnFromTail calls Learn with whether tracker currently reports a
+// power-law tail, i.e. whether the system blew up at r.
+func (a *AdaptiveGovernor) LearnFromTail(r float64, tracker *TailDivergenceTracker) {
+	a.Learn(r, tracker.IsPowerLaw())
+}
+
+// Onset returns the learned r at which the system blows up.
+func (a *AdaptiveGovernor) Onset() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.onset
+}
+
+// Thresholds returns the current warning and danger thresholds.
+func (a *AdaptiveGovernor) Thresholds() (warning, danger float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.warningThreshold, a.dangerThreshold
+}
+
+// setOnset clamps onset to the configured bounds and moves the thresholds.
+func (a *AdaptiveGovernor) setOnset(onset float64) {
+	a.onset = math.Max(a.minOnset, math.Min(onset, a.maxOnset))
+	a.dangerThreshold = a.onset - 0.1
+	a.warningThreshold = a.onset - 0.2
+}
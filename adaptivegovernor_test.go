@@ -0,0 +1,94 @@
+package lawbench
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestAdaptiveGovernor_LearnsEarlyOnset verifies repeated blowups at r=2.6
+// pull the danger threshold below 2.9, so the governor paces at r values the
+// standard thresholds call stable.
+func TestAdaptiveGovernor_LearnsEarlyOnset(t *testing.T) {
+	g := NewAdaptiveGovernor(2.0, AdaptiveGovernorConfig{})
+	if warning, danger := g.Thresholds(); warning != 2.8 || danger != 2.9 {
+		t.Fatalf("Expected standard 2.8/2.9 before learning, got %.2f/%.2f", warning, danger)
+	}
+
+	for i := 0; i < 20; i++ {
+		g.Learn(2.6, true)
+	}
+
+	warning, danger := g.Thresholds()
+	if danger >= 2.9 {
+		t.Errorf("Danger threshold %.4f should drop below 2.9", danger)
+	}
+	if math.Abs(g.Onset()-2.6) > 0.01 {
+		t.Errorf("Onset %.4f should converge to 2.6", g.Onset())
+	}
+	if math.Abs(danger-(g.Onset()-0.1)) > 1e-12 || math.Abs(warning-(g.Onset()-0.2)) > 1e-12 {
+		t.Errorf("Thresholds %.4f/%.4f should sit 0.2/0.1 below onset %.4f", warning, danger, g.Onset())
+	}
+
+	if action := g.Update(2.55, 0.01, 0.001, 8); action.Type != ActionPacing {
+		t.Errorf("Expected PACING at r=2.55 after learning, got %s", action.Type)
+	}
+	if action := NewGovernor(2.0).Update(2.55, 0.01, 0.001, 8); action.Type != ActionStable {
+		t.Errorf("Standard governor should be STABLE at r=2.55, got %s", action.Type)
+	}
+
+	t.Logf("✓ Onset %.4f: warning %.4f, danger %.4f", g.Onset(), warning, danger)
+}
+
+// TestAdaptiveGovernor_RelaxesAndBounds verifies calm observations above the
+// onset relax it, agreeing observations leave it alone, and the onset stays
+// within the configured bounds.
+func TestAdaptiveGovernor_RelaxesAndBounds(t *testing.T) {
+	g := NewAdaptiveGovernor(2.0, AdaptiveGovernorConfig{MinOnsetR: 2.4})
+
+	for i := 0; i < 50; i++ {
+		g.Learn(1.0, true)
+	}
+	if g.Onset() != 2.4 {
+		t.Errorf("Onset %.4f should clamp at MinOnsetR 2.4", g.Onset())
+	}
+
+	before := g.Onset()
+	g.Learn(3.5, true)  // Blowup above the onset: consistent
+	g.Learn(1.5, false) // Calm below it: consistent
+	if g.Onset() != before {
+		t.Errorf("Consistent observations moved the onset: %.4f → %.4f", before, g.Onset())
+	}
+
+	for i := 0; i < 50; i++ {
+		g.Learn(3.5, false)
+	}
+	if g.Onset() != 3.0 {
+		t.Errorf("Onset %.4f should relax up to MaxOnsetR (3.0)", g.Onset())
+	}
+}
+
+// TestAdaptiveGovernor_LearnFromTail verifies a power-law tracker counts as
+// a blowup and a Gaussian one as calm.
+func TestAdaptiveGovernor_LearnFromTail(t *testing.T) {
+	powerLaw := NewTailDivergenceTracker(1000)
+	gaussian := NewTailDivergenceTracker(1000)
+	for i := 0; i < 1000; i++ {
+		latency := 10 * time.Millisecond
+		if i%50 == 0 {
+			latency = 300 * time.Millisecond
+		}
+		powerLaw.Record(latency)
+		gaussian.Record(10 * time.Millisecond)
+	}
+
+	g := NewAdaptiveGovernor(2.0, AdaptiveGovernorConfig{})
+	g.LearnFromTail(2.6, gaussian)
+	if g.Onset() != 3.0 {
+		t.Errorf("Gaussian tail should not move the onset, got %.4f", g.Onset())
+	}
+	g.LearnFromTail(2.6, powerLaw)
+	if want := 3.0 + DefaultAdaptiveAlpha*(2.6-3.0); math.Abs(g.Onset()-want) > 1e-12 {
+		t.Errorf("Onset %.4f after one blowup, want %.4f", g.Onset(), want)
+	}
+}
@@ -2,6 +2,9 @@ package lawbench
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -21,6 +24,9 @@ type AssertionConfig struct {
 
 	// Maximum concurrency to test retrograde behavior
 	MaxN int
+
+	// Accept β < 0 (superlinear scaling) in AssertEfficiencyDecays
+	AllowSuperlinear bool
 }
 
 // DefaultAssertionConfig returns conservative thresholds.
@@ -34,6 +40,32 @@ func DefaultAssertionConfig() AssertionConfig {
 	}
 }
 
+// RegressionConfig contains per-coefficient tolerances for comparing a
+// benchmark run against a baseline.
+type RegressionConfig struct {
+	// Largest allowed absolute increase in α
+	MaxAlphaIncrease float64
+
+	// Largest allowed absolute increase in β
+	MaxBetaIncrease float64
+
+	// Largest allowed relative drop in λ (0.05 = 5%)
+	MaxLambdaDrop float64
+
+	// Largest allowed relative drop in N_peak below baseline (0 = none)
+	MaxPeakDrop float64
+}
+
+// DefaultRegressionConfig returns tolerances that absorb typical CI noise.
+func DefaultRegressionConfig() RegressionConfig {
+	return RegressionConfig{
+		MaxAlphaIncrease: 0.005, // Half a point of contention
+		MaxBetaIncrease:  0.001, // β is small; 0.001 moves N_peak a lot
+		MaxLambdaDrop:    0.10,  // 10% single-thread slowdown
+		MaxPeakDrop:      0.05,  // 5% earlier retrograde
+	}
+}
+
 // AssertZeroContention verifies α (contention coefficient) is near zero.
 //
 // Zero contention means the system is lock-free or uses efficient
@@ -42,14 +74,21 @@ func DefaultAssertionConfig() AssertionConfig {
 // Mathematical property:
 //
 //	∂C/∂N ≈ λ when α ≈ 0 (throughput grows linearly)
+//
+// α is fitted with FitUSLAboveNoise: an α whose effect is within the
+// standard error of the N=1 mean latency passes as 0, with a log note.
 func AssertZeroContention(t *testing.T, results []Result, cfg AssertionConfig) {
 	t.Helper()
 
-	coeffs, err := FitUSL(results)
+	coeffs, err := FitUSLAboveNoise(results)
 	if err != nil {
 		t.Fatalf("Failed to fit USL model: %v", err)
 	}
 
+	if coeffs.BelowNoiseFloor {
+		t.Logf("  α clamped to 0: its effect is below the N=1 latency noise (standard error %.3f)", NoiseFloor(results))
+	}
+
 	if coeffs.Alpha > cfg.MaxContention {
 		t.Errorf("Contention too high: α = %.6f (max: %.6f)\n"+
 			"System shows lock contention. Consider lock-free data structures.",
@@ -154,6 +193,12 @@ func AssertNoRetrograde(t *testing.T, results []Result, cfg AssertionConfig) {
 		t.Fatalf("Failed to fit USL model: %v", err)
 	}
 
+	// Compare adjacent levels in N order, whatever order results came in
+	results = append([]Result(nil), results...)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].N < results[j].N
+	})
+
 	var failures []string
 	for i := 1; i < len(results); i++ {
 		if results[i].N > cfg.MaxN {
@@ -179,6 +224,365 @@ func AssertNoRetrograde(t *testing.T, results []Result, cfg AssertionConfig) {
 	t.Logf("  α=%.6f, β=%.6f, R²=%.4f", coeffs.Alpha, coeffs.Beta, coeffs.RSquared)
 }
 
+// AssertEfficiencyDecays verifies efficiency C(N)/(λN) never rises with N.
+//
+// With β ≥ 0 every worker added costs something, so measured per-worker
+// throughput must be non-increasing. A fit with β < 0 claims the opposite:
+// efficiency growing without bound, i.e. infinite speedup. That is usually
+// a linearization artifact of noisy data rather than physics, so it is
+// flagged unless cfg.AllowSuperlinear is set (e.g. for workloads that
+// genuinely gain cache locality as they spread out). Levels are compared in
+// N order; a rise within EfficiencyJitterTolerance is not reported.
+//
+// Mathematical property:
+//
+//	C(N+1)/(λ(N+1)) ≤ C(N)/(λN) for all N ≤ MaxN, and β ≥ 0
+func AssertEfficiencyDecays(t *testing.T, results []Result, cfg AssertionConfig) {
+	t.Helper()
+
+	coeffs, err := FitUSL(results)
+	if err != nil {
+		t.Fatalf("Failed to fit USL model: %v", err)
+	}
+
+	if coeffs.Beta < 0 && !cfg.AllowSuperlinear {
+		t.Errorf("Superlinear fit: β = %.6f < 0 implies unbounded speedup\n"+
+			"Likely a fitting artifact of noisy data. Set AllowSuperlinear if intended.",
+			coeffs.Beta)
+	}
+
+	if !cfg.AllowSuperlinear {
+		if failures := efficiencyIncreases(results, coeffs, cfg.MaxN); len(failures) > 0 {
+			t.Errorf("Efficiency increased with N:\n%s\nα=%.6f, β=%.6f",
+				strings.Join(failures, "\n"), coeffs.Alpha, coeffs.Beta)
+		}
+	}
+
+	if cfg.AllowSuperlinear {
+		t.Logf("✓ Efficiency check skipped: superlinear scaling allowed")
+	} else {
+		t.Logf("✓ Efficiency decays: non-increasing up to N=%d", cfg.MaxN)
+	}
+	t.Logf("  α=%.6f, β=%.6f, R²=%.4f", coeffs.Alpha, coeffs.Beta, coeffs.RSquared)
+}
+
+// EfficiencyJitterTolerance is the relative rise in measured efficiency
+// AssertEfficiencyDecays ignores between adjacent levels: 0.1% is timing
+// jitter, not superlinear scaling.
+const EfficiencyJitterTolerance = 0.001
+
+// efficiencyIncreases lists each step N→N' (N' ≤ maxN) where measured
+// efficiency C(N)/(λN) went up by more than EfficiencyJitterTolerance.
+func efficiencyIncreases(results []Result, coeffs USLCoefficients, maxN int) []string {
+	efficiency := func(r Result) float64 {
+		return r.Throughput / (coeffs.Lambda * float64(r.N))
+	}
+
+	// Compare adjacent levels in N order, whatever order results came in
+	results = append([]Result(nil), results...)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].N < results[j].N
+	})
+
+	var failures []string
+	for i := 1; i < len(results); i++ {
+		prev, curr := results[i-1], results[i]
+		if curr.N > maxN {
+			break
+		}
+		if prev.N <= 0 || curr.N <= prev.N {
+			continue
+		}
+
+		if e0, e1 := efficiency(prev), efficiency(curr); e1 > e0*(1+EfficiencyJitterTolerance) {
+			failures = append(failures, fmt.Sprintf(
+				"  N=%d→%d: efficiency %.2f%% → %.2f%%",
+				prev.N, curr.N, e0*100, e1*100))
+		}
+	}
+	return failures
+}
+
+// AssertNoScalabilityRegression verifies current scales no worse than baseline.
+//
+// Both runs are fit with FitUSL. The assertion fails if α or β grew by more
+// than their tolerance, if λ dropped by more than MaxLambdaDrop, or if N_peak
+// fell more than MaxPeakDrop below the baseline's (a baseline that never
+// goes retrograde is held to MaxBetaIncrease only). Each regressed coefficient
+// is reported with its baseline value, current value and change, so a CI
+// failure says which law broke rather than just "slower".
+func AssertNoScalabilityRegression(t *testing.T, baseline, current []Result, cfg RegressionConfig) {
+	t.Helper()
+
+	base, err := FitUSL(baseline)
+	if err != nil {
+		t.Fatalf("Failed to fit USL model to baseline: %v", err)
+	}
+	curr, err := FitUSL(current)
+	if err != nil {
+		t.Fatalf("Failed to fit USL model to current run: %v", err)
+	}
+
+	if failures := scalabilityRegressions(base, curr, cfg); len(failures) > 0 {
+		t.Errorf("Scalability regressed against baseline:\n%s", strings.Join(failures, "\n"))
+		return
+	}
+
+	t.Logf("✓ No scalability regression")
+	t.Logf("  λ: %.2f → %.2f, α: %.6f → %.6f, β: %.6f → %.6f",
+		base.Lambda, curr.Lambda, base.Alpha, curr.Alpha, base.Beta, curr.Beta)
+	t.Logf("  N_peak: %.1f → %.1f", base.PeakConcurrency(), curr.PeakConcurrency())
+}
+
+// scalabilityRegressions lists each coefficient of curr that regressed
+// against base beyond its tolerance.
+func scalabilityRegressions(base, curr USLCoefficients, cfg RegressionConfig) []string {
+	var failures []string
+
+	if d := curr.Alpha - base.Alpha; d > cfg.MaxAlphaIncrease {
+		failures = append(failures, fmt.Sprintf(
+			"  α (contention): %.6f → %.6f (+%.6f, max +%.6f)",
+			base.Alpha, curr.Alpha, d, cfg.MaxAlphaIncrease))
+	}
+
+	if d := curr.Beta - base.Beta; d > cfg.MaxBetaIncrease {
+		failures = append(failures, fmt.Sprintf(
+			"  β (coordination): %.6f → %.6f (+%.6f, max +%.6f)",
+			base.Beta, curr.Beta, d, cfg.MaxBetaIncrease))
+	}
+
+	if base.Lambda > 0 {
+		if drop := (base.Lambda - curr.Lambda) / base.Lambda; drop > cfg.MaxLambdaDrop {
+			failures = append(failures, fmt.Sprintf(
+				"  λ (serial throughput): %.2f → %.2f ops/sec (-%.1f%%, max -%.1f%%)",
+				base.Lambda, curr.Lambda, drop*100, cfg.MaxLambdaDrop*100))
+		}
+	}
+
+	// A baseline with β = 0 never goes retrograde, so any β > 0 would drop
+	// N_peak from ∞; there the β tolerance alone decides.
+	basePeak, currPeak := base.PeakConcurrency(), curr.PeakConcurrency()
+	if !math.IsInf(basePeak, 1) && currPeak < basePeak*(1-cfg.MaxPeakDrop) {
+		failures = append(failures, fmt.Sprintf(
+			"  N_peak: %.1f → %.1f (-%.1f%%, max -%.1f%%)",
+			basePeak, currPeak, (basePeak-currPeak)/basePeak*100, cfg.MaxPeakDrop*100))
+	} else if math.IsInf(basePeak, 1) && !math.IsInf(currPeak, 1) && curr.Beta-base.Beta > cfg.MaxBetaIncrease {
+		failures = append(failures, fmt.Sprintf(
+			"  N_peak: ∞ → %.1f (baseline never went retrograde)", currPeak))
+	}
+
+	return failures
+}
+
+// AssertPeakAbove verifies the fitted N_peak is at least minN.
+//
+// Unlike AssertNoRetrograde, which only checks the measured levels, this
+// extrapolates the USL fit: a data structure benchmarked up to N=8 can still
+// guarantee it will not go retrograde before N=64.
+//
+// Mathematical property:
+//
+//	N_peak = sqrt((1-α)/β) ≥ minN
+func AssertPeakAbove(t *testing.T, results []Result, minN float64) {
+	t.Helper()
+
+	coeffs, err := FitUSL(results)
+	if err != nil {
+		t.Fatalf("Failed to fit USL model: %v", err)
+	}
+
+	peak := coeffs.PeakConcurrency()
+	if peak < minN {
+		t.Errorf("Peak concurrency too low: N_peak = %.1f (min: %.1f)\n"+
+			"Throughput goes retrograde at %.0f ops/sec. α=%.6f, β=%.6f",
+			peak, minN, coeffs.PeakThroughput(), coeffs.Alpha, coeffs.Beta)
+		return
+	}
+
+	t.Logf("✓ Peak above %.1f: N_peak = %.1f", minN, peak)
+	t.Logf("  α=%.6f, β=%.6f, R²=%.4f", coeffs.Alpha, coeffs.Beta, coeffs.RSquared)
+}
+
+// AssertStableCoupling verifies the fit keeps r below 3.0 up to maxN.
+//
+// r comes from CouplingR: coordination overhead (β) drives r up linearly in
+// N, so a system that benchmarks fine at low N can still be headed for the
+// period-doubling cascade at production concurrency.
+//
+// Mathematical property:
+//
+//	r(N) = 1 + 2α + 5βN < 3.0 for all N ≤ maxN
+func AssertStableCoupling(t *testing.T, coeffs USLCoefficients, maxN int) {
+	t.Helper()
+
+	for n := 1; n <= maxN; n++ {
+		if r := coeffs.CouplingR(n); r >= StableDNAConstraint.MaxR {
+			t.Errorf("Coupling unstable: r = %.4f ≥ %.1f at N=%d (max N: %d)\n"+
+				"Coordination overhead drives the system into the bifurcation cascade. α=%.6f, β=%.6f",
+				r, StableDNAConstraint.MaxR, n, maxN, coeffs.Alpha, coeffs.Beta)
+			return
+		}
+	}
+
+	t.Logf("✓ Stable coupling: r = %.4f at N=%d (< %.1f)", coeffs.CouplingR(maxN), maxN, StableDNAConstraint.MaxR)
+	t.Logf("  α=%.6f, β=%.6f", coeffs.Alpha, coeffs.Beta)
+}
+
+// AssertBoundedAllocs verifies heap allocation per operation stays under a budget.
+//
+// Allocation rate is a major driver of β: every byte allocated is GC work that
+// all workers share. A refactor that doubles allocations can leave throughput
+// looking fine at low N while quietly raising coordination overhead.
+//
+// Requires results produced with Config.MeasureAllocs enabled: when levels
+// ran operations but none reports any allocation the budget was never
+// checked, and the assertion fails rather than passing vacuously.
+func AssertBoundedAllocs(t *testing.T, results []Result, maxBytesPerOp float64) {
+	t.Helper()
+
+	if !hasAllocData(results) {
+		t.Errorf("No allocation data: every level reports 0 B/op and 0 allocs/op\n" +
+			"Run the benchmark with Config.MeasureAllocs enabled")
+		return
+	}
+
+	var failures []string
+	for _, r := range results {
+		if r.BytesPerOp > maxBytesPerOp {
+			failures = append(failures, fmt.Sprintf(
+				"  N=%d: %.1f B/op, %.2f allocs/op (max: %.1f B/op)",
+				r.N, r.BytesPerOp, r.AllocsPerOp, maxBytesPerOp))
+		}
+	}
+
+	if len(failures) > 0 {
+		t.Errorf("Allocation budget exceeded:\n%s", strings.Join(failures, "\n"))
+		return
+	}
+
+	t.Logf("✓ Bounded allocations: ≤ %.1f B/op at all levels", maxBytesPerOp)
+}
+
+// hasAllocData reports whether results can be held to an allocation budget:
+// false when some level ran operations yet no level recorded any allocation,
+// which is what results from a run without Config.MeasureAllocs look like.
+func hasAllocData(results []Result) bool {
+	ran := false
+	for _, r := range results {
+		if r.BytesPerOp > 0 || r.AllocsPerOp > 0 {
+			return true
+		}
+		ran = ran || r.Operations > 0
+	}
+	return !ran
+}
+
+// RecoveryEstimateTolerance is how many iterations past
+// EstimateRecoveryIterations AssertRecoveryWithinEstimate accepts.
+//
+// The estimate assumes a constant pulse, but ApplyRecovery corrects a
+// fraction of the remaining depth, so once below the 1/δ cap r approaches
+// 3.0 geometrically. With perfect isolation that tail adds up to ~11
+// iterations; 15 covers isolation ratios up to about 0.25.
+const RecoveryEstimateTolerance = 15
+
+// AssertRecoveryWithinEstimate verifies ApplyRecoveryUntilStable brings r
+// from initialR back below 3.0 within the iteration count the governor
+// quotes in its throttle reason (EstimateRecoveryIterations), plus
+// RecoveryEstimateTolerance.
+//
+// This guards the recovery model and the estimate against drifting apart.
+// Poor isolation legitimately fails: when mutable shared state rivals
+// immutable operations the correction factor collapses and recovery takes
+// hundreds of iterations. That is the "restart required" case
+// Governor.ApplyRecovery reports, not an estimation error.
+func AssertRecoveryWithinEstimate(t *testing.T, initialR float64, metrics SystemIntegrityMetrics) {
+	t.Helper()
+
+	actual, estimate, ok := recoveryWithinEstimate(initialR, metrics)
+	if !ok {
+		t.Errorf("Recovery exceeds estimate: r=%.4f still unstable after %d iterations (estimate %d + %d tolerance)\n"+
+			"Isolation ratio %.4f (mutable/immutable): poor isolation requires a restart",
+			initialR, actual-1, estimate, RecoveryEstimateTolerance,
+			float64(metrics.MutableSharedState)/float64(max(metrics.ImmutableOpsVerified, 1)))
+		return
+	}
+
+	t.Logf("✓ Recovery within estimate: r=%.4f stable after %d iterations (estimate %d, +%d tolerance)",
+		initialR, actual, estimate, RecoveryEstimateTolerance)
+}
+
+// recoveryWithinEstimate runs the recovery for AssertRecoveryWithinEstimate,
+// stopping one iteration past the tolerance. It returns the iterations used
+// and the estimate.
+func recoveryWithinEstimate(initialR float64, metrics SystemIntegrityMetrics) (actual, estimate int, ok bool) {
+	rd := NewRDynamics(initialR)
+	estimate = EstimateRecoveryIterations(initialR-StableDNAConstraint.MaxR, DefaultRecoveryCorrection)
+	limit := estimate + RecoveryEstimateTolerance
+
+	_, actual = rd.ApplyRecoveryUntilStable(metrics, limit+1)
+	return actual, estimate, actual <= limit && !rd.InSaturationZone
+}
+
+// RecoveryPulseTolerance is the ε AssertRecoveryPulseBounded allows over
+// 1/δ per step: floating-point slack, not a safety margin.
+const RecoveryPulseTolerance = 1e-9
+
+// recoveryPulseMaxSteps bounds each recovery AssertRecoveryPulseBounded
+// follows: poorly isolated systems approach the boundary asymptotically.
+const recoveryPulseMaxSteps = 100
+
+// AssertRecoveryPulseBounded verifies the core safety invariant of recovery:
+// every single ApplyRecovery step changes r by at most 1/δ
+// (CriticalityScalingRatio), for every combination of saturation depth
+// (r − 3.0) and metrics. A larger pulse is the "panic cascade" recovery
+// exists to avoid, so this guards ApplyRecovery against refactors that
+// would make it correct faster than is safe.
+//
+// Each recovery is followed until r leaves saturation, or for at most 100
+// steps.
+//
+//	lawbench.AssertRecoveryPulseBounded(t,
+//	    []float64{0.0001, 0.1, 0.5, 1.0},
+//	    []lawbench.SystemIntegrityMetrics{{ImmutableOpsVerified: 100}, ...})
+func AssertRecoveryPulseBounded(t *testing.T, depths []float64, metricsSet []SystemIntegrityMetrics) {
+	t.Helper()
+
+	if failures := recoveryPulseViolations(depths, metricsSet, (*RDynamics).ApplyRecovery); len(failures) > 0 {
+		t.Errorf("Recovery pulse exceeds 1/δ = %.4f:\n%s",
+			CriticalityScalingRatio, strings.Join(failures, "\n"))
+		return
+	}
+
+	t.Logf("✓ Recovery pulses bounded by 1/δ = %.4f across %d depths × %d metrics",
+		CriticalityScalingRatio, len(depths), len(metricsSet))
+}
+
+// recoveryPulseViolations runs recover from each depth with each metrics
+// and lists the first step of each run whose |Δr| exceeds 1/δ.
+func recoveryPulseViolations(depths []float64, metricsSet []SystemIntegrityMetrics,
+	recover func(*RDynamics, SystemIntegrityMetrics) float64) []string {
+	var failures []string
+	for _, depth := range depths {
+		for _, metrics := range metricsSet {
+			rd := NewRDynamics(StableDNAConstraint.MaxR + depth)
+			for step := 1; rd.InSaturationZone && step <= recoveryPulseMaxSteps; step++ {
+				before := rd.CurrentR
+				after := recover(&rd, metrics)
+				if pulse := math.Abs(after - before); pulse > CriticalityScalingRatio+RecoveryPulseTolerance {
+					failures = append(failures, fmt.Sprintf(
+						"  depth=%g isolation=%d/%d supervision=%d/%d step %d: r %.4f → %.4f (|Δr| = %.4f)",
+						depth, metrics.MutableSharedState, metrics.ImmutableOpsVerified,
+						metrics.UnsupervisedProcesses, metrics.SupervisedProcesses,
+						step, before, after, pulse))
+					break
+				}
+			}
+		}
+	}
+	return failures
+}
+
 // AssertScalability runs all scalability assertions with default config.
 func AssertScalability(t *testing.T, results []Result) {
 	t.Helper()
@@ -228,12 +632,19 @@ func PrintAnalysis(t *testing.T, results []Result) {
 			r.N, r.Throughput, predicted, efficiency*100)
 	}
 
+	plan := planCapacity(coeffs, []int{32, 64, 128})
 	t.Logf("\nCapacity Planning:")
-	for _, n := range []int{32, 64, 128} {
-		predicted := coeffs.PredictThroughput(n)
-		efficiency := coeffs.Efficiency(n)
-		t.Logf("  N=%-3d: %12.2f ops/sec (efficiency: %.1f%%)",
-			n, predicted, efficiency*100)
+	t.Logf("  N_peak = %.1f (%.2f ops/sec)", plan.PeakN, plan.PeakThroughput)
+	for _, level := range plan.Levels {
+		note := ""
+		if level.Retrograde {
+			note += "  ✗ retrograde (past N_peak)"
+		}
+		if level.Confidence < 1 {
+			note += fmt.Sprintf("  ⚠ extrapolated beyond N=%d (confidence %.0f%%)", coeffs.MaxMeasuredN, level.Confidence*100)
+		}
+		t.Logf("  N=%-3d: %12.2f ops/sec (efficiency: %.1f%%)%s",
+			level.N, level.Throughput, level.Efficiency*100, note)
 	}
 
 	// Interpret coefficients
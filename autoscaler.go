@@ -1,7 +1,10 @@
 package lawbench
 
 import (
+	"fmt"
 	"math"
+	"sync"
+	"time"
 )
 
 // ScalingDecision represents the autoscaler's action based on r-parameter.
@@ -13,6 +16,7 @@ const (
 	ScaleUp       ScalingDecision = "SCALE_UP"       // 2.5 ≤ r < 3.0 AND N < N_peak: Add capacity
 	ShedLoad      ScalingDecision = "SHED_LOAD"      // r ≥ 3.0 OR N ≥ N_peak: In retrograde zone
 	EmergencyStop ScalingDecision = "EMERGENCY_STOP" // r ≥ 4.0: System in saturation, stop scaling
+	ScaleUnknown  ScalingDecision = "UNKNOWN"        // r, α or β not finite: no decision possible
 )
 
 // AutoScalerMetrics contains system state for scaling decisions.
@@ -23,6 +27,26 @@ type AutoScalerMetrics struct {
 	Beta     float64 // USL coherency coefficient
 	Lambda   float64 // Serial performance (throughput at N=1)
 	TargetR  float64 // Desired r value (default: 2.0)
+
+	// Predictive scale-up (optional). When r is in The Pocket but
+	// R + RVelocity × ProvisioningLeadTime would reach 3.0, ShouldScale
+	// scales up now so new nodes are warm before saturation.
+	RVelocity            float64       // Δr/Δt per second (AutoScaler fills this from history if 0)
+	ProvisioningLeadTime time.Duration // Time for a new node to start serving
+
+	// CostPerNodeHour enables the absolute cost model (0 = disabled).
+	CostPerNodeHour float64
+
+	// ErrorRate is the fraction of failed requests in [0, 1] (optional).
+	// ShouldScale still acts on R; use CouplingR to derive R from the USL
+	// fit and the error rate when r is not measured directly.
+	ErrorRate float64
+}
+
+// CouplingR returns r at CurrentN from Alpha, Beta and ErrorRate (see
+// CouplingRWithErrors).
+func (m AutoScalerMetrics) CouplingR() float64 {
+	return CouplingRWithErrors(m.Alpha, m.Beta, m.CurrentN, m.ErrorRate)
 }
 
 // ScalingRecommendation provides detailed reasoning for the decision.
@@ -33,7 +57,21 @@ type ScalingRecommendation struct {
 	PeakN        float64 // Theoretical peak capacity point
 	InRetrograde bool    // True if currently in retrograde zone
 	CostSavings  float64 // Estimated cost savings (%) if scaling down
-	RiskLevel    string  // LOW, MEDIUM, HIGH, CRITICAL
+	RiskLevel    string  // LOW, MEDIUM, HIGH, CRITICAL (UNKNOWN with ScaleUnknown)
+
+	// Headroom. RetrogradeMargin is PeakN − CurrentN: the nodes that can
+	// still be added before the retrograde zone (negative once past the
+	// peak, +Inf when β ≤ 0). TimeToSaturation projects when r reaches the
+	// saturation boundary 3.0 at the current RVelocity; it is zero when r is
+	// not rising or already ≥ 3.0. The two are independent: r measures load
+	// on the current N, not distance to N_peak.
+	RetrogradeMargin float64
+	TimeToSaturation time.Duration
+
+	// Absolute cost model (populated when CostPerNodeHour > 0)
+	HourlyCostCurrent   float64 // CurrentN × CostPerNodeHour
+	HourlyCostTarget    float64 // TargetN × CostPerNodeHour
+	ThroughputPerDollar float64 // USL throughput at TargetN (ops/sec) per $/hour (needs Lambda)
 }
 
 // ShouldScale determines if and how to scale based on r-parameter and USL coefficients.
@@ -55,6 +93,8 @@ type ScalingRecommendation struct {
 //   - 1.5 ≤ r < 2.5: MAINTAIN (The Pocket - optimal efficiency)
 //   - 2.5 ≤ r < 3.0 AND N < N_peak: Scale UP (have headroom)
 //   - r ≥ 3.0 OR N ≥ N_peak: SHED LOAD (retrograde zone, don't add nodes)
+//   - In The Pocket but r + RVelocity × ProvisioningLeadTime ≥ 3.0 AND
+//     N < N_peak: Scale UP early (nodes need time to warm)
 //
 // Example:
 //
@@ -75,13 +115,8 @@ type ScalingRecommendation struct {
 func ShouldScale(m AutoScalerMetrics) ScalingRecommendation {
 	// Calculate theoretical peak capacity (where dC/dN = 0)
 	// From USL: C(N) = λN / (1 + α(N-1) + βN(N-1))
-	// Peak occurs at: N_peak = sqrt((1-α)/β)
-	var peakN float64
-	if m.Beta > 0 {
-		peakN = math.Sqrt((1 - m.Alpha) / m.Beta)
-	} else {
-		peakN = math.Inf(1) // No coherency penalty, no peak
-	}
+	// Peak occurs at: N_peak = sqrt((1-α)/β), +Inf without coherency penalty
+	peakN := CalculatePeakCapacity(m.Alpha, m.Beta)
 
 	// Check if we're in retrograde zone
 	inRetrograde := float64(m.CurrentN) >= peakN
@@ -93,8 +128,24 @@ func ShouldScale(m AutoScalerMetrics) ScalingRecommendation {
 	}
 
 	rec := ScalingRecommendation{
-		PeakN:        peakN,
-		InRetrograde: inRetrograde,
+		PeakN:            peakN,
+		InRetrograde:     inRetrograde,
+		RetrogradeMargin: peakN - float64(m.CurrentN),
+	}
+
+	// Non-finite input is UNKNOWN, as in the governor (see unknownAction);
+	// otherwise a broken r would fall through to SCALE_DOWN
+	if !isFinite(m.R) || math.IsNaN(m.Alpha) || math.IsNaN(m.Beta) {
+		rec.Decision = ScaleUnknown
+		rec.TargetN = m.CurrentN
+		rec.Reason = fmt.Sprintf("UNKNOWN: non-finite input (r=%v, α=%v, β=%v). "+
+			"Hold the current size and check the metrics pipeline.", m.R, m.Alpha, m.Beta)
+		rec.RiskLevel = "UNKNOWN"
+		return rec
+	}
+
+	if m.RVelocity > 0 && m.R < 3.0 {
+		rec.TimeToSaturation = time.Duration((3.0 - m.R) / m.RVelocity * float64(time.Second))
 	}
 
 	// Decision tree based on r-parameter
@@ -141,24 +192,42 @@ func ShouldScale(m AutoScalerMetrics) ScalingRecommendation {
 			targetN := int(math.Ceil(float64(m.CurrentN) * scaleFactor))
 
 			// Don't exceed 80% of peak capacity (safety margin)
-			maxSafeN := int(math.Floor(peakN * 0.8))
-			if targetN > maxSafeN {
-				targetN = maxSafeN
-			}
-
-			rec.TargetN = targetN
+			rec.TargetN = capBelowPeak(targetN, peakN)
 			rec.Reason = "STRESS: r approaching 3.0 boundary. Scale up to reduce load. " +
 				"Still have headroom before retrograde zone."
 			rec.RiskLevel = "MEDIUM"
 		}
 
 	case m.R >= 1.5 && m.R < 2.5:
-		// The Pocket - optimal operation
-		rec.Decision = Maintain
-		rec.TargetN = m.CurrentN
-		rec.Reason = "OPTIMAL: r in antifragile zone [1.5, 2.5]. No action needed. " +
-			"System operating at peak efficiency."
-		rec.RiskLevel = "LOW"
+		// The Pocket - optimal operation, unless r is rising fast enough to
+		// saturate before new nodes could be ready
+		projectedR := m.R + m.RVelocity*m.ProvisioningLeadTime.Seconds()
+
+		switch {
+		case m.RVelocity > 0 && projectedR >= 3.0 && !inRetrograde:
+			rec.Decision = ScaleUp
+			targetN := int(math.Ceil(float64(m.CurrentN) * projectedR / targetR))
+			rec.TargetN = clampToPeak(m.CurrentN, capBelowPeak(targetN, peakN), peakN)
+			rec.Reason = fmt.Sprintf("PREDICTIVE: r=%.2f rising at %.4f/s projects to r=%.2f within the %v "+
+				"provisioning lead time (≥ 3.0). Scale up now so capacity is warm before saturation.",
+				m.R, m.RVelocity, projectedR, m.ProvisioningLeadTime)
+			rec.RiskLevel = "MEDIUM"
+
+		case m.RVelocity > 0 && projectedR >= 3.0:
+			rec.Decision = Maintain
+			rec.TargetN = m.CurrentN
+			rec.Reason = fmt.Sprintf("RETROGRADE: r=%.2f projects to r=%.2f within %v, but N ≥ N_peak. "+
+				"Adding nodes would increase overhead. Prepare to shed load.",
+				m.R, projectedR, m.ProvisioningLeadTime)
+			rec.RiskLevel = "MEDIUM"
+
+		default:
+			rec.Decision = Maintain
+			rec.TargetN = m.CurrentN
+			rec.Reason = "OPTIMAL: r in antifragile zone [1.5, 2.5]. No action needed. " +
+				"System operating at peak efficiency."
+			rec.RiskLevel = "LOW"
+		}
 
 	case m.R < 1.5:
 		// Underutilized - wasting money
@@ -183,6 +252,128 @@ func ShouldScale(m AutoScalerMetrics) ScalingRecommendation {
 		rec.RiskLevel = "LOW"
 	}
 
+	applyCost(&rec, m)
+	if m.CostPerNodeHour > 0 && rec.TargetN != m.CurrentN {
+		rec.Reason += " " + costImpact(rec, m)
+	}
+
+	return rec
+}
+
+// applyCost fills the absolute cost model for rec.TargetN.
+func applyCost(rec *ScalingRecommendation, m AutoScalerMetrics) {
+	if m.CostPerNodeHour <= 0 {
+		return
+	}
+
+	rec.HourlyCostCurrent = float64(m.CurrentN) * m.CostPerNodeHour
+	rec.HourlyCostTarget = float64(rec.TargetN) * m.CostPerNodeHour
+	rec.ThroughputPerDollar = 0
+	if rec.HourlyCostTarget > 0 {
+		rec.ThroughputPerDollar = EstimateThroughput(rec.TargetN, m.Lambda, m.Alpha, m.Beta) / rec.HourlyCostTarget
+	}
+}
+
+// costImpact describes the dollar and throughput effect of moving from
+// CurrentN to TargetN, e.g. "Cost: +$0.82/hr for +12.3% throughput."
+func costImpact(rec ScalingRecommendation, m AutoScalerMetrics) string {
+	delta := rec.HourlyCostTarget - rec.HourlyCostCurrent
+	sign := "+"
+	if delta < 0 {
+		sign, delta = "-", -delta
+	}
+	impact := fmt.Sprintf("Cost: %s$%.2f/hr", sign, delta)
+
+	current := EstimateThroughput(m.CurrentN, m.Lambda, m.Alpha, m.Beta)
+	if current > 0 {
+		target := EstimateThroughput(rec.TargetN, m.Lambda, m.Alpha, m.Beta)
+		impact += fmt.Sprintf(" for %+.1f%% throughput", (target/current-1)*100)
+	}
+
+	return impact + "."
+}
+
+// AutoScaler wraps ShouldScale with anti-flapping state.
+//
+// A noisy r oscillating around a zone boundary makes the pure ShouldScale
+// alternate ScaleUp/Maintain/ScaleDown on every sample. AutoScaler only
+// acts on ScaleUp/ScaleDown once the same decision has been seen for
+// ConsecutiveChecks samples in a row, and then holds for CooldownPeriod.
+// ShedLoad and EmergencyStop are safety actions and pass through immediately.
+//
+// If m.RVelocity is 0, Decide derives it from the previous sample, enabling
+// predictive scale-up (see AutoScalerMetrics.ProvisioningLeadTime).
+//
+// Safe for concurrent use.
+type AutoScaler struct {
+	CooldownPeriod    time.Duration // Minimum time between scaling actions
+	ConsecutiveChecks int           // Samples a decision must persist (≤ 1 = act immediately)
+
+	mu         sync.Mutex
+	pending    ScalingDecision // Decision being confirmed
+	streak     int             // Consecutive samples of pending
+	lastAction time.Time       // When the last scaling action was returned
+	lastR      float64         // Previous sample, for RVelocity
+	lastAt     time.Time
+}
+
+// NewAutoScaler creates an AutoScaler with the given cooldown and
+// confirmation count.
+func NewAutoScaler(cooldown time.Duration, consecutiveChecks int) *AutoScaler {
+	return &AutoScaler{
+		CooldownPeriod:    cooldown,
+		ConsecutiveChecks: consecutiveChecks,
+	}
+}
+
+// Decide returns ShouldScale's recommendation, downgraded to Maintain while
+// a scaling decision is unconfirmed or during cooldown.
+func (a *AutoScaler) Decide(m AutoScalerMetrics, now time.Time) ScalingRecommendation {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if m.RVelocity == 0 && !a.lastAt.IsZero() {
+		if dt := now.Sub(a.lastAt).Seconds(); dt > 0 {
+			m.RVelocity = (m.R - a.lastR) / dt
+		}
+	}
+	a.lastR, a.lastAt = m.R, now
+
+	rec := ShouldScale(m)
+	if rec.Decision != ScaleUp && rec.Decision != ScaleDown {
+		a.pending, a.streak = "", 0
+		return rec
+	}
+
+	if rec.Decision == a.pending {
+		a.streak++
+	} else {
+		a.pending, a.streak = rec.Decision, 1
+	}
+
+	if !a.lastAction.IsZero() && now.Sub(a.lastAction) < a.CooldownPeriod {
+		remaining := a.CooldownPeriod - now.Sub(a.lastAction)
+		return hold(rec, m, fmt.Sprintf("COOLDOWN: %s suppressed, %v left since last scaling action.",
+			rec.Decision, remaining.Round(time.Second)))
+	}
+
+	if a.streak < a.ConsecutiveChecks {
+		return hold(rec, m, fmt.Sprintf("PENDING: %s seen %d/%d consecutive checks. Waiting for r to persist.",
+			rec.Decision, a.streak, a.ConsecutiveChecks))
+	}
+
+	a.pending, a.streak = "", 0
+	a.lastAction = now
+	return rec
+}
+
+// hold converts a scaling recommendation into Maintain with the given reason.
+func hold(rec ScalingRecommendation, m AutoScalerMetrics, reason string) ScalingRecommendation {
+	rec.Decision = Maintain
+	rec.TargetN = m.CurrentN
+	rec.CostSavings = 0
+	rec.Reason = reason
+	applyCost(&rec, m)
 	return rec
 }
 
@@ -282,3 +473,143 @@ func KubernetesHPATarget(currentReplicas int, currentR, targetR, alpha, beta flo
 	// K8s HPA expects a target replica count
 	return rec.TargetN
 }
+
+// KubernetesHPATargetClamped is KubernetesHPATarget bounded to the HPA's
+// [minReplicas, maxReplicas], reporting whether N_peak (not maxReplicas) is
+// what holds the replica count back.
+//
+// retrograde is true when there is scale-up pressure that adding replicas
+// cannot relieve: either the deployment is already at or past N_peak under
+// stress (2.5 ≤ r < 4.0), or a scale-up was capped at 80% of N_peak below
+// maxReplicas. A custom-metrics adapter should surface this as a distinct
+// "don't scale, you're past peak" signal; otherwise the HPA just pins at max.
+func KubernetesHPATargetClamped(current, minReplicas, maxReplicas int, currentR, targetR, alpha, beta float64) (replicas int, retrograde bool) {
+	rec := ShouldScale(AutoScalerMetrics{
+		R:        currentR,
+		CurrentN: current,
+		Alpha:    alpha,
+		Beta:     beta,
+		TargetR:  targetR,
+	})
+
+	// What ShouldScale's heuristic would ask for without the N_peak cap
+	if targetR == 0 {
+		targetR = 2.0
+	}
+	wanted := int(math.Ceil(float64(current) * currentR / targetR))
+
+	switch {
+	case currentR >= 2.5 && currentR < 4.0 && rec.InRetrograde:
+		retrograde = true
+	case rec.Decision == ScaleUp && rec.TargetN < wanted && rec.TargetN < maxReplicas:
+		retrograde = true
+	}
+
+	replicas = rec.TargetN
+	if replicas > maxReplicas {
+		replicas = maxReplicas
+	}
+	if replicas < minReplicas {
+		replicas = minReplicas
+	}
+	return replicas, retrograde
+}
+
+// AWSTargetCapacity calculates the desired capacity for an AWS Auto Scaling
+// group (e.g. SetDesiredCapacity), mirroring KubernetesHPATarget.
+//
+// The retrograde guard is explicit: the result never exceeds N_peak when
+// scaling up, no matter what the current r says.
+func AWSTargetCapacity(current int, currentR, targetR, alpha, beta float64) int {
+	rec := ShouldScale(AutoScalerMetrics{
+		R:        currentR,
+		CurrentN: current,
+		Alpha:    alpha,
+		Beta:     beta,
+		TargetR:  targetR,
+	})
+
+	return clampToPeak(current, rec.TargetN, rec.PeakN)
+}
+
+// StepAdjustment is one band of an AWS step scaling policy, as a plain struct
+// (no aws-sdk dependency). Map it to autoscaling types.StepAdjustment:
+// ±Inf bounds become nil, ScalingAdjustment is a ChangeInCapacity.
+type StepAdjustment struct {
+	MetricIntervalLowerBound float64 // Inclusive; -Inf = unbounded
+	MetricIntervalUpperBound float64 // Exclusive; +Inf = unbounded
+	ScalingAdjustment        int     // Nodes to add (negative = remove)
+}
+
+// AWSStepScalingPolicy translates ShouldScale into CloudWatch step-scaling
+// adjustments for the group's current size, one band per r zone:
+//
+//	r < 1.5        → ScaleDown (negative)
+//	1.5 ≤ r < 2.5  → Maintain (0)
+//	2.5 ≤ r < 3.0  → ScaleUp (+N, capped below N_peak)
+//	3.0 ≤ r < 4.0  → ShedLoad (0, or negative back to 80% of N_peak if retrograde)
+//	r ≥ 4.0        → EmergencyStop (0)
+//
+// Bounds are absolute r values: put the CloudWatch alarm on the r metric with
+// threshold 0 (GreaterThanOrEqualToThreshold) so AWS's threshold-relative
+// bounds coincide with r. Each band's adjustment is ShouldScale evaluated at
+// the band's midpoint (m.R is ignored); regenerate the policy when the group
+// size or the USL fit changes.
+func AWSStepScalingPolicy(m AutoScalerMetrics) []StepAdjustment {
+	bands := []struct {
+		lower, upper float64
+		sampleR      float64 // r at which the band's decision is evaluated
+	}{
+		{math.Inf(-1), 1.5, 0.75},
+		{1.5, 2.5, 2.0},
+		{2.5, 3.0, 2.75},
+		{3.0, 4.0, 3.5},
+		{4.0, math.Inf(1), 4.0},
+	}
+
+	steps := make([]StepAdjustment, 0, len(bands))
+	for _, band := range bands {
+		bandMetrics := m
+		bandMetrics.R = band.sampleR
+		rec := ShouldScale(bandMetrics)
+
+		target := clampToPeak(m.CurrentN, rec.TargetN, rec.PeakN)
+		steps = append(steps, StepAdjustment{
+			MetricIntervalLowerBound: band.lower,
+			MetricIntervalUpperBound: band.upper,
+			ScalingAdjustment:        target - m.CurrentN,
+		})
+	}
+
+	return steps
+}
+
+// capBelowPeak caps a scale-up target at 80% of N_peak, the safety margin.
+// Without a coherency penalty (β ≤ 0) N_peak is +Inf and nothing is capped.
+func capBelowPeak(target int, peakN float64) int {
+	if math.IsInf(peakN, 1) {
+		return target
+	}
+	return min(target, int(math.Floor(peakN*0.8)))
+}
+
+// clampToPeak is the retrograde guard: a scale-up never goes past N_peak.
+// A negative target (an overflowed float-to-int conversion) is rejected and
+// the current size kept.
+func clampToPeak(current, target int, peakN float64) int {
+	if target < 0 {
+		return current
+	}
+	if target <= current || math.IsInf(peakN, 1) {
+		return target
+	}
+
+	maxN := int(math.Floor(peakN))
+	if maxN < current {
+		return current // Already past peak: never add nodes
+	}
+	if target > maxN {
+		return maxN
+	}
+	return target
+}
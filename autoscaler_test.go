@@ -2,7 +2,9 @@ package lawbench
 
 import (
 	"math"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestShouldScale_Underutilized(t *testing.T) {
@@ -83,6 +85,13 @@ func TestShouldScale_StressWithHeadroom(t *testing.T) {
 	t.Logf("✓ Stress + Headroom: Scale from %d to %d nodes (peak capacity: %.1f)",
 		metrics.CurrentN, rec.TargetN, rec.PeakN)
 	t.Logf("  Reason: %s", rec.Reason)
+
+	// Without a coherency penalty N_peak is +Inf: the 80% cap must not apply
+	metrics.Beta = 0
+	rec = ShouldScale(metrics)
+	if rec.Decision != ScaleUp || rec.TargetN != 7 {
+		t.Errorf("β = 0: expected ScaleUp to 7 (⌈5 × 2.8/2.0⌉), got %s to %d", rec.Decision, rec.TargetN)
+	}
 }
 
 func TestShouldScale_RetrogradeZone(t *testing.T) {
@@ -172,6 +181,32 @@ func TestShouldScale_EmergencyCritical(t *testing.T) {
 	t.Logf("  Risk: %s", rec.RiskLevel)
 }
 
+func TestShouldScale_NonFiniteInputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics AutoScalerMetrics
+	}{
+		{"NaN r", AutoScalerMetrics{R: math.NaN(), CurrentN: 20, Alpha: 0.05, Beta: 0.01}},
+		{"Inf r", AutoScalerMetrics{R: math.Inf(1), CurrentN: 20, Alpha: 0.05, Beta: 0.01}},
+		{"NaN β", AutoScalerMetrics{R: 2.0, CurrentN: 20, Alpha: 0.05, Beta: math.NaN()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := ShouldScale(tt.metrics)
+
+			if rec.Decision != ScaleUnknown || rec.RiskLevel != "UNKNOWN" {
+				t.Errorf("Expected UNKNOWN decision and risk, got %v/%s", rec.Decision, rec.RiskLevel)
+			}
+			if rec.TargetN != tt.metrics.CurrentN {
+				t.Errorf("Unknown should hold node count %d, got %d", tt.metrics.CurrentN, rec.TargetN)
+			}
+
+			t.Logf("✓ %s", rec.Reason)
+		})
+	}
+}
+
 func TestCalculatePeakCapacity(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -312,17 +347,22 @@ func TestIsRetrograde(t *testing.T) {
 
 func TestKubernetesHPATarget(t *testing.T) {
 	tests := []struct {
-		name            string
-		currentReplicas int
-		currentR        float64
-		targetR         float64
-		alpha, beta     float64
-		expectChange    bool
-		expectDirection string // "up", "down", "maintain"
+		name             string
+		currentReplicas  int
+		minReplicas      int
+		maxReplicas      int
+		currentR         float64
+		targetR          float64
+		alpha, beta      float64
+		expectChange     bool
+		expectDirection  string // "up", "down", "maintain"
+		expectRetrograde bool
 	}{
 		{
 			name:            "Underutilized - scale down",
 			currentReplicas: 10,
+			minReplicas:     1,
+			maxReplicas:     100,
 			currentR:        1.2,
 			targetR:         2.0,
 			alpha:           0.05,
@@ -333,6 +373,8 @@ func TestKubernetesHPATarget(t *testing.T) {
 		{
 			name:            "Optimal - maintain",
 			currentReplicas: 10,
+			minReplicas:     1,
+			maxReplicas:     100,
 			currentR:        2.0,
 			targetR:         2.0,
 			alpha:           0.05,
@@ -343,6 +385,32 @@ func TestKubernetesHPATarget(t *testing.T) {
 		{
 			name:            "Stressed - scale up",
 			currentReplicas: 5,
+			minReplicas:     1,
+			maxReplicas:     100,
+			currentR:        2.8,
+			targetR:         2.0,
+			alpha:           0.05,
+			beta:            0.01,
+			expectChange:    true,
+			expectDirection: "up",
+		},
+		{
+			name:            "Underutilized - held at minReplicas",
+			currentReplicas: 10,
+			minReplicas:     10,
+			maxReplicas:     100,
+			currentR:        1.2,
+			targetR:         2.0,
+			alpha:           0.05,
+			beta:            0.01,
+			expectChange:    false,
+			expectDirection: "maintain",
+		},
+		{
+			name:            "Stressed - capped by maxReplicas",
+			currentReplicas: 5,
+			minReplicas:     1,
+			maxReplicas:     6,
 			currentR:        2.8,
 			targetR:         2.0,
 			alpha:           0.05,
@@ -350,12 +418,64 @@ func TestKubernetesHPATarget(t *testing.T) {
 			expectChange:    true,
 			expectDirection: "up",
 		},
+		{
+			name:             "Stressed - capped by N_peak below maxReplicas",
+			currentReplicas:  6,
+			minReplicas:      1,
+			maxReplicas:      50,
+			currentR:         2.9,
+			targetR:          2.0,
+			alpha:            0.05,
+			beta:             0.01, // N_peak ≈ 9.7, 80% cap = 7
+			expectChange:     true,
+			expectDirection:  "up",
+			expectRetrograde: true,
+		},
+		{
+			name:             "Retrograde - past N_peak, don't scale",
+			currentReplicas:  10,
+			minReplicas:      2,
+			maxReplicas:      50,
+			currentR:         2.8,
+			targetR:          2.0,
+			alpha:            0.05,
+			beta:             0.01,
+			expectChange:     false,
+			expectDirection:  "maintain",
+			expectRetrograde: true,
+		},
+		{
+			name:            "Stressed, no N_peak (β = 0) - scale up",
+			currentReplicas: 4,
+			minReplicas:     1,
+			maxReplicas:     10,
+			currentR:        2.7,
+			targetR:         2.0,
+			alpha:           0.05,
+			beta:            0, // N_peak = +Inf: nothing to cap at
+			expectChange:    true,
+			expectDirection: "up",
+		},
+		{
+			name:            "Stressed, negative β - scale up",
+			currentReplicas: 4,
+			minReplicas:     1,
+			maxReplicas:     10,
+			currentR:        2.7,
+			targetR:         2.0,
+			alpha:           0.05,
+			beta:            -0.001, // Superlinear fit noise, treated as β = 0
+			expectChange:    true,
+			expectDirection: "up",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			targetReplicas := KubernetesHPATarget(
+			targetReplicas, retrograde := KubernetesHPATargetClamped(
 				tt.currentReplicas,
+				tt.minReplicas,
+				tt.maxReplicas,
 				tt.currentR,
 				tt.targetR,
 				tt.alpha,
@@ -382,8 +502,372 @@ func TestKubernetesHPATarget(t *testing.T) {
 				t.Errorf("Expected direction=%s, got %s", tt.expectDirection, actualDirection)
 			}
 
-			t.Logf("✓ %s: %d → %d replicas (r=%.1f → target=%.1f)",
-				tt.name, tt.currentReplicas, targetReplicas, tt.currentR, tt.targetR)
+			if retrograde != tt.expectRetrograde {
+				t.Errorf("Expected retrograde=%v, got %v", tt.expectRetrograde, retrograde)
+			}
+
+			if targetReplicas < tt.minReplicas || targetReplicas > tt.maxReplicas {
+				t.Errorf("Target %d outside [%d, %d]", targetReplicas, tt.minReplicas, tt.maxReplicas)
+			}
+
+			// Strictly inside the bounds, clamping must not change the answer
+			if targetReplicas > tt.minReplicas && targetReplicas < tt.maxReplicas {
+				unclamped := KubernetesHPATarget(tt.currentReplicas, tt.currentR, tt.targetR, tt.alpha, tt.beta)
+				if unclamped != targetReplicas {
+					t.Errorf("Unclamped target %d disagrees with clamped %d", unclamped, targetReplicas)
+				}
+			}
+
+			t.Logf("✓ %s: %d → %d replicas (r=%.1f → target=%.1f, retrograde=%v)",
+				tt.name, tt.currentReplicas, targetReplicas, tt.currentR, tt.targetR, retrograde)
+		})
+	}
+}
+
+// TestAutoScaler_SpikeDoesNotScale verifies a single-sample r spike does not
+// trigger scale-up until it is sustained, and cooldown holds afterwards.
+func TestAutoScaler_SpikeDoesNotScale(t *testing.T) {
+	scaler := NewAutoScaler(5*time.Minute, 3)
+	start := time.Unix(0, 0)
+
+	steps := []struct {
+		r    float64
+		want ScalingDecision
+	}{
+		{2.0, Maintain},
+		{2.8, Maintain}, // Spike: 1/3
+		{2.0, Maintain}, // Spike gone, streak reset
+		{2.8, Maintain}, // 1/3
+		{2.8, Maintain}, // 2/3
+		{2.8, ScaleUp},  // 3/3: sustained
+		{2.8, Maintain}, // Cooldown
+		{2.8, Maintain},
+		{1.2, Maintain}, // Opposite direction also held by cooldown
+		{3.5, ShedLoad}, // Safety actions pass through immediately
+	}
+
+	for i, step := range steps {
+		now := start.Add(time.Duration(i) * time.Minute)
+		m := AutoScalerMetrics{R: step.r, CurrentN: 5, Alpha: 0.05, Beta: 0.01, TargetR: 2.0}
+		rec := scaler.Decide(m, now)
+
+		if rec.Decision != step.want {
+			t.Errorf("t=%dm r=%.1f: got %s, want %s (%s)", i, step.r, rec.Decision, step.want, rec.Reason)
+		}
+		if rec.Decision == Maintain && rec.TargetN != m.CurrentN {
+			t.Errorf("t=%dm: held decision should keep TargetN=%d, got %d", i, m.CurrentN, rec.TargetN)
+		}
+		t.Logf("  t=%2dm r=%.1f → %-9s %s", i, step.r, rec.Decision, rec.Reason)
+	}
+
+	// After cooldown, a sustained low r scales down
+	for i := 0; i < 3; i++ {
+		now := start.Add(time.Duration(20+i) * time.Minute)
+		rec := scaler.Decide(AutoScalerMetrics{R: 1.2, CurrentN: 5, Alpha: 0.05, Beta: 0.01, TargetR: 2.0}, now)
+		want := Maintain
+		if i == 2 {
+			want = ScaleDown
+		}
+		if rec.Decision != want {
+			t.Errorf("After cooldown, check %d: got %s, want %s", i+1, rec.Decision, want)
+		}
+	}
+}
+
+// TestAutoScaler_NoConfirmation verifies ConsecutiveChecks ≤ 1 with no
+// cooldown behaves exactly like ShouldScale.
+func TestAutoScaler_NoConfirmation(t *testing.T) {
+	scaler := NewAutoScaler(0, 1)
+	now := time.Unix(0, 0)
+
+	for _, r := range []float64{1.2, 2.0, 2.8, 3.5, 4.2, 2.8, 1.2} {
+		// RVelocity is pinned so Decide does not derive it from the history.
+		m := AutoScalerMetrics{R: r, CurrentN: 5, Alpha: 0.05, Beta: 0.01, TargetR: 2.0, RVelocity: 0.001}
+		if got, want := scaler.Decide(m, now), ShouldScale(m); got != want {
+			t.Errorf("r=%.1f: Decide=%+v, ShouldScale=%+v", r, got, want)
+		}
+		now = now.Add(time.Minute)
+	}
+}
+
+// TestShouldScale_Predictive verifies a rising r in The Pocket triggers an
+// early scale-up, while a flat r or the retrograde zone does not.
+func TestShouldScale_Predictive(t *testing.T) {
+	tests := []struct {
+		name     string
+		currentN int
+		beta     float64
+		velocity float64 // Δr per second
+		want     ScalingDecision
+	}{
+		{"Flat r", 5, 0.01, 0, Maintain},
+		{"Slowly rising (projects to 2.5)", 5, 0.01, 0.001, Maintain},
+		{"Rising fast (projects to 3.4)", 5, 0.01, 0.01, ScaleUp},
+		{"Rising fast but retrograde", 12, 0.01, 0.01, Maintain},
+		{"Rising fast, no N_peak (β = 0)", 5, 0, 0.01, ScaleUp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := AutoScalerMetrics{
+				R:                    2.2,
+				CurrentN:             tt.currentN,
+				Alpha:                0.05,
+				Beta:                 tt.beta,
+				TargetR:              2.0,
+				RVelocity:            tt.velocity,
+				ProvisioningLeadTime: 2 * time.Minute,
+			}
+			rec := ShouldScale(m)
+
+			if rec.Decision != tt.want {
+				t.Errorf("Expected %s, got %s (%s)", tt.want, rec.Decision, rec.Reason)
+			}
+			if rec.Decision == ScaleUp {
+				if !strings.Contains(rec.Reason, "projects to r=3.40") {
+					t.Errorf("Reason should explain the projection: %s", rec.Reason)
+				}
+				if rec.TargetN <= tt.currentN || float64(rec.TargetN) > rec.PeakN {
+					t.Errorf("TargetN=%d should grow but stay ≤ N_peak=%.1f", rec.TargetN, rec.PeakN)
+				}
+			}
+			t.Logf("✓ %s: %s → %d (%s)", tt.name, rec.Decision, rec.TargetN, rec.Reason)
+		})
+	}
+}
+
+// TestAutoScaler_PredictsFromHistory verifies the stateful scaler derives
+// r velocity from successive samples: rising r scales up early, flat does not.
+func TestAutoScaler_PredictsFromHistory(t *testing.T) {
+	tests := []struct {
+		name string
+		rs   []float64
+		want ScalingDecision
+	}{
+		{"Rising", []float64{1.8, 2.0, 2.2}, ScaleUp},
+		{"Flat", []float64{2.2, 2.2, 2.2}, Maintain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scaler := NewAutoScaler(0, 1)
+			now := time.Unix(0, 0)
+
+			var rec ScalingRecommendation
+			for _, r := range tt.rs {
+				rec = scaler.Decide(AutoScalerMetrics{
+					R:                    r,
+					CurrentN:             5,
+					Alpha:                0.05,
+					Beta:                 0.01,
+					TargetR:              2.0,
+					ProvisioningLeadTime: 3 * time.Minute,
+				}, now)
+				now = now.Add(30 * time.Second)
+			}
+
+			if rec.Decision != tt.want {
+				t.Errorf("Expected %s after %v, got %s (%s)", tt.want, tt.rs, rec.Decision, rec.Reason)
+			}
+		})
+	}
+}
+
+// TestShouldScale_CostModel verifies absolute costs are populated on every
+// decision, including ScaleUp where cost increases.
+func TestShouldScale_CostModel(t *testing.T) {
+	tests := []struct {
+		name       string
+		r          float64
+		want       ScalingDecision
+		wantReason string
+	}{
+		{"Scale up costs more", 2.8, ScaleUp, "Cost: +$"},
+		{"Scale down saves", 1.2, ScaleDown, "Cost: -$"},
+		{"Maintain", 2.0, Maintain, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := AutoScalerMetrics{R: tt.r, CurrentN: 5, Alpha: 0.05, Beta: 0.01, Lambda: 1000, TargetR: 2.0, CostPerNodeHour: 0.5}
+			rec := ShouldScale(m)
+
+			if rec.Decision != tt.want {
+				t.Fatalf("Expected %s, got %s", tt.want, rec.Decision)
+			}
+			if rec.HourlyCostCurrent != 2.5 || rec.HourlyCostTarget != float64(rec.TargetN)*0.5 {
+				t.Errorf("Costs: current=$%.2f target=$%.2f (TargetN=%d)", rec.HourlyCostCurrent, rec.HourlyCostTarget, rec.TargetN)
+			}
+			if rec.ThroughputPerDollar <= 0 {
+				t.Errorf("ThroughputPerDollar should be positive, got %.2f", rec.ThroughputPerDollar)
+			}
+			if tt.wantReason != "" && !strings.Contains(rec.Reason, tt.wantReason) {
+				t.Errorf("Reason should contain %q: %s", tt.wantReason, rec.Reason)
+			}
+			t.Logf("✓ %s: %s", tt.name, rec.Reason)
+		})
+	}
+}
+
+// TestShouldScale_RetrogradeMargin verifies the distance to N_peak and the
+// projected time until r reaches 3.0.
+func TestShouldScale_RetrogradeMargin(t *testing.T) {
+	// α=0, β=0.01 → N_peak = 10
+	tests := []struct {
+		name          string
+		currentN      int
+		beta          float64
+		rVelocity     float64
+		wantMargin    float64
+		wantRetro     bool
+		wantTimeToSat time.Duration
+	}{
+		{"Below peak", 7, 0.01, 0.01, 3, false, 50 * time.Second},
+		{"At peak", 10, 0.01, 0, 0, true, 0},
+		{"Past peak", 14, 0.01, 0, -4, true, 0},
+		{"Falling r", 7, 0.01, -0.01, 3, false, 0},
+		{"No coherency penalty", 50, 0, 0.01, math.Inf(1), false, 50 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := ShouldScale(AutoScalerMetrics{
+				R:         2.5,
+				CurrentN:  tt.currentN,
+				Beta:      tt.beta,
+				RVelocity: tt.rVelocity,
+			})
+
+			if math.IsInf(tt.wantMargin, 1) {
+				if !math.IsInf(rec.RetrogradeMargin, 1) {
+					t.Errorf("Expected +Inf margin, got %.2f", rec.RetrogradeMargin)
+				}
+			} else if math.Abs(rec.RetrogradeMargin-tt.wantMargin) > 1e-9 {
+				t.Errorf("Expected margin %.2f, got %.2f", tt.wantMargin, rec.RetrogradeMargin)
+			}
+			if rec.InRetrograde != tt.wantRetro {
+				t.Errorf("Expected InRetrograde=%v, got %v", tt.wantRetro, rec.InRetrograde)
+			}
+			if d := rec.TimeToSaturation - tt.wantTimeToSat; d < -time.Millisecond || d > time.Millisecond {
+				t.Errorf("Expected time to saturation %v, got %v", tt.wantTimeToSat, rec.TimeToSaturation)
+			}
+
+			t.Logf("✓ %s: margin %.1f nodes, time to saturation %v", tt.name, rec.RetrogradeMargin, rec.TimeToSaturation)
+		})
+	}
+}
+
+// TestAWSTargetCapacity mirrors TestKubernetesHPATarget for AWS Auto Scaling.
+func TestAWSTargetCapacity(t *testing.T) {
+	tests := []struct {
+		name            string
+		current         int
+		currentR        float64
+		targetR         float64
+		alpha, beta     float64
+		expectDirection string // "up", "down", "maintain"
+	}{
+		{"Underutilized - scale down", 10, 1.2, 2.0, 0.05, 0.01, "down"},
+		{"Optimal - maintain", 10, 2.0, 2.0, 0.05, 0.01, "maintain"},
+		{"Stressed - scale up", 5, 2.8, 2.0, 0.05, 0.01, "up"},
+		{"Stressed but retrograde - no scale up", 12, 2.8, 2.0, 0.05, 0.01, "maintain"},
+		{"Stressed, no N_peak (β = 0) - scale up", 4, 2.7, 2.0, 0.05, 0, "up"},
+		{"Underutilized, no N_peak (β = 0) - scale down", 10, 1.2, 2.0, 0.05, 0, "down"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capacity := AWSTargetCapacity(tt.current, tt.currentR, tt.targetR, tt.alpha, tt.beta)
+
+			var actualDirection string
+			if capacity > tt.current {
+				actualDirection = "up"
+			} else if capacity < tt.current {
+				actualDirection = "down"
+			} else {
+				actualDirection = "maintain"
+			}
+
+			if actualDirection != tt.expectDirection {
+				t.Errorf("Expected direction=%s, got %s (%d → %d)", tt.expectDirection, actualDirection, tt.current, capacity)
+			}
+
+			peakN := CalculatePeakCapacity(tt.alpha, tt.beta)
+			if capacity > tt.current && float64(capacity) > peakN {
+				t.Errorf("Scaled up past N_peak: %d > %.1f", capacity, peakN)
+			}
+			if capacity < 1 || capacity > 2*tt.current {
+				t.Errorf("Capacity %d out of range for %d instances", capacity, tt.current)
+			}
+
+			t.Logf("✓ %s: %d → %d instances (r=%.1f, N_peak=%.1f)",
+				tt.name, tt.current, capacity, tt.currentR, peakN)
+		})
+	}
+
+	if got := clampToPeak(4, math.MinInt64, math.Inf(1)); got != 4 {
+		t.Errorf("An overflowed target should keep the current size, got %d", got)
+	}
+}
+
+// TestAWSStepScalingPolicy verifies the step bands cover r contiguously and
+// never add capacity past N_peak.
+func TestAWSStepScalingPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		currentN int
+		beta     float64
+		wantUp   bool // Expect a positive adjustment in the 2.5-3.0 band
+		wantShed bool // Expect a negative adjustment in the 3.0-4.0 band
+	}{
+		{"Headroom", 5, 0.01, true, false},
+		{"Retrograde", 12, 0.01, false, true},
+		{"No N_peak (β = 0)", 4, 0, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := AutoScalerMetrics{CurrentN: tt.currentN, Alpha: 0.05, Beta: tt.beta, TargetR: 2.0}
+			steps := AWSStepScalingPolicy(m)
+			peakN := CalculatePeakCapacity(m.Alpha, m.Beta)
+
+			if len(steps) != 5 {
+				t.Fatalf("Expected 5 bands, got %d", len(steps))
+			}
+			if !math.IsInf(steps[0].MetricIntervalLowerBound, -1) || !math.IsInf(steps[len(steps)-1].MetricIntervalUpperBound, 1) {
+				t.Errorf("Outer bands should be unbounded: %+v", steps)
+			}
+			for i := 1; i < len(steps); i++ {
+				if steps[i].MetricIntervalLowerBound != steps[i-1].MetricIntervalUpperBound {
+					t.Errorf("Gap between bands %d and %d", i-1, i)
+				}
+			}
+
+			for _, s := range steps {
+				if s.ScalingAdjustment > 0 && float64(tt.currentN+s.ScalingAdjustment) > peakN {
+					t.Errorf("Band [%.1f, %.1f) scales past N_peak: %d + %d > %.1f",
+						s.MetricIntervalLowerBound, s.MetricIntervalUpperBound, tt.currentN, s.ScalingAdjustment, peakN)
+				}
+				if n := tt.currentN + s.ScalingAdjustment; n < 1 || n > 2*tt.currentN {
+					t.Errorf("Band [%.1f, %.1f) adjusts %d by %+d, out of range",
+						s.MetricIntervalLowerBound, s.MetricIntervalUpperBound, tt.currentN, s.ScalingAdjustment)
+				}
+				t.Logf("  r ∈ [%5.1f, %5.1f): %+d", s.MetricIntervalLowerBound, s.MetricIntervalUpperBound, s.ScalingAdjustment)
+			}
+
+			if steps[0].ScalingAdjustment >= 0 {
+				t.Errorf("r < 1.5 should scale down, got %+d", steps[0].ScalingAdjustment)
+			}
+			if steps[1].ScalingAdjustment != 0 || steps[4].ScalingAdjustment != 0 {
+				t.Errorf("Maintain and EmergencyStop bands should be 0, got %+d / %+d",
+					steps[1].ScalingAdjustment, steps[4].ScalingAdjustment)
+			}
+			if (steps[2].ScalingAdjustment > 0) != tt.wantUp {
+				t.Errorf("Stress band: adjustment %+d, want scale up %v", steps[2].ScalingAdjustment, tt.wantUp)
+			}
+			if (steps[3].ScalingAdjustment < 0) != tt.wantShed {
+				t.Errorf("Saturation band: adjustment %+d, want shed back to peak %v", steps[3].ScalingAdjustment, tt.wantShed)
+			}
 		})
 	}
 }
@@ -402,6 +886,8 @@ func TestBillionDollarOptimization(t *testing.T) {
 		Beta:     0.05, // Moderate coherency overhead
 		Lambda:   1000,
 		TargetR:  2.0,
+
+		CostPerNodeHour: 100.0 / 730, // $100/month per node
 	}
 
 	// Calculate peak capacity
@@ -438,6 +924,31 @@ func TestBillionDollarOptimization(t *testing.T) {
 	t.Logf("  Reason: %s", rec.Reason)
 	t.Log("")
 
+	// Absolute cost model
+	wantCurrent := 50 * metrics.CostPerNodeHour
+	wantTarget := float64(rec.TargetN) * metrics.CostPerNodeHour
+	if math.Abs(rec.HourlyCostCurrent-wantCurrent) > 1e-9 {
+		t.Errorf("HourlyCostCurrent = $%.4f, want $%.4f", rec.HourlyCostCurrent, wantCurrent)
+	}
+	if math.Abs(rec.HourlyCostTarget-wantTarget) > 1e-9 {
+		t.Errorf("HourlyCostTarget = $%.4f, want $%.4f", rec.HourlyCostTarget, wantTarget)
+	}
+	wantTPD := EstimateThroughput(rec.TargetN, metrics.Lambda, metrics.Alpha, metrics.Beta) / wantTarget
+	if math.Abs(rec.ThroughputPerDollar-wantTPD) > 1e-9 {
+		t.Errorf("ThroughputPerDollar = %.2f, want %.2f", rec.ThroughputPerDollar, wantTPD)
+	}
+
+	// Shedding back to 80% of peak costs less AND serves more per dollar
+	currentTPD := EstimateThroughput(metrics.CurrentN, metrics.Lambda, metrics.Alpha, metrics.Beta) / wantCurrent
+	if rec.ThroughputPerDollar <= currentTPD {
+		t.Errorf("Retrograde shed should improve throughput per dollar: %.2f ≤ %.2f", rec.ThroughputPerDollar, currentTPD)
+	}
+	if !strings.Contains(rec.Reason, "/hr") {
+		t.Errorf("Reason should state the cost impact: %s", rec.Reason)
+	}
+	t.Logf("  Hourly: $%.2f → $%.2f, throughput/$: %.0f → %.0f",
+		rec.HourlyCostCurrent, rec.HourlyCostTarget, currentTPD, rec.ThroughputPerDollar)
+
 	// Cost comparison
 	traditionalNodes := 100
 	lawbenchNodes := rec.TargetN
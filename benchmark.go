@@ -22,15 +22,17 @@
 //   - Linear Scaling: C(N) ≈ λN (ideal parallelism)
 //   - No Retrograde: C'(N) > 0 (throughput always increases)
 //
-// Future extensions:
+// Chaos analysis (feigenbaum.go):
 //   - Feigenbaum bifurcation analysis (chaos theory for stability boundaries)
 //   - Period-doubling detection (stable → periodic → chaotic transitions)
-//   - Lyapunov exponent measurement (quantify chaos)
+//   - Lyapunov exponent measurement (quantify chaos, LyapunovExponent)
 package lawbench
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"math"
 	"runtime"
 	"sort"
@@ -43,14 +45,106 @@ import (
 // Implementations should be stateless and safe for concurrent execution.
 type Operation func(ctx context.Context) error
 
+// SizedOperation is an Operation that also reports how many bytes it
+// processed, for workloads where ops/sec hides the bytes/sec story (see
+// RunSized). The size of a failed operation is ignored.
+type SizedOperation func(ctx context.Context) (int, error)
+
+// ErrorClass categorizes an operation's error (see Config.ClassifyError).
+type ErrorClass int
+
+const (
+	ErrorFatal     ErrorClass = iota // A real failure (unclassified errors)
+	ErrorTransient                   // A failure expected to pass on retry (timeout, throttling)
+	ErrorCancelled                   // Cancellation, not a failure
+
+	numErrorClasses = iota
+)
+
+// String returns the class name.
+func (c ErrorClass) String() string {
+	switch c {
+	case ErrorFatal:
+		return "fatal"
+	case ErrorTransient:
+		return "transient"
+	case ErrorCancelled:
+		return "cancelled"
+	default:
+		return fmt.Sprintf("ErrorClass(%d)", int(c))
+	}
+}
+
+// ClassifyError is the default Config.ClassifyError: context.Canceled is
+// ErrorCancelled, context.DeadlineExceeded ErrorTransient, anything else
+// ErrorFatal.
+//
+// A deadline that fires is the operation's own request timeout, the
+// clearest saturation signal there is, so it counts as a failure. Errors
+// from operations cut off by the end of the phase never reach the
+// classifier.
+func ClassifyError(err error) ErrorClass {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrorCancelled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorTransient
+	}
+	return ErrorFatal
+}
+
+// sized adapts op to a SizedOperation that processes no bytes.
+func (op Operation) sized() SizedOperation {
+	return func(ctx context.Context) (int, error) {
+		return 0, op(ctx)
+	}
+}
+
 // Result contains measurements from a single concurrency level.
 type Result struct {
 	N          int             // Number of concurrent workers
 	Duration   time.Duration   // Total benchmark duration
 	Operations int64           // Total operations completed
-	Throughput float64         // Operations per second
+	Throughput float64         // Operations per second (see Config.ThroughputAggregation)
 	Latencies  []time.Duration // Individual operation latencies (for percentiles)
-	Errors     int64           // Number of failed operations
+	Errors     int64           // Number of failed operations (excludes ErrorCancelled)
+
+	// ErrorsByClass counts every error by Config.ClassifyError, including
+	// cancellations; nil when there were none.
+	ErrorsByClass map[ErrorClass]int64
+
+	// Payload volume (populated only by RunSized)
+	Bytes           int64   // Total bytes reported by successful operations
+	BytesThroughput float64 // Bytes per second
+
+	// Recorder holds the latency distribution when Config.Recorder is set.
+	// Latencies is nil in that case.
+	Recorder LatencyRecorder
+
+	// Allocation behavior (populated only when Config.MeasureAllocs is set)
+	AllocsPerOp float64 // Heap allocations per successful operation
+	BytesPerOp  float64 // Heap bytes allocated per successful operation
+
+	WallTime     time.Duration // Warmup + measurement wall-clock time for this level
+	WarmupActual time.Duration // Time spent warming up (varies with Config.AdaptiveWarmup)
+
+	// Stalled is set when no operation completed within Config.StallTimeout
+	// and the level was abandoned. Latencies and the Recorder are then empty.
+	Stalled bool
+
+	// Stragglers counts workers still inside the operation when the
+	// measurement phase's drain timeout expired (see Config.StallTimeout).
+	// They were abandoned, and whatever they return is discarded.
+	Stragglers int
+
+	// OpenLoop is set when the level ran paced at Config.TargetRate.
+	// Latencies are then measured from each operation's intended start and
+	// include queueing delay; closed-loop latencies do not.
+	OpenLoop bool
+
+	// TimeSeries holds per-interval statistics when Config.LatencyBuckets is
+	// set, in chronological order. MergeResults does not pool it.
+	TimeSeries []Statistics
 }
 
 // Statistics contains percentile latency data.
@@ -68,16 +162,224 @@ type USLCoefficients struct {
 	Alpha    float64 // α: Contention coefficient
 	Beta     float64 // β: Coordination coefficient
 	RSquared float64 // R²: Goodness of fit (1.0 = perfect)
+
+	// BelowNoiseFloor is set by FitUSLAboveNoise when the fitted α was
+	// indistinguishable from latency jitter and has been clamped to 0.
+	BelowNoiseFloor bool
+
+	// MaxMeasuredN is the highest concurrency level the fit saw (0 when the
+	// coefficients were not fitted). Predictions beyond it are
+	// extrapolation; see PredictThroughputSafe.
+	MaxMeasuredN int
 }
 
 // Config controls benchmark execution.
 type Config struct {
 	Duration time.Duration // How long to run at each concurrency level
 	Warmup   time.Duration // Warmup period before measurement
-	Levels   []int         // Concurrency levels to test (default: [1,2,4,8,16])
+	Levels   []int         // Concurrency levels to test, run in ascending order (default: [1,2,4,8,16]; see NormalizeLevels)
 	MaxProcs int           // GOMAXPROCS limit (0 = use runtime default)
+	MaxN     int           // Upper bound for RunAdaptive probing (default: 64)
+
+	// AdaptiveWarmup ends warmup once throughput stabilizes instead of always
+	// running for Warmup, which then only caps it (DefaultAdaptiveWarmupCap if
+	// zero). Throughput is sampled in WarmupWindow sub-windows; measurement
+	// starts when the coefficient of variation of the last few samples drops
+	// below WarmupCV. Result.WarmupActual reports the time taken.
+	AdaptiveWarmup bool
+	WarmupWindow   time.Duration // Sub-window length (default: 100ms)
+	WarmupCV       float64       // Stability threshold (default: 0.05)
+
+	// LatencyBuckets splits each measurement phase into this many equal
+	// intervals and reports their statistics in Result.TimeSeries, exposing
+	// drift within a run (a leak, a filling cache) that the aggregate hides.
+	// Samples are bucketed by start time as they are recorded, so the only
+	// per-operation cost is computing the bucket index. ≤ 1 disables it.
+	LatencyBuckets int
+
+	// StallTimeout aborts a level early when no operation completes for this
+	// long (0 = disabled), e.g. on a deadlock in the benchmarked code. Run
+	// then stops with a *StallError instead of waiting out Duration at every
+	// level. Workers blocked inside the operation are abandoned, not joined.
+	//
+	// It also bounds how long a phase waits after its deadline for
+	// operations still in flight (DefaultDrainTimeout if zero); workers that
+	// miss it are reported in Result.Stragglers.
+	StallTimeout time.Duration
+
+	// MeasureAllocs samples runtime.ReadMemStats around each measurement phase
+	// to populate Result.AllocsPerOp and Result.BytesPerOp. ReadMemStats stops
+	// the world, so it runs only at phase boundaries, never per operation.
+	// The harness's own allocations (latency recording, workers) are
+	// measured on a no-op phase of the same N and operation count and
+	// subtracted, which lengthens each level by that short phase.
+	MeasureAllocs bool
+
+	// StrictGOMAXPROCS makes Run fail with a *GOMAXPROCSWarning when any level
+	// exceeds GOMAXPROCS. Above GOMAXPROCS, α measures scheduler overhead rather
+	// than application contention.
+	StrictGOMAXPROCS bool
+
+	// Logger receives the GOMAXPROCS warning when StrictGOMAXPROCS is off,
+	// and the warning when Levels lacks N=1. nil disables logging.
+	Logger *slog.Logger
+
+	// OnLevelStart is called before each concurrency level begins (before warmup).
+	// OnLevelComplete is called with each level's Result as soon as it finishes.
+	//
+	// Both run on the goroutine that called Run, never inside worker
+	// goroutines, so they are safe for logging or UI updates without extra
+	// synchronization. They have no way to stop a level: to abort early,
+	// cancel the ctx passed to Run.
+	OnLevelStart    func(n int)
+	OnLevelComplete func(Result)
+
+	// TargetRate switches to open-loop load at this many operations per
+	// second across all N workers (0 = closed-loop, each worker starting its
+	// next operation as soon as the last returns).
+	//
+	// Closed-loop load measures maximum throughput but hides queueing: when
+	// the system slows, the benchmark slows with it and never records the
+	// requests that would have waited (coordinated omission). Open-loop,
+	// operations are scheduled at fixed arrival times and latency is
+	// measured from the intended start, so an operation slower than the
+	// arrival rate shows a growing backlog in its latencies, as users would
+	// see it. N caps the operations in flight. StallTimeout, if set, must
+	// exceed the arrival interval 1/TargetRate.
+	TargetRate float64
+
+	// ClassifyError sorts operation errors into Result.ErrorsByClass (nil =
+	// ClassifyError). Errors classed ErrorCancelled are not counted in
+	// Result.Errors, so an operation that observes its context ending
+	// doesn't inflate the failure rate; return ErrorFatal for them to count
+	// them. Classes outside the defined ones count as ErrorFatal.
+	ClassifyError func(error) ErrorClass
+
+	// ThroughputAggregation selects how Result.Throughput is computed (zero =
+	// ThroughputMean, operations / elapsed). The robust methods split the
+	// phase into sub-windows, the LatencyBuckets intervals when set and
+	// DefaultThroughputWindows otherwise, and aggregate their rates, so a
+	// GC pause or other transient stall in one window doesn't drag the
+	// level's throughput (and the USL fit) down with it.
+	ThroughputAggregation ThroughputAggregation
+
+	// Recorder creates the latency recorder for each worker. nil keeps every
+	// sample in Result.Latencies. Use an HDRRecorder for long runs, where
+	// retaining millions of samples is too expensive:
+	//
+	//	cfg.Recorder = func() LatencyRecorder { return NewHDRRecorder(time.Minute, 3) }
+	Recorder func() LatencyRecorder
 }
 
+// ThroughputAggregation selects how a level's throughput is aggregated.
+type ThroughputAggregation string
+
+const (
+	ThroughputMean            ThroughputAggregation = "MEAN"              // Operations / elapsed (default)
+	ThroughputMedianSubWindow ThroughputAggregation = "MEDIAN_SUB_WINDOW" // Median of sub-window rates
+	ThroughputTrimmedMean     ThroughputAggregation = "TRIMMED_MEAN"      // Mean of sub-window rates without the extremes
+)
+
+// DefaultThroughputWindows is the number of sub-windows a robust
+// ThroughputAggregation uses when Config.LatencyBuckets is not set.
+const DefaultThroughputWindows = 10
+
+// throughputTrimFraction is the share of sub-windows ThroughputTrimmedMean
+// drops from each end (at least one when there are three or more).
+const throughputTrimFraction = 0.1
+
+// aggregateThroughput combines per-sub-window rates (ops/sec) with a robust
+// method. It returns 0 for no rates.
+func aggregateThroughput(rates []float64, method ThroughputAggregation) float64 {
+	if len(rates) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), rates...)
+	sort.Float64s(sorted)
+
+	if method == ThroughputMedianSubWindow {
+		return median(sorted)
+	}
+
+	// Trimmed mean; an unknown method gets the untrimmed mean of rates
+	trim := 0
+	if method == ThroughputTrimmedMean && len(sorted) >= 3 {
+		trim = max(1, int(float64(len(sorted))*throughputTrimFraction))
+	}
+	var sum float64
+	kept := sorted[trim : len(sorted)-trim]
+	for _, rate := range kept {
+		sum += rate
+	}
+	return sum / float64(len(kept))
+}
+
+// GOMAXPROCSWarning reports concurrency levels that exceed GOMAXPROCS.
+type GOMAXPROCSWarning struct {
+	GOMAXPROCS int   // Effective GOMAXPROCS during the benchmark
+	Levels     []int // Offending levels (N > GOMAXPROCS)
+}
+
+func (w *GOMAXPROCSWarning) Error() string {
+	return fmt.Sprintf("concurrency levels %v exceed GOMAXPROCS=%d: "+
+		"α at these levels measures Go scheduler overhead, not application contention",
+		w.Levels, w.GOMAXPROCS)
+}
+
+// StallError reports a level where no operation completed within
+// Config.StallTimeout.
+type StallError struct {
+	N       int           // Concurrency level that stalled
+	Phase   string        // "warmup" or "measurement"
+	Timeout time.Duration // Config.StallTimeout
+}
+
+func (e *StallError) Error() string {
+	return fmt.Sprintf("N=%d stalled during %s: no operation completed within %v "+
+		"(deadlock or operation ignoring ctx?)", e.N, e.Phase, e.Timeout)
+}
+
+// checkGOMAXPROCS validates levels against the effective GOMAXPROCS.
+// Returns an error in strict mode, otherwise logs (if a Logger is set) and returns nil.
+func checkGOMAXPROCS(cfg Config, levels []int) error {
+	procs := runtime.GOMAXPROCS(0)
+
+	var offending []int
+	for _, n := range levels {
+		if n > procs {
+			offending = append(offending, n)
+		}
+	}
+	if len(offending) == 0 {
+		return nil
+	}
+
+	warning := &GOMAXPROCSWarning{GOMAXPROCS: procs, Levels: offending}
+	if cfg.StrictGOMAXPROCS {
+		return warning
+	}
+	if cfg.Logger != nil {
+		cfg.Logger.Warn("lawbench: concurrency exceeds GOMAXPROCS",
+			"gomaxprocs", procs,
+			"levels", offending)
+	}
+	return nil
+}
+
+// Adaptive warmup defaults.
+const (
+	DefaultWarmupWindow      = 100 * time.Millisecond
+	DefaultWarmupCV          = 0.05
+	DefaultAdaptiveWarmupCap = 10 * time.Second
+
+	// warmupStableWindows is how many consecutive sub-windows must agree.
+	warmupStableWindows = 5
+)
+
+// DefaultDrainTimeout bounds how long a phase waits, after its deadline, for
+// operations still in flight when Config.StallTimeout is not set.
+const DefaultDrainTimeout = 5 * time.Second
+
 // DefaultConfig returns sensible defaults.
 func DefaultConfig() Config {
 	return Config{
@@ -85,21 +387,85 @@ func DefaultConfig() Config {
 		Warmup:   1 * time.Second,
 		Levels:   []int{1, 2, 4, 8, 16},
 		MaxProcs: 0,
+		MaxN:     64,
+	}
+}
+
+// NormalizeLevels returns concurrency levels sorted ascending with
+// duplicates removed, the order Run measures them in and the fits and
+// assertions expect. It errors on a level below 1.
+//
+// Levels should include N=1: λ is the single-worker throughput, and without
+// a measurement there the fit extrapolates it (Run logs a warning).
+func NormalizeLevels(levels []int) ([]int, error) {
+	sorted := make([]int, 0, len(levels))
+	for _, n := range levels {
+		if n < 1 {
+			return nil, fmt.Errorf("invalid concurrency level %d in %v: must be at least 1", n, levels)
+		}
+		sorted = append(sorted, n)
 	}
+	sort.Ints(sorted)
+
+	unique := sorted[:0]
+	for i, n := range sorted {
+		if i == 0 || n != sorted[i-1] {
+			unique = append(unique, n)
+		}
+	}
+	return unique, nil
 }
 
 // Run executes the operation at multiple concurrency levels and returns results.
+//
+// Levels are run in ascending order without duplicates (see NormalizeLevels),
+// so results are sorted by N whatever the order of cfg.Levels.
+//
+// If a level stalls (see Config.StallTimeout), Run stops and returns the
+// levels measured so far, ending with the stalled one, and a *StallError.
 func Run(ctx context.Context, op Operation, cfg Config) ([]Result, error) {
+	return run(ctx, op.sized(), cfg)
+}
+
+// RunSized is Run for an operation that reports its payload size, so each
+// Result also carries Bytes and BytesThroughput. Throughput stays in
+// ops/sec; use FitUSLBytes to fit the bytes/sec curve instead.
+func RunSized(ctx context.Context, op SizedOperation, cfg Config) ([]Result, error) {
+	return run(ctx, op, cfg)
+}
+
+// run implements Run and RunSized.
+func run(ctx context.Context, op SizedOperation, cfg Config) ([]Result, error) {
 	if cfg.MaxProcs > 0 {
 		oldMaxProcs := runtime.GOMAXPROCS(cfg.MaxProcs)
 		defer runtime.GOMAXPROCS(oldMaxProcs)
 	}
 
-	results := make([]Result, 0, len(cfg.Levels))
+	levels, err := NormalizeLevels(cfg.Levels)
+	if err != nil {
+		return nil, err
+	}
+	if len(levels) > 0 && levels[0] != 1 && cfg.Logger != nil {
+		cfg.Logger.Warn("lawbench: levels lack N=1, λ is extrapolated",
+			"levels", levels)
+	}
+
+	if err := checkGOMAXPROCS(cfg, levels); err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(levels))
 
-	for _, n := range cfg.Levels {
-		result, err := runAtLevel(ctx, op, n, cfg)
+	for _, n := range levels {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result, err := runLevel(ctx, op, n, cfg)
 		if err != nil {
+			if result.Stalled {
+				return append(results, result), fmt.Errorf("failed at N=%d: %w", n, err)
+			}
 			return nil, fmt.Errorf("failed at N=%d: %w", n, err)
 		}
 		results = append(results, result)
@@ -108,84 +474,633 @@ func Run(ctx context.Context, op Operation, cfg Config) ([]Result, error) {
 	return results, nil
 }
 
+// RunAdaptive probes concurrency levels instead of using cfg.Levels.
+//
+// It starts at N=1 and doubles N until measured throughput drops (retrograde
+// detected) or cfg.MaxN is reached, then bisects the interval around the best
+// level to refine the peak. This avoids spending time at N=16 on a system that
+// already peaks at N=4.
+//
+// Returns every measured level sorted by N, plus the N with the highest
+// measured throughput (the estimated N_peak). ctx is checked between levels.
+func RunAdaptive(ctx context.Context, op Operation, cfg Config) ([]Result, int, error) {
+	if cfg.MaxProcs > 0 {
+		oldMaxProcs := runtime.GOMAXPROCS(cfg.MaxProcs)
+		defer runtime.GOMAXPROCS(oldMaxProcs)
+	}
+
+	maxN := cfg.MaxN
+	if maxN <= 0 {
+		maxN = 64
+	}
+
+	measured := make(map[int]Result)
+	measure := func(n int) (Result, error) {
+		if r, ok := measured[n]; ok {
+			return r, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return Result{}, err
+		}
+		if err := checkGOMAXPROCS(cfg, []int{n}); err != nil {
+			return Result{}, err
+		}
+		r, err := runLevel(ctx, op.sized(), n, cfg)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed at N=%d: %w", n, err)
+		}
+		measured[n] = r
+		return r, nil
+	}
+
+	collect := func() []Result {
+		results := make([]Result, 0, len(measured))
+		for _, r := range measured {
+			results = append(results, r)
+		}
+		sort.Slice(results, func(i, j int) bool {
+			return results[i].N < results[j].N
+		})
+		return results
+	}
+
+	// Phase 1: Doubling until throughput drops or MaxN is reached
+	best, err := measure(1)
+	if err != nil {
+		return nil, 0, err
+	}
+	lo, hi := 1, 1
+	for n := 2; n <= maxN; n *= 2 {
+		r, err := measure(n)
+		if err != nil {
+			return nil, 0, err
+		}
+		if r.Throughput < best.Throughput {
+			hi = n // Retrograde: peak lies below n
+			break
+		}
+		lo = best.N
+		best = r
+		hi = n
+	}
+
+	// Phase 2: Bisect (lo, hi) around the best level.
+	// Invariant: lo ≤ best ≤ hi and best has the highest throughput seen.
+	for best.N-lo > 1 || hi-best.N > 1 {
+		// Probe the midpoint of the wider side
+		var n int
+		if best.N-lo > hi-best.N {
+			n = (lo + best.N) / 2
+		} else {
+			n = (best.N + hi) / 2
+		}
+
+		r, err := measure(n)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		switch {
+		case r.Throughput > best.Throughput && n < best.N:
+			hi, best = best.N, r
+		case r.Throughput > best.Throughput:
+			lo, best = best.N, r
+		case n < best.N:
+			lo = n
+		default:
+			hi = n
+		}
+	}
+
+	return collect(), best.N, nil
+}
+
+// runLevel wraps runAtLevel with the level callbacks and wall-clock timing.
+func runLevel(ctx context.Context, op SizedOperation, n int, cfg Config) (Result, error) {
+	if cfg.OnLevelStart != nil {
+		cfg.OnLevelStart(n)
+	}
+
+	start := time.Now()
+	result, err := runAtLevel(ctx, op, n, cfg)
+	result.WallTime = time.Since(start)
+	if err != nil {
+		return result, err
+	}
+
+	if cfg.OnLevelComplete != nil {
+		cfg.OnLevelComplete(result)
+	}
+
+	return result, nil
+}
+
 // runAtLevel executes the operation with N concurrent workers.
-func runAtLevel(ctx context.Context, op Operation, n int, cfg Config) (Result, error) {
+func runAtLevel(ctx context.Context, op SizedOperation, n int, cfg Config) (Result, error) {
 	// Warmup phase
-	if cfg.Warmup > 0 {
-		warmupCtx, cancel := context.WithTimeout(ctx, cfg.Warmup)
-		_ = runPhase(warmupCtx, op, n, cfg.Warmup)
-		cancel()
+	warmupActual, stalled := warmup(ctx, op, n, cfg)
+	if stalled {
+		return Result{N: n, Stalled: true, WarmupActual: warmupActual},
+			&StallError{N: n, Phase: "warmup", Timeout: cfg.StallTimeout}
 	}
 
 	// Measurement phase
 	measureCtx, cancel := context.WithTimeout(ctx, cfg.Duration)
 	defer cancel()
 
-	return runPhase(measureCtx, op, n, cfg.Duration), nil
+	var before runtime.MemStats
+	if cfg.MeasureAllocs {
+		runtime.ReadMemStats(&before)
+	}
+
+	result := runPhase(measureCtx, op, n, cfg.Duration, cfg)
+	result.WarmupActual = warmupActual
+	if result.Stalled {
+		return result, &StallError{N: n, Phase: "measurement", Timeout: cfg.StallTimeout}
+	}
+
+	if cfg.MeasureAllocs && result.Operations > 0 {
+		var after runtime.MemStats
+		runtime.ReadMemStats(&after)
+		mallocs, bytes := harnessAllocs(ctx, n, result.Operations, cfg)
+		ops := float64(result.Operations)
+		result.AllocsPerOp = math.Max(0, float64(after.Mallocs-before.Mallocs)-mallocs) / ops
+		result.BytesPerOp = math.Max(0, float64(after.TotalAlloc-before.TotalAlloc)-bytes) / ops
+	}
+
+	return result, nil
+}
+
+// harnessAllocs returns the heap allocations (count and bytes) runPhase
+// itself makes for ops operations with n workers, by running a no-op
+// operation until it has completed ops times (or cfg.Duration passes).
+func harnessAllocs(ctx context.Context, n int, ops int64, cfg Config) (mallocs, bytes float64) {
+	phaseCtx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	defer cancel()
+
+	var done int64
+	noop := func(context.Context) (int, error) {
+		if atomic.AddInt64(&done, 1) >= ops {
+			cancel()
+		}
+		return 0, nil
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	runPhase(phaseCtx, noop, n, cfg.Duration, cfg)
+	runtime.ReadMemStats(&after)
+	return float64(after.Mallocs - before.Mallocs), float64(after.TotalAlloc - before.TotalAlloc)
+}
+
+// warmup runs the warmup phase and returns how long it took and whether
+// it stalled.
+func warmup(ctx context.Context, op SizedOperation, n int, cfg Config) (time.Duration, bool) {
+	cfg.LatencyBuckets = 0
+
+	if !cfg.AdaptiveWarmup {
+		if cfg.Warmup <= 0 {
+			return 0, false
+		}
+		start := time.Now()
+		warmupCtx, cancel := context.WithTimeout(ctx, cfg.Warmup)
+		sample := runPhase(warmupCtx, op, n, cfg.Warmup, cfg)
+		cancel()
+		return time.Since(start), sample.Stalled
+	}
+
+	limit := cfg.Warmup
+	if limit <= 0 {
+		limit = DefaultAdaptiveWarmupCap
+	}
+	window := cfg.WarmupWindow
+	if window <= 0 {
+		window = DefaultWarmupWindow
+	}
+	threshold := cfg.WarmupCV
+	if threshold <= 0 {
+		threshold = DefaultWarmupCV
+	}
+
+	start := time.Now()
+	var throughputs []float64
+	for ctx.Err() == nil {
+		remaining := limit - time.Since(start)
+		if remaining <= 0 {
+			break
+		}
+
+		windowCtx, cancel := context.WithTimeout(ctx, min(window, remaining))
+		sample := runPhase(windowCtx, op, n, window, cfg)
+		cancel()
+		if sample.Stalled {
+			return time.Since(start), true
+		}
+
+		throughputs = append(throughputs, sample.Throughput)
+		if len(throughputs) >= warmupStableWindows &&
+			coefficientOfVariation(throughputs[len(throughputs)-warmupStableWindows:]) < threshold {
+			break
+		}
+	}
+
+	return time.Since(start), false
+}
+
+// coefficientOfVariation returns stddev/mean of xs (+Inf if the mean is 0).
+func coefficientOfVariation(xs []float64) float64 {
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	mean := sum / float64(len(xs))
+	if mean == 0 {
+		return math.Inf(1)
+	}
+
+	var variance float64
+	for _, x := range xs {
+		variance += (x - mean) * (x - mean)
+	}
+	return math.Sqrt(variance/float64(len(xs))) / mean
 }
 
 // runPhase executes the actual benchmark measurement.
-func runPhase(ctx context.Context, op Operation, n int, duration time.Duration) Result {
+// It uses cfg.Recorder (nil keeps latencies as a slice), cfg.LatencyBuckets,
+// cfg.ThroughputAggregation, cfg.StallTimeout and cfg.TargetRate.
+//
+// Operations that return after ctx is done are discarded, and workers still
+// inside the operation DefaultDrainTimeout (or cfg.StallTimeout) after that
+// are abandoned as stragglers rather than joined.
+func runPhase(ctx context.Context, op SizedOperation, n int, duration time.Duration, cfg Config) Result {
+	newRecorder, buckets := cfg.Recorder, cfg.LatencyBuckets
+	if newRecorder == nil {
+		newRecorder = func() LatencyRecorder { return NewSliceRecorder() }
+	}
+	if buckets < 1 {
+		buckets = 1
+	}
+	subWindows := cfg.ThroughputAggregation != "" && cfg.ThroughputAggregation != ThroughputMean
+	if subWindows && buckets == 1 {
+		buckets = DefaultThroughputWindows
+	}
+	timeSeries := cfg.LatencyBuckets > 1
+	bucketWidth := duration / time.Duration(buckets)
+
+	drainTimeout := cfg.StallTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = DefaultDrainTimeout
+	}
+	classify := cfg.ClassifyError
+	if classify == nil {
+		classify = ClassifyError
+	}
+
+	// Cancelled when the watchdog declares a stall
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	var (
 		wg         sync.WaitGroup
 		operations int64
 		errors     int64
-		latencies  = make([][]time.Duration, n) // Per-worker latency slices
+		bytes      int64
+		classified [numErrorClasses]int64
+		exited     int64
+		workers    = make([]*phaseWorker, n)
 	)
 
 	start := time.Now()
 
+	var arrivals *pacer
+	if cfg.TargetRate > 0 {
+		arrivals = &pacer{start: start, rate: cfg.TargetRate}
+	}
+
 	for i := 0; i < n; i++ {
 		wg.Add(1)
-		workerID := i
-		latencies[workerID] = make([]time.Duration, 0, 1000)
+		w := &phaseWorker{recorders: make([]LatencyRecorder, buckets)}
+		for b := range w.recorders {
+			w.recorders[b] = newRecorder()
+		}
+		workers[i] = w
 
 		go func() {
 			defer wg.Done()
+			defer atomic.AddInt64(&exited, 1)
 
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					opStart := time.Now()
-					err := op(ctx)
-					opDuration := time.Since(opStart)
+			for ctx.Err() == nil {
+				opStart := time.Now()
+				if arrivals != nil {
+					var due bool
+					if opStart, due = arrivals.wait(ctx); !due {
+						return
+					}
+				}
+				size, err := op(ctx)
+				opDuration := time.Since(opStart)
 
-					if err != nil {
+				// Re-check after op: one that overran the phase is not part of it
+				w.mu.Lock()
+				if w.closed || ctx.Err() != nil {
+					w.mu.Unlock()
+					return
+				}
+				if err != nil {
+					class := classify(err)
+					if class < 0 || class >= numErrorClasses {
+						class = ErrorFatal
+					}
+					atomic.AddInt64(&classified[class], 1)
+					if class != ErrorCancelled {
 						atomic.AddInt64(&errors, 1)
-					} else {
-						atomic.AddInt64(&operations, 1)
-						latencies[workerID] = append(latencies[workerID], opDuration)
 					}
+				} else {
+					atomic.AddInt64(&operations, 1)
+					atomic.AddInt64(&bytes, int64(size))
+					bucket := 0
+					if buckets > 1 && bucketWidth > 0 {
+						bucket = min(int(opStart.Sub(start)/bucketWidth), buckets-1)
+					}
+					w.recorders[bucket].Record(opDuration)
 				}
+				w.mu.Unlock()
 			}
 		}()
 	}
 
-	wg.Wait()
+	stalled := waitOrStall(ctx, &wg, cfg.StallTimeout, drainTimeout, func() int64 {
+		completed := atomic.LoadInt64(&operations)
+		for c := range classified {
+			completed += atomic.LoadInt64(&classified[c])
+		}
+		return completed
+	})
+	cancel()
+
+	// Stop stragglers from writing while their recorders are merged
+	for _, w := range workers {
+		w.close()
+	}
+	stragglers := n - int(atomic.LoadInt64(&exited))
+
 	elapsed := time.Since(start)
+	if deadline, ok := ctx.Deadline(); ok && deadline.Sub(start) < elapsed {
+		elapsed = deadline.Sub(start) // Exclude the drain
+	}
 
-	// Merge latencies from all workers
-	allLatencies := make([]time.Duration, 0, operations)
-	for _, workerLatencies := range latencies {
-		allLatencies = append(allLatencies, workerLatencies...)
+	if stalled {
+		return Result{
+			N:          n,
+			Duration:   elapsed,
+			Operations: atomic.LoadInt64(&operations),
+			Errors:     atomic.LoadInt64(&errors),
+			Stalled:    true,
+			Stragglers: stragglers,
+
+			ErrorsByClass: errorsByClass(&classified),
+		}
 	}
 
-	throughput := float64(operations) / elapsed.Seconds()
+	// Merge latencies from all workers, bucket by bucket
+	merged := newRecorder()
+	var series []Statistics
+	var rates []float64
+	for b := 0; b < buckets; b++ {
+		bucket := merged
+		if buckets > 1 {
+			bucket = newRecorder()
+		}
+		for _, w := range workers {
+			bucket.Merge(w.recorders[b])
+		}
+		if buckets > 1 {
+			if timeSeries {
+				series = append(series, CalculateStatistics(Result{Recorder: bucket}))
+			}
+			rates = append(rates, float64(bucket.Count())/bucketWidth.Seconds())
+			merged.Merge(bucket)
+		}
+	}
+
+	ops, processed := atomic.LoadInt64(&operations), atomic.LoadInt64(&bytes)
+	throughput := float64(ops) / elapsed.Seconds()
+	if subWindows {
+		throughput = aggregateThroughput(rates, cfg.ThroughputAggregation)
+	}
 
-	return Result{
+	result := Result{
 		N:          n,
 		Duration:   elapsed,
-		Operations: operations,
+		Operations: ops,
 		Throughput: throughput,
-		Latencies:  allLatencies,
-		Errors:     errors,
+		Errors:     atomic.LoadInt64(&errors),
+		Bytes:      processed,
+		Stragglers: stragglers,
+		OpenLoop:   arrivals != nil,
+		TimeSeries: series,
+
+		ErrorsByClass: errorsByClass(&classified),
+	}
+	if processed > 0 {
+		result.BytesThroughput = float64(processed) / elapsed.Seconds()
+	}
+	if slice, ok := merged.(*SliceRecorder); ok {
+		result.Latencies = slice.Latencies()
+	} else {
+		result.Recorder = merged
+	}
+
+	return result
+}
+
+// errorsByClass returns the non-zero per-class error counts, or nil.
+func errorsByClass(classified *[numErrorClasses]int64) map[ErrorClass]int64 {
+	var counts map[ErrorClass]int64
+	for c := range classified {
+		if count := atomic.LoadInt64(&classified[c]); count > 0 {
+			if counts == nil {
+				counts = make(map[ErrorClass]int64)
+			}
+			counts[ErrorClass(c)] = count
+		}
+	}
+	return counts
+}
+
+// pacer schedules open-loop arrivals at a fixed rate (see Config.TargetRate).
+type pacer struct {
+	start time.Time
+	rate  float64 // Arrivals per second
+	next  int64   // Next arrival slot, claimed atomically
+}
+
+// wait claims the next arrival slot and sleeps until it is due, returning
+// its intended start time. A worker that falls behind gets slots already in
+// the past and runs them immediately, so the backlog shows up as latency.
+// It returns false once ctx ends, including when the slot falls past ctx's
+// deadline.
+func (p *pacer) wait(ctx context.Context) (time.Time, bool) {
+	slot := atomic.AddInt64(&p.next, 1) - 1
+	intended := p.start.Add(time.Duration(float64(slot) / p.rate * float64(time.Second)))
+	if deadline, ok := ctx.Deadline(); ok && !intended.Before(deadline) {
+		<-ctx.Done() // Idle out the phase so its elapsed time stays whole
+		return intended, false
+	}
+
+	if d := time.Until(intended); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return intended, false
+		case <-timer.C:
+		}
+	}
+	return intended, true
+}
+
+// phaseWorker holds one runPhase worker's per-bucket recorders.
+//
+// The worker records under mu and only while the phase is open, so once
+// close returns its recorders are safe to read even if the worker itself is
+// still blocked inside the operation.
+type phaseWorker struct {
+	mu        sync.Mutex
+	closed    bool
+	recorders []LatencyRecorder
+}
+
+// close ends recording for the phase.
+func (w *phaseWorker) close() {
+	w.mu.Lock()
+	w.closed = true
+	w.mu.Unlock()
+}
+
+// waitOrStall waits for wg, or returns true once completed() has not
+// changed for stallTimeout (≤ 0 disables the watchdog).
+//
+// After ctx is done it waits at most drainTimeout more for operations still
+// in flight and then returns false with wg possibly still pending.
+func waitOrStall(ctx context.Context, wg *sync.WaitGroup, stallTimeout, drainTimeout time.Duration, completed func() int64) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	var tick <-chan time.Time
+	if stallTimeout > 0 {
+		ticker := time.NewTicker(stallTimeout / 4)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	phaseDone := ctx.Done()
+	var drain <-chan time.Time
+
+	last, lastChange := completed(), time.Now()
+	for {
+		select {
+		case <-done:
+			return false
+		case <-phaseDone:
+			// No progress is expected while draining: stop the watchdog
+			phaseDone, tick = nil, nil
+			timer := time.NewTimer(drainTimeout)
+			defer timer.Stop()
+			drain = timer.C
+		case <-drain:
+			return false
+		case now := <-tick:
+			if count := completed(); count != last {
+				last, lastChange = count, now
+			} else if now.Sub(lastChange) >= stallTimeout {
+				return true
+			}
+		}
 	}
 }
 
 // CalculateStatistics computes percentile latencies.
+//
+// Percentiles use nearest-rank indexing (sorted[n·p]); see
+// CalculateStatisticsInterpolated for small sample counts.
+// When the result carries a Recorder, statistics come from it instead of
+// sorting Result.Latencies.
 func CalculateStatistics(result Result) Statistics {
+	return calculateStatistics(result, func(sorted []time.Duration, p float64) time.Duration {
+		return sorted[nearestRankIndex(len(sorted), p)]
+	})
+}
+
+// CalculateStatisticsInterpolated is CalculateStatistics with percentiles
+// interpolated between order statistics (see PercentileInterpolated).
+// Recorder-backed results are unaffected: their percentiles come from the
+// recorder.
+func CalculateStatisticsInterpolated(result Result) Statistics {
+	return calculateStatistics(result, PercentileInterpolated)
+}
+
+// CalculateStatisticsAt returns the requested percentiles (each in [0, 1],
+// e.g. 0.75, 0.9, 0.999) of a result's latencies, keyed by percentile, for
+// SLOs beyond the P50/P95/P99 in Statistics:
+//
+//	pcts := lawbench.CalculateStatisticsAt(result, 0.5, 0.99, 0.999)
+//	if pcts[0.999] > slo { ... }
+//
+// Percentiles use nearest-rank indexing like CalculateStatistics, so they
+// are always recorded samples. With n samples only percentiles up to
+// 1 − 1/n are resolved: P99.9 of fewer than 1000 samples is the maximum.
+// Use PercentileInterpolated on the sorted latencies to interpolate
+// instead. Recorder-backed results use the recorder's percentiles. Returns
+// nil when there are no latencies.
+func CalculateStatisticsAt(result Result, percentiles ...float64) map[float64]time.Duration {
+	if result.Recorder != nil && len(result.Latencies) == 0 {
+		if result.Recorder.Count() == 0 {
+			return nil
+		}
+		values := make(map[float64]time.Duration, len(percentiles))
+		for _, p := range percentiles {
+			values[p] = result.Recorder.Percentile(p)
+		}
+		return values
+	}
+
+	if len(result.Latencies) == 0 {
+		return nil
+	}
+
+	sorted := make([]time.Duration, len(result.Latencies))
+	copy(sorted, result.Latencies)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	values := make(map[float64]time.Duration, len(percentiles))
+	for _, p := range percentiles {
+		values[p] = sorted[nearestRankIndex(len(sorted), p)]
+	}
+	return values
+}
+
+// calculateStatistics computes statistics with the given percentile method.
+func calculateStatistics(result Result, percentile func(sorted []time.Duration, p float64) time.Duration) Statistics {
+	if result.Recorder != nil && len(result.Latencies) == 0 {
+		rec := result.Recorder
+		if rec.Count() == 0 {
+			return Statistics{}
+		}
+		return Statistics{
+			Mean:   rec.Mean(),
+			Stddev: rec.Stddev(),
+			P50:    rec.Percentile(0.50),
+			P95:    rec.Percentile(0.95),
+			P99:    rec.Percentile(0.99),
+		}
+	}
+
 	if len(result.Latencies) == 0 {
 		return Statistics{}
 	}
@@ -212,9 +1127,9 @@ func CalculateStatistics(result Result) Statistics {
 	stddev := time.Duration(math.Sqrt(variance / float64(len(sorted))))
 
 	// Percentiles
-	p50 := sorted[len(sorted)*50/100]
-	p95 := sorted[len(sorted)*95/100]
-	p99 := sorted[len(sorted)*99/100]
+	p50 := percentile(sorted, 0.50)
+	p95 := percentile(sorted, 0.95)
+	p99 := percentile(sorted, 0.99)
 
 	return Statistics{
 		Mean:   mean,
@@ -225,6 +1140,121 @@ func CalculateStatistics(result Result) Statistics {
 	}
 }
 
+// MergeResults pools result sets measured separately (e.g. on several hosts)
+// into one set, for a combined USL fit.
+//
+// Each set is treated as a replicate of the same experiment: N workers on
+// one host. For every concurrency level present in any set, Operations,
+// Errors (and ErrorsByClass), Bytes, Duration and WallTime are summed and
+// Latencies concatenated. Throughput is the duration-weighted mean of the
+// sets' Throughput, so each keeps its Config.ThroughputAggregation (for
+// ThroughputMean this is ΣOperations / ΣDuration); BytesThroughput is
+// ΣBytes / ΣDuration. Both are per-host rates, not the aggregate across
+// hosts. A level missing from some sets is averaged over the sets that have
+// it. AllocsPerOp and BytesPerOp are weighted by successful operations.
+// OpenLoop is set if any contributing result ran open-loop.
+//
+// Recorders are merged into a fresh recorder when every contributing
+// result has one of the same built-in type; otherwise Recorder is nil.
+// Inputs are not modified. The result is sorted by N.
+func MergeResults(sets ...[]Result) []Result {
+	type pool struct {
+		merged    Result
+		recorders []LatencyRecorder
+		succeeded float64 // Σ successful ops, for alloc weighting
+		ops       float64 // Σ Throughput × Duration
+		allocs    float64 // Σ AllocsPerOp × successful ops
+		bytes     float64 // Σ BytesPerOp × successful ops
+		count     int
+	}
+
+	pools := make(map[int]*pool)
+	for _, set := range sets {
+		for _, r := range set {
+			p, ok := pools[r.N]
+			if !ok {
+				p = &pool{merged: Result{N: r.N}}
+				pools[r.N] = p
+			}
+
+			p.count++
+			p.merged.Duration += r.Duration
+			p.merged.WallTime += r.WallTime
+			p.merged.WarmupActual += r.WarmupActual
+			p.merged.Operations += r.Operations
+			p.merged.Errors += r.Errors
+			for class, count := range r.ErrorsByClass {
+				if p.merged.ErrorsByClass == nil {
+					p.merged.ErrorsByClass = make(map[ErrorClass]int64)
+				}
+				p.merged.ErrorsByClass[class] += count
+			}
+			p.merged.Bytes += r.Bytes
+			p.merged.Stragglers += r.Stragglers
+			p.merged.OpenLoop = p.merged.OpenLoop || r.OpenLoop
+			p.merged.Latencies = append(p.merged.Latencies, r.Latencies...)
+			p.recorders = append(p.recorders, r.Recorder)
+			p.ops += r.Throughput * r.Duration.Seconds()
+
+			succeeded := float64(r.Operations) // Errors are not counted in Operations
+			p.succeeded += succeeded
+			p.allocs += r.AllocsPerOp * succeeded
+			p.bytes += r.BytesPerOp * succeeded
+		}
+	}
+
+	merged := make([]Result, 0, len(pools))
+	for _, p := range pools {
+		r := p.merged
+		if r.Duration > 0 {
+			r.Throughput = p.ops / r.Duration.Seconds()
+			r.BytesThroughput = float64(r.Bytes) / r.Duration.Seconds()
+		}
+		if p.succeeded > 0 {
+			r.AllocsPerOp = p.allocs / p.succeeded
+			r.BytesPerOp = p.bytes / p.succeeded
+		}
+		r.Recorder = mergeRecorders(p.recorders)
+		merged = append(merged, r)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].N < merged[j].N
+	})
+	return merged
+}
+
+// poolableRecorder is a built-in recorder MergeResults can pool.
+type poolableRecorder interface {
+	fresh() LatencyRecorder                // Empty recorder with the same configuration
+	sameConfig(other LatencyRecorder) bool // other can be merged into it
+}
+
+// mergeRecorders pools recorders of one built-in type and configuration into
+// a fresh one. Returns nil if any is missing or the types or configurations
+// (HDR range and precision, t-digest compression) differ.
+func mergeRecorders(recorders []LatencyRecorder) LatencyRecorder {
+	if len(recorders) == 0 || recorders[0] == nil {
+		return nil
+	}
+
+	first, ok := recorders[0].(poolableRecorder)
+	if !ok {
+		return nil
+	}
+	for _, rec := range recorders[1:] {
+		if rec == nil || !first.sameConfig(rec) {
+			return nil
+		}
+	}
+
+	pooled := first.fresh()
+	for _, rec := range recorders {
+		pooled.Merge(rec)
+	}
+	return pooled
+}
+
 // FitUSL performs nonlinear regression to find λ, α, β coefficients.
 //
 // Uses linearization approach: transform USL to linear form and solve analytically.
@@ -240,6 +1270,172 @@ func FitUSL(results []Result) (USLCoefficients, error) {
 		return USLCoefficients{}, fmt.Errorf("need at least 3 data points, got %d", len(results))
 	}
 
+	return fitUSL(results, nil), nil
+}
+
+// FitUSLBytes is FitUSL on BytesThroughput instead of Throughput, for
+// results from RunSized. λ is then in bytes/sec at N=1; α and β describe how
+// data volume scales, which can differ from ops/sec when large operations
+// drive the coordination cost.
+func FitUSLBytes(results []Result) (USLCoefficients, error) {
+	byBytes := make([]Result, len(results))
+	for i, r := range results {
+		r.Throughput = r.BytesThroughput
+		byBytes[i] = r
+	}
+	return FitUSL(byBytes)
+}
+
+// FitUSLWeighted is FitUSL with each concurrency level weighted by the
+// precision of its measurement.
+//
+// The weight of a level is 1/variance of its latencies (Result.Latencies,
+// or Result.Recorder when set): a level whose latencies are tightly
+// clustered contributes more to the fit than a noisy one, so a jittery
+// N=16 run no longer drags β around as much as a clean N=1 run. Weights are
+// normalized so the largest is 1.0; when every level has the same variance
+// all weights are exactly 1.0 and the result is identical to FitUSL.
+//
+// Levels with fewer than two latency samples (or zero variance) have an
+// undefined or infinite weight. They are assigned the largest finite weight
+// observed among the other levels, or 1.0 if no level has a finite weight.
+func FitUSLWeighted(results []Result) (USLCoefficients, error) {
+	if len(results) < 3 {
+		return USLCoefficients{}, fmt.Errorf("need at least 3 data points, got %d", len(results))
+	}
+
+	return fitUSL(results, latencyWeights(results)), nil
+}
+
+// FitUSLAboveNoise is FitUSL with α clamped to 0 when its effect is smaller
+// than the measurement noise.
+//
+// α inflates latency at N by a factor of 1 + α(N−1) relative to N=1. If that
+// inflation at the highest measured N is below NoiseFloor (the relative
+// standard error of the mean latency at N=1), the fitted α cannot be told
+// apart from noise: Alpha is set to 0 and BelowNoiseFloor to true. Network
+// I/O jitter over a few samples otherwise shows up as a small but
+// confidently reported α. More samples lower the floor, so a jittery
+// operation measured long enough still reveals real contention.
+func FitUSLAboveNoise(results []Result) (USLCoefficients, error) {
+	coeffs, err := FitUSL(results)
+	if err != nil {
+		return coeffs, err
+	}
+
+	noise := NoiseFloor(results)
+	maxN := 0
+	for _, r := range results {
+		if r.N > maxN {
+			maxN = r.N
+		}
+	}
+
+	if noise > 0 && coeffs.Alpha != 0 && math.Abs(coeffs.Alpha)*float64(maxN-1) < noise {
+		coeffs.Alpha = 0
+		coeffs.BelowNoiseFloor = true
+	}
+
+	return coeffs, nil
+}
+
+// NoiseFloor returns the relative standard error of the mean latency,
+// CV/√samples (CV = stddev/mean), at the lowest measured concurrency,
+// normally N=1, where there is no contention and all spread is measurement
+// noise. It is how precisely that level's mean, and so its throughput, is
+// known.
+//
+// Returns 0 when that level has no latency samples.
+func NoiseFloor(results []Result) float64 {
+	if len(results) == 0 {
+		return 0
+	}
+
+	base := results[0]
+	for _, r := range results[1:] {
+		if r.N < base.N {
+			base = r
+		}
+	}
+
+	samples := int64(len(base.Latencies))
+	if samples == 0 && base.Recorder != nil {
+		samples = base.Recorder.Count()
+	}
+	stats := CalculateStatistics(base)
+	if stats.Mean <= 0 || samples == 0 {
+		return 0
+	}
+	cv := float64(stats.Stddev) / float64(stats.Mean)
+	return cv / math.Sqrt(float64(samples))
+}
+
+// latencyWeights computes normalized 1/variance weights for each result.
+func latencyWeights(results []Result) []float64 {
+	weights := make([]float64, len(results))
+
+	maxWeight := 0.0
+	for i, r := range results {
+		variance := latencyVariance(r.Latencies)
+		if r.Recorder != nil && len(r.Latencies) == 0 && r.Recorder.Count() >= 2 {
+			stddev := r.Recorder.Stddev().Seconds()
+			variance = stddev * stddev
+		}
+		if variance > 0 {
+			weights[i] = 1.0 / variance
+			if weights[i] > maxWeight {
+				maxWeight = weights[i]
+			}
+		}
+	}
+
+	if maxWeight == 0 {
+		maxWeight = 1.0
+	}
+
+	for i := range weights {
+		if weights[i] == 0 {
+			// Single sample or zero variance: treat as the most precise level
+			weights[i] = maxWeight
+		}
+		weights[i] /= maxWeight
+	}
+
+	return weights
+}
+
+// latencyVariance returns the population variance of latencies in seconds².
+// Returns 0 when fewer than two samples are available.
+func latencyVariance(latencies []time.Duration) float64 {
+	if len(latencies) < 2 {
+		return 0
+	}
+
+	var sum float64
+	for _, lat := range latencies {
+		sum += lat.Seconds()
+	}
+	mean := sum / float64(len(latencies))
+
+	var variance float64
+	for _, lat := range latencies {
+		diff := lat.Seconds() - mean
+		variance += diff * diff
+	}
+
+	return variance / float64(len(latencies))
+}
+
+// fitUSL solves the (optionally weighted) linearized USL least-squares problem.
+// A nil weights slice means every level has weight 1.0.
+func fitUSL(results []Result, weights []float64) USLCoefficients {
+	weightAt := func(i int) float64 {
+		if weights == nil {
+			return 1.0
+		}
+		return weights[i]
+	}
+
 	// Build design matrix and response vector for linear system
 	// Y = N/C(N), X = [1, (N-1), N(N-1)]
 	// Solve: Y = b0 + b1*(N-1) + b2*N*(N-1)
@@ -248,26 +1444,29 @@ func FitUSL(results []Result) (USLCoefficients, error) {
 
 	var sumY, sumX1, sumX2, sumX1X1, sumX2X2, sumX1X2, sumYX1, sumYX2 float64
 	var sumOne float64
+	maxN := 0
 
-	for _, r := range results {
+	for i, r := range results {
 		if r.Throughput == 0 {
 			continue
 		}
+		maxN = max(maxN, r.N)
 
+		w := weightAt(i)
 		N := float64(r.N)
 		Y := N / r.Throughput // N/C(N)
 		X1 := N - 1           // (N-1)
 		X2 := N * (N - 1)     // N(N-1)
 
-		sumY += Y
-		sumX1 += X1
-		sumX2 += X2
-		sumX1X1 += X1 * X1
-		sumX2X2 += X2 * X2
-		sumX1X2 += X1 * X2
-		sumYX1 += Y * X1
-		sumYX2 += Y * X2
-		sumOne += 1
+		sumY += w * Y
+		sumX1 += w * X1
+		sumX2 += w * X2
+		sumX1X1 += w * X1 * X1
+		sumX2X2 += w * X2 * X2
+		sumX1X2 += w * X1 * X2
+		sumYX1 += w * Y * X1
+		sumYX2 += w * Y * X2
+		sumOne += w
 	}
 
 	// Solve 3x3 system using Cramer's rule
@@ -283,11 +1482,12 @@ func FitUSL(results []Result) (USLCoefficients, error) {
 		// Fallback: use simple heuristic estimation
 		lambda := results[0].Throughput
 		return USLCoefficients{
-			Lambda:   lambda,
-			Alpha:    0.01,
-			Beta:     0.0,
-			RSquared: 0.0,
-		}, nil
+			Lambda:       lambda,
+			Alpha:        0.01,
+			Beta:         0.0,
+			RSquared:     0.0,
+			MaxMeasuredN: maxN,
+		}
 	}
 
 	// Calculate b0, b1, b2 using Cramer's rule
@@ -317,34 +1517,59 @@ func FitUSL(results []Result) (USLCoefficients, error) {
 	// (cache friendliness, rare). Usually indicates fitting error from noise.
 	// Fallback to 2-parameter model (λ, α only) when β < 0.
 	if beta < 0 && alpha > 0 {
-		// Re-fit with β = 0 (contention-only model)
-		// Y = b0 + b1*(N-1), solve 2x2 system
-		var sum2Y, sum2X1, sum2X1X1, sum2YX1, sum2One float64
-		for _, r := range results {
-			if r.Throughput == 0 {
-				continue
-			}
-			N := float64(r.N)
-			Y := N / r.Throughput
-			X1 := N - 1
-			sum2Y += Y
-			sum2X1 += X1
-			sum2X1X1 += X1 * X1
-			sum2YX1 += Y * X1
-			sum2One += 1
-		}
-
-		det2 := sum2One*sum2X1X1 - sum2X1*sum2X1
-		if math.Abs(det2) > 1e-10 {
-			b0_new := (sum2X1X1*sum2Y - sum2X1*sum2YX1) / det2
-			b1_new := (sum2One*sum2YX1 - sum2X1*sum2Y) / det2
-			lambda = 1.0 / b0_new
-			alpha = b1_new / b0_new
+		if l, a, ok := fitContentionOnly(results, weightAt); ok {
+			lambda = l
+			alpha = a
 			beta = 0.0 // Clamped
 		}
 	}
 
-	// Calculate R² (coefficient of determination)
+	rSquared := throughputRSquared(results, func(n float64) float64 {
+		return uslModel(n, lambda, alpha, beta)
+	})
+
+	return USLCoefficients{
+		Lambda:       lambda,
+		Alpha:        alpha,
+		Beta:         beta,
+		RSquared:     rSquared,
+		MaxMeasuredN: maxN,
+	}
+}
+
+// fitContentionOnly fits the β = 0 (Amdahl) form of USL.
+// Y = N/C(N) = b0 + b1*(N-1), solved as a weighted 2x2 system.
+// Returns ok=false when the system is singular.
+func fitContentionOnly(results []Result, weightAt func(int) float64) (lambda, alpha float64, ok bool) {
+	var sum2Y, sum2X1, sum2X1X1, sum2YX1, sum2One float64
+	for i, r := range results {
+		if r.Throughput == 0 {
+			continue
+		}
+		w := weightAt(i)
+		N := float64(r.N)
+		Y := N / r.Throughput
+		X1 := N - 1
+		sum2Y += w * Y
+		sum2X1 += w * X1
+		sum2X1X1 += w * X1 * X1
+		sum2YX1 += w * Y * X1
+		sum2One += w
+	}
+
+	det2 := sum2One*sum2X1X1 - sum2X1*sum2X1
+	if math.Abs(det2) <= 1e-10 {
+		return 0, 0, false
+	}
+
+	b0_new := (sum2X1X1*sum2Y - sum2X1*sum2YX1) / det2
+	b1_new := (sum2One*sum2YX1 - sum2X1*sum2Y) / det2
+	return 1.0 / b0_new, b1_new / b0_new, true
+}
+
+// throughputRSquared calculates R² (coefficient of determination) of a
+// throughput model against the measured throughput.
+func throughputRSquared(results []Result, predict func(n float64) float64) float64 {
 	var ssRes, ssTot float64
 	var meanThroughput float64
 	for _, r := range results {
@@ -353,19 +1578,12 @@ func FitUSL(results []Result) (USLCoefficients, error) {
 	meanThroughput /= float64(len(results))
 
 	for _, r := range results {
-		predicted := uslModel(float64(r.N), lambda, alpha, beta)
+		predicted := predict(float64(r.N))
 		ssRes += (r.Throughput - predicted) * (r.Throughput - predicted)
 		ssTot += (r.Throughput - meanThroughput) * (r.Throughput - meanThroughput)
 	}
 
-	rSquared := 1 - (ssRes / ssTot)
-
-	return USLCoefficients{
-		Lambda:   lambda,
-		Alpha:    alpha,
-		Beta:     beta,
-		RSquared: rSquared,
-	}, nil
+	return 1 - (ssRes / ssTot)
 }
 
 // uslModel calculates predicted throughput using USL formula.
@@ -378,6 +1596,84 @@ func (c USLCoefficients) PredictThroughput(n int) float64 {
 	return uslModel(float64(n), c.Lambda, c.Alpha, c.Beta)
 }
 
+// PredictThroughputSafe is PredictThroughput with a confidence in (0, 1]
+// that drops when n lies beyond the data the fit saw.
+//
+// Within MaxMeasuredN confidence is 1. Beyond it confidence is
+// MaxMeasuredN/n: predicting N=32 from a fit up to N=16 gives 0.5, N=128
+// gives 0.125. Far outside the data the prediction rests almost entirely
+// on β, the coefficient the fewest levels constrain, so a capacity plan
+// built on it is a guess. Coefficients not produced by a fit
+// (MaxMeasuredN = 0) carry no range and report 1.
+func (c USLCoefficients) PredictThroughputSafe(n int) (throughput float64, confidence float64) {
+	throughput = c.PredictThroughput(n)
+	if c.MaxMeasuredN <= 0 || n <= c.MaxMeasuredN {
+		return throughput, 1
+	}
+	return throughput, float64(c.MaxMeasuredN) / float64(n)
+}
+
+// PeakConcurrency returns N_peak = sqrt((1-α)/β), the concurrency at which
+// throughput is maximal. Beyond it, scaling is retrograde.
+//
+// Identical to CalculatePeakCapacity(c.Alpha, c.Beta): +Inf when β ≤ 0,
+// 0 when α ≥ 1.
+func (c USLCoefficients) PeakConcurrency() float64 {
+	return CalculatePeakCapacity(c.Alpha, c.Beta)
+}
+
+// PeakThroughput returns the predicted throughput at the nearest integer
+// N_peak.
+//
+// When N_peak is infinite (β ≤ 0) there is no peak, only an asymptote:
+// the result is λ/α for α > 0 (Amdahl saturation) and +Inf otherwise.
+func (c USLCoefficients) PeakThroughput() float64 {
+	peak := c.PeakConcurrency()
+	if math.IsInf(peak, 1) {
+		if c.Alpha > 0 {
+			return c.Lambda / c.Alpha
+		}
+		return math.Inf(1)
+	}
+	return c.PredictThroughput(int(math.Round(peak)))
+}
+
+// CouplingR returns the logistic-map coupling parameter r at concurrency n
+// implied by the fit (see CouplingRFromUSL). Feed it to ShouldScale or
+// Governor.Update when r is not measured directly.
+func (c USLCoefficients) CouplingR(n int) float64 {
+	return CouplingRFromUSL(c.Alpha, c.Beta, n)
+}
+
+// CouplingRFromUSL connects scalability measurement to stability prediction:
+//
+//	r = 1 + 2·α + 5·β·N
+//
+// Contention adds a fixed offset; coordination grows with N, so any β > 0
+// eventually pushes r past 3.0 (at N = (2 − 2α)/(5β)).
+func CouplingRFromUSL(alpha, beta float64, n int) float64 {
+	return 1 + 2*alpha + 5*beta*float64(n)
+}
+
+// ErrorRWeight is the r added per unit of error rate in CouplingRWithErrors.
+// Every request failing adds 2.0, enough to take an otherwise idle system
+// (r = 1) to the saturation boundary; a 10% error rate adds 0.2, one
+// governor zone.
+const ErrorRWeight = 2.0
+
+// CouplingRWithErrors is CouplingRFromUSL with an error-rate term:
+//
+//	r = 1 + 2·α + 5·β·N + 2·errorRate
+//
+// Rising errors are a classic saturation signal (timeouts, rejected
+// connections, exhausted pools) that latency alone can miss, so at the same
+// α, β and N a higher error rate means a higher r. errorRate is the fraction
+// of failed requests, clamped to [0, 1]; at 0 the result equals
+// CouplingRFromUSL.
+func CouplingRWithErrors(alpha, beta float64, n int, errorRate float64) float64 {
+	return CouplingRFromUSL(alpha, beta, n) + ErrorRWeight*clampFraction(errorRate)
+}
+
 // Efficiency returns the ratio of actual to ideal throughput.
 // 1.0 = perfect linear scaling, <1.0 = contention/coordination overhead.
 func (c USLCoefficients) Efficiency(n int) float64 {
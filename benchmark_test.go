@@ -1,7 +1,15 @@
 package lawbench
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -81,6 +89,52 @@ func TestCalculateStatistics(t *testing.T) {
 		stats.Mean, stats.P50, stats.P95, stats.P99)
 }
 
+// TestCalculateStatisticsAt verifies arbitrary percentiles are the expected
+// nearest-rank order statistics, including P99.9, and agree with
+// CalculateStatistics.
+func TestCalculateStatisticsAt(t *testing.T) {
+	// 1µs..2000µs, shuffled
+	latencies := make([]time.Duration, 2000)
+	for i := range latencies {
+		latencies[i] = time.Duration((i*7919)%2000+1) * time.Microsecond
+	}
+	result := Result{Latencies: latencies}
+
+	got := CalculateStatisticsAt(result, 0.5, 0.95, 0.99, 0.999)
+	want := map[float64]time.Duration{
+		0.5:   1001 * time.Microsecond, // sorted[1000]
+		0.95:  1901 * time.Microsecond, // sorted[1900]
+		0.99:  1981 * time.Microsecond, // sorted[1980]
+		0.999: 1999 * time.Microsecond, // sorted[1998]
+	}
+	for p, w := range want {
+		if got[p] != w {
+			t.Errorf("P%g = %v, want %v", p*100, got[p], w)
+		}
+	}
+
+	stats := CalculateStatistics(result)
+	if got[0.5] != stats.P50 || got[0.95] != stats.P95 || got[0.99] != stats.P99 {
+		t.Errorf("Disagrees with CalculateStatistics: %v vs %+v", got, stats)
+	}
+
+	// 100 samples cannot resolve P99.9: nearest rank gives the maximum
+	small := CalculateStatisticsAt(Result{Latencies: latencies[:100]}, 0.999)
+	var maxSmall time.Duration
+	for _, l := range latencies[:100] {
+		if l > maxSmall {
+			maxSmall = l
+		}
+	}
+	if small[0.999] != maxSmall {
+		t.Errorf("P99.9 of 100 samples = %v, want the maximum %v", small[0.999], maxSmall)
+	}
+
+	if empty := CalculateStatisticsAt(Result{}, 0.5); empty != nil {
+		t.Errorf("Expected nil for no latencies, got %v", empty)
+	}
+}
+
 // TestFitUSL_LinearScaling tests USL fit with ideal linear data.
 func TestFitUSL_LinearScaling(t *testing.T) {
 	// Simulate perfect linear scaling: C(N) = 1000 * N
@@ -143,3 +197,1499 @@ func TestFitUSL_WithContention(t *testing.T) {
 		t.Errorf("Expected α ≈ 0.1, got α=%.6f", coeffs.Alpha)
 	}
 }
+
+// TestFitUSLWeighted_EqualWeightsMatchUnweighted verifies equal variances reproduce FitUSL exactly.
+func TestFitUSLWeighted_EqualWeightsMatchUnweighted(t *testing.T) {
+	latencies := []time.Duration{
+		90 * time.Microsecond,
+		100 * time.Microsecond,
+		110 * time.Microsecond,
+	}
+
+	results := make([]Result, 0)
+	for _, n := range []int{1, 2, 4, 8, 16} {
+		throughput := (1000.0 * float64(n)) / (1 + 0.05*float64(n-1) + 0.002*float64(n)*float64(n-1))
+		results = append(results, Result{N: n, Throughput: throughput, Latencies: latencies})
+	}
+
+	unweighted, err := FitUSL(results)
+	if err != nil {
+		t.Fatalf("FitUSL failed: %v", err)
+	}
+
+	weighted, err := FitUSLWeighted(results)
+	if err != nil {
+		t.Fatalf("FitUSLWeighted failed: %v", err)
+	}
+
+	if weighted != unweighted {
+		t.Errorf("Equal weights should match unweighted fit:\n  weighted:   %+v\n  unweighted: %+v",
+			weighted, unweighted)
+	}
+}
+
+// TestFitUSLWeighted_DownWeightsNoisyLevel verifies a noisy outlier level moves the fit less.
+func TestFitUSLWeighted_DownWeightsNoisyLevel(t *testing.T) {
+	lambda, alpha, beta := 1000.0, 0.05, 0.002
+
+	clean := []time.Duration{99 * time.Microsecond, 100 * time.Microsecond, 101 * time.Microsecond}
+	noisy := []time.Duration{10 * time.Microsecond, 100 * time.Microsecond, 5000 * time.Microsecond}
+
+	results := make([]Result, 0)
+	for _, n := range []int{1, 2, 4, 8, 16} {
+		r := Result{N: n, Throughput: uslModel(float64(n), lambda, alpha, beta), Latencies: clean}
+		if n == 16 {
+			// Noisy measurement: throughput reads 30% low
+			r.Throughput *= 0.7
+			r.Latencies = noisy
+		}
+		results = append(results, r)
+	}
+
+	unweighted, err := FitUSL(results)
+	if err != nil {
+		t.Fatalf("FitUSL failed: %v", err)
+	}
+
+	weighted, err := FitUSLWeighted(results)
+	if err != nil {
+		t.Fatalf("FitUSLWeighted failed: %v", err)
+	}
+
+	t.Logf("True:       α=%.6f, β=%.6f", alpha, beta)
+	t.Logf("Unweighted: α=%.6f, β=%.6f", unweighted.Alpha, unweighted.Beta)
+	t.Logf("Weighted:   α=%.6f, β=%.6f", weighted.Alpha, weighted.Beta)
+
+	if math.Abs(weighted.Beta-beta) >= math.Abs(unweighted.Beta-beta) {
+		t.Errorf("Weighted β error (%.6f) should be smaller than unweighted (%.6f)",
+			math.Abs(weighted.Beta-beta), math.Abs(unweighted.Beta-beta))
+	}
+}
+
+// TestFitUSLAboveNoise_JitterIsNotContention verifies jitter on linear
+// scaling doesn't produce a spurious high-contention verdict, while real
+// contention measured with the same jitter is kept.
+func TestFitUSLAboveNoise_JitterIsNotContention(t *testing.T) {
+	rng := rand.New(rand.NewSource(10))
+	levels := []int{1, 2, 4, 8, 16}
+
+	// Linear scaling, 10 samples per level with ±80% jitter around 1ms
+	jittered := make([]Result, 0, len(levels))
+	for _, n := range levels {
+		latencies := make([]time.Duration, 10)
+		var sum time.Duration
+		for i := range latencies {
+			latencies[i] = time.Duration(200+rng.Intn(1600)) * time.Microsecond
+			sum += latencies[i]
+		}
+		mean := sum / time.Duration(len(latencies))
+		jittered = append(jittered, Result{N: n, Latencies: latencies, Throughput: float64(n) / mean.Seconds()})
+	}
+
+	cfg := DefaultAssertionConfig()
+	plain, err := FitUSL(jittered)
+	if err != nil {
+		t.Fatalf("FitUSL failed: %v", err)
+	}
+	if plain.Alpha <= cfg.MaxContention {
+		t.Fatalf("Setup: expected jitter to fit a spurious α > %.2f, got %.4f", cfg.MaxContention, plain.Alpha)
+	}
+
+	coeffs, err := FitUSLAboveNoise(jittered)
+	if err != nil {
+		t.Fatalf("FitUSLAboveNoise failed: %v", err)
+	}
+	if !coeffs.BelowNoiseFloor || coeffs.Alpha != 0 {
+		t.Errorf("α=%.4f at noise floor %.3f should be clamped, got α=%.4f (below=%v)",
+			plain.Alpha, NoiseFloor(jittered), coeffs.Alpha, coeffs.BelowNoiseFloor)
+	}
+	AssertZeroContention(t, jittered, cfg)
+
+	// Real contention with the same jitter stays above the floor
+	contended := make([]Result, len(jittered))
+	for i, r := range jittered {
+		r.Throughput = uslModel(float64(r.N), 1000, 0.1, 0)
+		contended[i] = r
+	}
+	coeffs, err = FitUSLAboveNoise(contended)
+	if err != nil {
+		t.Fatalf("FitUSLAboveNoise failed: %v", err)
+	}
+	if coeffs.BelowNoiseFloor || math.Abs(coeffs.Alpha-0.1) > 0.001 {
+		t.Errorf("α=0.1 should survive the noise floor, got α=%.4f (below=%v)", coeffs.Alpha, coeffs.BelowNoiseFloor)
+	}
+
+	t.Logf("✓ Jitter α=%.4f clamped at noise floor %.3f; real α=%.4f kept", plain.Alpha, NoiseFloor(jittered), coeffs.Alpha)
+}
+
+// TestFitUSLAboveNoise_ManySamples verifies small real contention under a
+// noisy operation is kept once enough samples pin down the mean: the floor
+// is the standard error of the mean, not the per-operation jitter.
+func TestFitUSLAboveNoise_ManySamples(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	// CV ≈ 1 (exponential latencies), 1000 samples per level, α = 0.03
+	var results []Result
+	for _, n := range []int{1, 2, 4, 8, 16} {
+		latencies := make([]time.Duration, 1000)
+		for i := range latencies {
+			latencies[i] = time.Duration(rng.ExpFloat64() * float64(time.Millisecond))
+		}
+		results = append(results, Result{N: n, Latencies: latencies, Throughput: uslModel(float64(n), 1000, 0.03, 0)})
+	}
+
+	coeffs, err := FitUSLAboveNoise(results)
+	if err != nil {
+		t.Fatalf("FitUSLAboveNoise failed: %v", err)
+	}
+	if coeffs.BelowNoiseFloor || math.Abs(coeffs.Alpha-0.03) > 0.001 {
+		t.Errorf("α=0.03 should survive noise floor %.3f, got α=%.4f (below=%v)",
+			NoiseFloor(results), coeffs.Alpha, coeffs.BelowNoiseFloor)
+	}
+
+	t.Logf("✓ α=%.4f kept at noise floor %.3f", coeffs.Alpha, NoiseFloor(results))
+}
+
+// TestFitUSLWeighted_SingleSample verifies single-sample levels don't produce infinite weights.
+func TestFitUSLWeighted_SingleSample(t *testing.T) {
+	results := []Result{
+		{N: 1, Throughput: 1000, Latencies: []time.Duration{time.Millisecond}},
+		{N: 2, Throughput: 1900, Latencies: []time.Duration{time.Millisecond, 2 * time.Millisecond}},
+		{N: 4, Throughput: 3400},
+		{N: 8, Throughput: 5600, Latencies: []time.Duration{time.Millisecond, 3 * time.Millisecond}},
+	}
+
+	coeffs, err := FitUSLWeighted(results)
+	if err != nil {
+		t.Fatalf("FitUSLWeighted failed: %v", err)
+	}
+
+	for name, v := range map[string]float64{"λ": coeffs.Lambda, "α": coeffs.Alpha, "β": coeffs.Beta} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			t.Errorf("%s is not finite: %v", name, v)
+		}
+	}
+}
+
+// TestRunAdaptive_FindsPeak verifies adaptive probing locates the throughput peak.
+func TestRunAdaptive_FindsPeak(t *testing.T) {
+	var active int64
+
+	// Latency grows quadratically with concurrency: C(N) ∝ N / (1 + (N-1)²/9)
+	// peaks near N=3-4 and is retrograde beyond. Sleeps round up to the
+	// timer tick (1ms on some hosts); with a 1ms base N=2's 1.11ms sleep
+	// costs two ticks and measures no faster than N=1, so the base is 5ms.
+	op := func(ctx context.Context) error {
+		n := atomic.AddInt64(&active, 1)
+		defer atomic.AddInt64(&active, -1)
+		k := float64(n - 1)
+		time.Sleep(time.Duration(float64(5*time.Millisecond) * (1 + k*k/9)))
+		return nil
+	}
+
+	cfg := DefaultConfig()
+	cfg.Duration = 150 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.MaxN = 32
+
+	results, peakN, err := RunAdaptive(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("RunAdaptive failed: %v", err)
+	}
+
+	for i := 1; i < len(results); i++ {
+		if results[i].N <= results[i-1].N {
+			t.Errorf("Results not sorted by N: %d then %d", results[i-1].N, results[i].N)
+		}
+	}
+
+	for _, r := range results {
+		if r.N > 16 {
+			t.Errorf("Probed N=%d beyond the first retrograde level", r.N)
+		}
+		t.Logf("N=%d: %.0f ops/sec", r.N, r.Throughput)
+	}
+
+	if peakN < 2 || peakN > 6 {
+		t.Errorf("Expected peak N in [2, 6], got %d", peakN)
+	}
+	t.Logf("✓ Estimated N_peak = %d (%d levels measured)", peakN, len(results))
+}
+
+// TestRunAdaptive_Cancelled verifies ctx cancellation stops probing.
+func TestRunAdaptive_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	op := func(ctx context.Context) error { return nil }
+
+	_, _, err := RunAdaptive(ctx, op, DefaultConfig())
+	if err == nil {
+		t.Fatal("Expected error from cancelled context")
+	}
+}
+
+var allocSink []byte
+
+// TestRun_MeasureAllocs verifies per-operation allocation tracking.
+func TestRun_MeasureAllocs(t *testing.T) {
+	op := func(ctx context.Context) error {
+		allocSink = make([]byte, 4096)
+		return nil
+	}
+
+	cfg := DefaultConfig()
+	cfg.Duration = 100 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{1}
+	cfg.MeasureAllocs = true
+
+	results, err := Run(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	r := results[0]
+	t.Logf("N=%d: %.2f allocs/op, %.1f B/op", r.N, r.AllocsPerOp, r.BytesPerOp)
+
+	if r.BytesPerOp < 4096 {
+		t.Errorf("Expected ≥ 4096 B/op, got %.1f", r.BytesPerOp)
+	}
+	if r.AllocsPerOp < 1 {
+		t.Errorf("Expected ≥ 1 alloc/op, got %.2f", r.AllocsPerOp)
+	}
+
+	AssertBoundedAllocs(t, results, 64*1024)
+
+	// The harness's latency recording is not charged to the operation
+	noop := func(ctx context.Context) error { return nil }
+	results, err = Run(context.Background(), noop, cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if r := results[0]; r.BytesPerOp > 64 || r.AllocsPerOp > 0.01 {
+		t.Errorf("A no-op should allocate ~nothing, got %.3f allocs/op, %.1f B/op", r.AllocsPerOp, r.BytesPerOp)
+	}
+
+	// Disabled by default
+	cfg.MeasureAllocs = false
+	results, err = Run(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if results[0].BytesPerOp != 0 || results[0].AllocsPerOp != 0 {
+		t.Errorf("Allocation fields should be zero when MeasureAllocs is off")
+	}
+}
+
+// TestHasAllocData verifies AssertBoundedAllocs can tell results measured
+// with Config.MeasureAllocs from results that carry no allocation data.
+func TestHasAllocData(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []Result
+		want    bool
+	}{
+		{"Measured", []Result{{N: 1, Operations: 100, BytesPerOp: 4096, AllocsPerOp: 1}}, true},
+		{"Measured at one level", []Result{{N: 1, Operations: 100}, {N: 2, Operations: 100, AllocsPerOp: 0.5}}, true},
+		{"MeasureAllocs off", []Result{{N: 1, Operations: 100}, {N: 2, Operations: 200}}, false},
+		{"No operations", []Result{{N: 1}}, true},
+		{"No results", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasAllocData(tt.results); got != tt.want {
+				t.Errorf("hasAllocData = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRun_StrictGOMAXPROCS verifies levels above GOMAXPROCS are rejected in strict mode.
+func TestRun_StrictGOMAXPROCS(t *testing.T) {
+	op := func(ctx context.Context) error { return nil }
+
+	cfg := DefaultConfig()
+	cfg.Duration = 10 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{1, 2, 4}
+	cfg.MaxProcs = 1
+	cfg.StrictGOMAXPROCS = true
+
+	_, err := Run(context.Background(), op, cfg)
+
+	var warning *GOMAXPROCSWarning
+	if !errors.As(err, &warning) {
+		t.Fatalf("Expected *GOMAXPROCSWarning, got %v", err)
+	}
+	if warning.GOMAXPROCS != 1 {
+		t.Errorf("Expected GOMAXPROCS=1, got %d", warning.GOMAXPROCS)
+	}
+	if len(warning.Levels) != 2 || warning.Levels[0] != 2 || warning.Levels[1] != 4 {
+		t.Errorf("Expected offending levels [2 4], got %v", warning.Levels)
+	}
+
+	t.Logf("✓ Rejected: %v", err)
+}
+
+// TestRun_GOMAXPROCSWarningLogged verifies non-strict mode logs and proceeds.
+func TestRun_GOMAXPROCSWarningLogged(t *testing.T) {
+	op := func(ctx context.Context) error { return nil }
+
+	var buf bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Duration = 10 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{1, 2}
+	cfg.MaxProcs = 1
+	cfg.Logger = slog.New(slog.NewTextHandler(&buf, nil))
+
+	results, err := Run(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	if !strings.Contains(buf.String(), "gomaxprocs=1") {
+		t.Errorf("Expected GOMAXPROCS warning in log, got: %q", buf.String())
+	}
+}
+
+// TestRun_UnsortedLevels verifies levels run in ascending order without
+// duplicates, a missing N=1 is logged and invalid levels are rejected.
+func TestRun_UnsortedLevels(t *testing.T) {
+	op := func(ctx context.Context) error { return nil }
+
+	cfg := DefaultConfig()
+	cfg.Duration = 10 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{4, 1, 2, 2}
+
+	results, err := Run(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	var ns []int
+	for _, r := range results {
+		ns = append(ns, r.N)
+	}
+	if fmt.Sprint(ns) != "[1 2 4]" {
+		t.Errorf("Expected levels [1 2 4], got %v", ns)
+	}
+
+	var buf bytes.Buffer
+	cfg.Levels = []int{4, 2}
+	cfg.Logger = slog.New(slog.NewTextHandler(&buf, nil))
+	if _, err := Run(context.Background(), op, cfg); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "lack N=1") {
+		t.Errorf("Expected a missing N=1 warning, got: %q", buf.String())
+	}
+
+	cfg.Levels = []int{1, 0, 2}
+	if _, err := Run(context.Background(), op, cfg); err == nil {
+		t.Error("Expected an error for level 0")
+	}
+}
+
+// TestRun_LevelCallbacks verifies progress callbacks fire in order on the calling goroutine.
+func TestRun_LevelCallbacks(t *testing.T) {
+	op := func(ctx context.Context) error { return nil }
+
+	var events []string // Unsynchronized on purpose: -race catches worker-goroutine calls
+	cfg := DefaultConfig()
+	cfg.Duration = 20 * time.Millisecond
+	cfg.Warmup = 10 * time.Millisecond
+	cfg.Levels = []int{1, 2}
+	cfg.OnLevelStart = func(n int) {
+		events = append(events, fmt.Sprintf("start:%d", n))
+	}
+	cfg.OnLevelComplete = func(r Result) {
+		events = append(events, fmt.Sprintf("done:%d", r.N))
+		if r.WallTime < cfg.Duration+cfg.Warmup {
+			t.Errorf("N=%d: WallTime %v shorter than warmup+duration", r.N, r.WallTime)
+		}
+	}
+
+	if _, err := Run(context.Background(), op, cfg); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	want := "start:1 done:1 start:2 done:2"
+	if got := strings.Join(events, " "); got != want {
+		t.Errorf("Callback order = %q, want %q", got, want)
+	}
+}
+
+// TestRun_CallbackCancelsRun verifies cancelling ctx from a callback aborts remaining levels.
+func TestRun_CallbackCancelsRun(t *testing.T) {
+	op := func(ctx context.Context) error { return nil }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var levels []int
+	cfg := DefaultConfig()
+	cfg.Duration = 20 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{1, 2, 4}
+	cfg.OnLevelComplete = func(r Result) {
+		levels = append(levels, r.N)
+		cancel()
+	}
+
+	_, err := Run(ctx, op, cfg)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	if len(levels) != 1 || levels[0] != 1 {
+		t.Errorf("Expected only N=1 to complete, got %v", levels)
+	}
+}
+
+// TestRun_AdaptiveWarmup verifies adaptive warmup waits out an operation
+// whose latency decays over its first second, ends early for a steady one,
+// and that fixed warmup reports its duration.
+func TestRun_AdaptiveWarmup(t *testing.T) {
+	// Latency decays linearly from 10ms to 1ms over the first second
+	var once sync.Once
+	var started time.Time
+	decaying := func(ctx context.Context) error {
+		once.Do(func() { started = time.Now() })
+		var extra time.Duration
+		if left := time.Second - time.Since(started); left > 0 {
+			extra = 9 * time.Millisecond * left / time.Second
+		}
+		time.Sleep(time.Millisecond + extra)
+		return nil
+	}
+	steady := func(ctx context.Context) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	}
+
+	tests := []struct {
+		name      string
+		op        Operation
+		adaptive  bool
+		minWarmup time.Duration
+		maxWarmup time.Duration
+	}{
+		{"Decaying", decaying, true, time.Second, 4 * time.Second},
+		{"Steady", steady, true, 0, time.Second},
+		{"Fixed", steady, false, 200 * time.Millisecond, 400 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Duration = 100 * time.Millisecond
+			cfg.Warmup = 200 * time.Millisecond
+			if tt.adaptive {
+				cfg.Warmup = 5 * time.Second // Cap
+			}
+			cfg.AdaptiveWarmup = tt.adaptive
+			cfg.Levels = []int{4}
+
+			results, err := Run(context.Background(), tt.op, cfg)
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+
+			warmup := results[0].WarmupActual
+			if warmup < tt.minWarmup || warmup > tt.maxWarmup {
+				t.Errorf("Expected warmup in [%v, %v], got %v", tt.minWarmup, tt.maxWarmup, warmup)
+			}
+			t.Logf("✓ %s: warmed up in %v", tt.name, warmup)
+		})
+	}
+}
+
+// TestRun_LatencyBuckets verifies the time series exposes latency that
+// ramps up during the measurement window.
+func TestRun_LatencyBuckets(t *testing.T) {
+	var once sync.Once
+	var started time.Time
+	op := func(ctx context.Context) error {
+		once.Do(func() { started = time.Now() })
+		time.Sleep(time.Millisecond + time.Since(started)/50) // 1ms → ~9ms over 400ms
+		return nil
+	}
+
+	cfg := DefaultConfig()
+	cfg.Duration = 400 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{2}
+	cfg.LatencyBuckets = 4
+
+	results, err := Run(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	result := results[0]
+	if len(result.TimeSeries) != cfg.LatencyBuckets {
+		t.Fatalf("Expected %d buckets, got %d", cfg.LatencyBuckets, len(result.TimeSeries))
+	}
+	if int64(len(result.Latencies)) != result.Operations {
+		t.Errorf("Aggregate should keep every sample: %d latencies for %d ops", len(result.Latencies), result.Operations)
+	}
+
+	for i, stats := range result.TimeSeries {
+		t.Logf("  Bucket %d: P50=%v P99=%v", i, stats.P50, stats.P99)
+	}
+	first, last := result.TimeSeries[0], result.TimeSeries[len(result.TimeSeries)-1]
+	if last.P99 <= first.P99 || last.P50 <= first.P50 {
+		t.Errorf("Later buckets should show higher latency: first P99=%v, last P99=%v", first.P99, last.P99)
+	}
+	t.Logf("✓ P99 drifted from %v to %v within the run", first.P99, last.P99)
+
+	// Without buckets there is no time series
+	cfg.LatencyBuckets = 0
+	cfg.Duration = 20 * time.Millisecond
+	if results, _ := Run(context.Background(), op, cfg); results[0].TimeSeries != nil {
+		t.Errorf("Expected no time series without LatencyBuckets")
+	}
+}
+
+// TestAggregateThroughput_StallWindow verifies one stalled sub-window drags
+// the mean down but barely moves the median or trimmed mean.
+func TestAggregateThroughput_StallWindow(t *testing.T) {
+	rates := []float64{1000, 1010, 990, 1005, 995, 1000, 1002, 998, 1003, 997}
+	stalled := append([]float64(nil), rates...)
+	stalled[4] = 50 // GC pause
+
+	shift := func(method ThroughputAggregation) float64 {
+		before, after := aggregateThroughput(rates, method), aggregateThroughput(stalled, method)
+		return (before - after) / before
+	}
+
+	if mean := shift(""); mean < 0.09 {
+		t.Errorf("Mean of rates moved %.1f%%, want ≈ 9.5%%", mean*100)
+	}
+	for _, method := range []ThroughputAggregation{ThroughputMedianSubWindow, ThroughputTrimmedMean} {
+		if moved := shift(method); math.Abs(moved) > 0.005 {
+			t.Errorf("%s moved %.2f%%, want < 0.5%%", method, moved*100)
+		}
+	}
+
+	if got := aggregateThroughput(nil, ThroughputMedianSubWindow); got != 0 {
+		t.Errorf("No windows: got %.1f, want 0", got)
+	}
+}
+
+// TestRun_ThroughputAggregation verifies a stall in the middle of a level
+// lowers the mean throughput more than the median of sub-windows.
+func TestRun_ThroughputAggregation(t *testing.T) {
+	var started, stalled int64
+	op := func(ctx context.Context) error {
+		now := time.Now().UnixNano()
+		atomic.CompareAndSwapInt64(&started, 0, now)
+		if now-atomic.LoadInt64(&started) > int64(200*time.Millisecond) && atomic.CompareAndSwapInt64(&stalled, 0, 1) {
+			time.Sleep(80 * time.Millisecond) // One stall, covering a sub-window
+		}
+		time.Sleep(time.Millisecond)
+		return nil
+	}
+
+	cfg := DefaultConfig()
+	cfg.Duration = 500 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{1}
+	cfg.ThroughputAggregation = ThroughputMedianSubWindow
+
+	results, err := Run(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	result := results[0]
+	if result.TimeSeries != nil {
+		t.Error("Sub-windows without LatencyBuckets should not produce a time series")
+	}
+
+	mean := float64(result.Operations) / result.Duration.Seconds()
+	if result.Throughput <= mean {
+		t.Errorf("Median throughput %.0f should exceed the stall-depressed mean %.0f", result.Throughput, mean)
+	}
+	t.Logf("✓ Median of sub-windows %.0f ops/sec vs mean %.0f ops/sec", result.Throughput, mean)
+}
+
+// TestRun_StallTimeout verifies an operation blocked forever aborts the run
+// promptly with Stalled set, in either phase, while a healthy run is not
+// flagged.
+func TestRun_StallTimeout(t *testing.T) {
+	never := make(chan struct{})
+	t.Cleanup(func() { close(never) }) // Release the abandoned workers
+
+	blocked := func(ctx context.Context) error {
+		<-never // Lock bug: ignores ctx
+		return nil
+	}
+
+	tests := []struct {
+		name      string
+		warmup    time.Duration
+		wantPhase string
+	}{
+		{"Measurement", 0, "measurement"},
+		{"Warmup", time.Second, "warmup"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Duration = 5 * time.Second
+			cfg.Warmup = tt.warmup
+			cfg.Levels = []int{1, 2, 4}
+			cfg.StallTimeout = 100 * time.Millisecond
+
+			start := time.Now()
+			results, err := Run(context.Background(), blocked, cfg)
+			elapsed := time.Since(start)
+
+			var stall *StallError
+			if !errors.As(err, &stall) {
+				t.Fatalf("Expected *StallError, got %v", err)
+			}
+			if stall.N != 1 || stall.Phase != tt.wantPhase {
+				t.Errorf("Expected stall at N=1 during %s, got %+v", tt.wantPhase, stall)
+			}
+			if len(results) != 1 || !results[0].Stalled || results[0].Operations != 0 {
+				t.Errorf("Expected one stalled result with 0 ops, got %+v", results)
+			}
+			if elapsed > time.Second {
+				t.Errorf("Run took %v, should abort within ~%v", elapsed, cfg.StallTimeout)
+			}
+			t.Logf("✓ Aborted after %v: %v", elapsed, err)
+		})
+	}
+
+	// Operations slower than nothing but faster than the timeout are fine
+	cfg := DefaultConfig()
+	cfg.Duration = 200 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{1, 2}
+	cfg.StallTimeout = 50 * time.Millisecond
+	results, err := Run(context.Background(), func(ctx context.Context) error {
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	}, cfg)
+	if err != nil || results[0].Stalled || results[1].Stalled {
+		t.Errorf("Healthy run should not stall: err=%v", err)
+	}
+}
+
+// TestRun_StragglerPastDeadline verifies an operation that overruns the
+// measurement deadline neither wedges Run nor writes into the merged
+// results, and is reported as a straggler. Run with -race.
+func TestRun_StragglerPastDeadline(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) }) // Release the abandoned worker
+
+	var (
+		once    sync.Once
+		begin   time.Time
+		stalled int32
+	)
+	op := func(ctx context.Context) error {
+		once.Do(func() { begin = time.Now() })
+		if time.Since(begin) > 80*time.Millisecond && atomic.CompareAndSwapInt32(&stalled, 0, 1) {
+			<-release // Overruns the 100ms deadline, ignoring ctx
+			return nil
+		}
+		time.Sleep(time.Millisecond)
+		return nil
+	}
+
+	cfg := DefaultConfig()
+	cfg.Duration = 100 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{4}
+	cfg.StallTimeout = 200 * time.Millisecond
+
+	start := time.Now()
+	results, err := Run(context.Background(), op, cfg)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	r := results[0]
+	if r.Stalled {
+		t.Error("Other workers kept completing: the level should not stall")
+	}
+	if r.Stragglers != 1 {
+		t.Errorf("Expected 1 straggler, got %d", r.Stragglers)
+	}
+	if int64(len(r.Latencies)) != r.Operations {
+		t.Errorf("Recorded %d latencies for %d operations", len(r.Latencies), r.Operations)
+	}
+	if r.Duration > cfg.Duration+20*time.Millisecond {
+		t.Errorf("Duration %v should exclude the drain", r.Duration)
+	}
+	if elapsed > time.Second {
+		t.Errorf("Run took %v, drain should give up after ~%v", elapsed, cfg.StallTimeout)
+	}
+
+	// The straggler returning now must not touch the returned results
+	release <- struct{}{}
+	time.Sleep(10 * time.Millisecond)
+	if int64(len(r.Latencies)) != r.Operations {
+		t.Errorf("Results changed after the straggler returned")
+	}
+
+	t.Logf("✓ Run returned after %v with %d straggler, %d ops", elapsed, r.Stragglers, r.Operations)
+}
+
+// TestRun_OpenLoopBacklog verifies an operation slower than TargetRate
+// accumulates queueing delay in its latencies (measured from the intended
+// start), while the same operation closed-loop reports only its own duration.
+func TestRun_OpenLoopBacklog(t *testing.T) {
+	const opTime = 4 * time.Millisecond
+	op := func(ctx context.Context) error {
+		time.Sleep(opTime)
+		return nil
+	}
+
+	cfg := DefaultConfig()
+	cfg.Duration = 200 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{1}
+
+	closed, err := Run(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("Closed-loop run failed: %v", err)
+	}
+
+	cfg.TargetRate = 500 // One arrival every 2ms, served in ≥ 4ms
+	open, err := Run(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("Open-loop run failed: %v", err)
+	}
+
+	if closed[0].OpenLoop || !open[0].OpenLoop {
+		t.Errorf("OpenLoop = %v closed, %v open", closed[0].OpenLoop, open[0].OpenLoop)
+	}
+
+	lat := open[0].Latencies
+	if len(lat) < 10 {
+		t.Fatalf("Expected a backlog of samples, got %d", len(lat))
+	}
+	half := len(lat) / 2
+	early, late := meanDuration(lat[:half]), meanDuration(lat[half:])
+	if late < 2*early {
+		t.Errorf("Backlog should grow latency: first half mean %v, second half %v", early, late)
+	}
+
+	closedP99, openP99 := CalculateStatistics(closed[0]).P99, CalculateStatistics(open[0]).P99
+	if openP99 < 5*closedP99 {
+		t.Errorf("Open-loop P99 %v should far exceed closed-loop P99 %v", openP99, closedP99)
+	}
+	if open[0].Throughput > 0.75*cfg.TargetRate {
+		t.Errorf("Throughput %.0f should fall short of the %.0f ops/s target", open[0].Throughput, cfg.TargetRate)
+	}
+
+	t.Logf("✓ Closed-loop P99 %v; open-loop P99 %v (mean %v → %v), %.0f of %.0f ops/s",
+		closedP99, openP99, early, late, open[0].Throughput, cfg.TargetRate)
+}
+
+// TestRun_OpenLoopPacesFastOperation verifies an operation faster than
+// TargetRate completes at the target rate rather than flat out.
+func TestRun_OpenLoopPacesFastOperation(t *testing.T) {
+	op := func(ctx context.Context) error { return nil }
+
+	cfg := DefaultConfig()
+	cfg.Duration = 200 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{2}
+	cfg.TargetRate = 1000
+
+	results, err := Run(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	r := results[0]
+	if math.Abs(r.Throughput-cfg.TargetRate)/cfg.TargetRate > 0.15 {
+		t.Errorf("Throughput %.0f ops/s, want ≈ %.0f", r.Throughput, cfg.TargetRate)
+	}
+	if r.Duration < 190*time.Millisecond {
+		t.Errorf("Phase ended early: %v", r.Duration)
+	}
+
+	t.Logf("✓ Paced at %.0f ops/s (%d ops in %v)", r.Throughput, r.Operations, r.Duration)
+}
+
+// meanDuration returns the mean of a non-empty slice.
+func meanDuration(ds []time.Duration) time.Duration {
+	var sum time.Duration
+	for _, d := range ds {
+		sum += d
+	}
+	return sum / time.Duration(len(ds))
+}
+
+// TestRunSized_BytesThroughput verifies a SizedOperation with variable
+// payloads yields bytes/sec from successful operations only, alongside the
+// usual ops/sec.
+func TestRunSized_BytesThroughput(t *testing.T) {
+	var calls int64
+	op := func(ctx context.Context) (int, error) {
+		i := atomic.AddInt64(&calls, 1)
+		time.Sleep(100 * time.Microsecond)
+		if i%7 == 0 {
+			return 1 << 20, errors.New("failed") // Size must be ignored
+		}
+		return int(100 * (i%10 + 1)), nil // 100..1000 bytes
+	}
+
+	cfg := DefaultConfig()
+	cfg.Duration = 100 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{1, 4}
+
+	results, err := RunSized(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("RunSized failed: %v", err)
+	}
+
+	for _, r := range results {
+		if r.Operations == 0 || r.Throughput <= 0 || r.Errors == 0 {
+			t.Fatalf("N=%d: expected ops/sec and errors, got %+v", r.N, r)
+		}
+
+		meanSize := float64(r.Bytes) / float64(r.Operations)
+		if meanSize < 100 || meanSize > 1000 {
+			t.Errorf("N=%d: mean payload %.0f bytes outside [100, 1000]: failed sizes counted?", r.N, meanSize)
+		}
+		if want := r.Throughput * meanSize; math.Abs(r.BytesThroughput-want)/want > 1e-9 {
+			t.Errorf("N=%d: BytesThroughput %.0f, want Throughput × mean size = %.0f", r.N, r.BytesThroughput, want)
+		}
+
+		t.Logf("✓ N=%d: %.0f ops/s, %.0f B/s (mean %.0f B)", r.N, r.Throughput, r.BytesThroughput, meanSize)
+	}
+
+	plain, err := Run(context.Background(), func(ctx context.Context) error { return nil }, cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if plain[0].Bytes != 0 || plain[0].BytesThroughput != 0 {
+		t.Errorf("Plain Operation should report no bytes, got %+v", plain[0])
+	}
+}
+
+// TestRun_CancelledErrorsNotFailures verifies an operation returning
+// context.Canceled as the phase nears its deadline is counted as cancelled,
+// not as a failure, while a real error is.
+func TestRun_CancelledErrorsNotFailures(t *testing.T) {
+	var calls int64
+	op := func(ctx context.Context) error {
+		time.Sleep(time.Millisecond)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < 30*time.Millisecond {
+			return context.Canceled // e.g. a client shutting down its request
+		}
+		if atomic.AddInt64(&calls, 1)%10 == 0 {
+			return errors.New("connection reset")
+		}
+		return nil
+	}
+
+	cfg := DefaultConfig()
+	cfg.Duration = 100 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{2}
+
+	results, err := Run(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	r := results[0]
+	cancelled, fatal := r.ErrorsByClass[ErrorCancelled], r.ErrorsByClass[ErrorFatal]
+	if cancelled == 0 || fatal == 0 {
+		t.Fatalf("Expected cancelled and fatal errors, got %v", r.ErrorsByClass)
+	}
+	if r.Errors != fatal {
+		t.Errorf("Errors = %d, want only the %d real failures (%d cancelled)", r.Errors, fatal, cancelled)
+	}
+
+	t.Logf("✓ %d failures counted, %d cancellations excluded (%d ops)", r.Errors, cancelled, r.Operations)
+}
+
+// TestRun_OperationTimeoutsAreFailures verifies an operation's own request
+// timeout counts as a transient failure in Errors, not as a cancellation.
+func TestRun_OperationTimeoutsAreFailures(t *testing.T) {
+	var calls int64
+	op := func(ctx context.Context) error {
+		if atomic.AddInt64(&calls, 1)%4 != 0 {
+			time.Sleep(time.Millisecond)
+			return nil
+		}
+		reqCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+		defer cancel()
+		<-reqCtx.Done() // The backend never answers
+		return reqCtx.Err()
+	}
+
+	cfg := DefaultConfig()
+	cfg.Duration = 50 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{2}
+
+	results, err := Run(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	r := results[0]
+	timeouts := r.ErrorsByClass[ErrorTransient]
+	if timeouts == 0 || r.ErrorsByClass[ErrorCancelled] != 0 {
+		t.Fatalf("Expected timeouts classed transient, got %v", r.ErrorsByClass)
+	}
+	if r.Errors != timeouts {
+		t.Errorf("Errors = %d, want the %d timeouts", r.Errors, timeouts)
+	}
+
+	t.Logf("✓ %d timeouts counted as failures (%d ops)", timeouts, r.Operations)
+}
+
+// TestRun_ClassifyError verifies a custom classifier, with out-of-range
+// classes counted as fatal and cancellations countable as failures.
+func TestRun_ClassifyError(t *testing.T) {
+	errThrottled := errors.New("throttled")
+	var calls int64
+	op := func(ctx context.Context) error {
+		time.Sleep(time.Millisecond)
+		switch atomic.AddInt64(&calls, 1) % 4 {
+		case 1:
+			return errThrottled
+		case 2:
+			return context.DeadlineExceeded
+		case 3:
+			return errors.New("unknown")
+		}
+		return nil
+	}
+
+	cfg := DefaultConfig()
+	cfg.Duration = 50 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{1}
+	cfg.ClassifyError = func(err error) ErrorClass {
+		switch {
+		case errors.Is(err, errThrottled):
+			return ErrorTransient
+		case errors.Is(err, context.DeadlineExceeded):
+			return ErrorFatal // This service treats its own timeouts as failures
+		}
+		return ErrorClass(99)
+	}
+
+	results, err := Run(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	r := results[0]
+	if r.ErrorsByClass[ErrorTransient] == 0 || r.ErrorsByClass[ErrorCancelled] != 0 || len(r.ErrorsByClass) != 2 {
+		t.Errorf("Unexpected classes %v", r.ErrorsByClass)
+	}
+	if r.Errors != r.ErrorsByClass[ErrorTransient]+r.ErrorsByClass[ErrorFatal] {
+		t.Errorf("Errors = %d, want transient + fatal from %v", r.Errors, r.ErrorsByClass)
+	}
+	if got := ErrorClass(99).String(); got != "ErrorClass(99)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+// TestFitUSLBytes verifies the bytes/sec fit scales λ by the payload size
+// and recovers the same α and β.
+func TestFitUSLBytes(t *testing.T) {
+	const payload = 4096
+	var results []Result
+	for _, n := range []int{1, 2, 4, 8, 16} {
+		x := uslModel(float64(n), 1000, 0.05, 0.002)
+		results = append(results, Result{N: n, Throughput: x, BytesThroughput: x * payload})
+	}
+
+	ops, err := FitUSL(results)
+	if err != nil {
+		t.Fatalf("FitUSL failed: %v", err)
+	}
+	byBytes, err := FitUSLBytes(results)
+	if err != nil {
+		t.Fatalf("FitUSLBytes failed: %v", err)
+	}
+
+	if math.Abs(byBytes.Lambda/ops.Lambda-payload) > 1e-6 {
+		t.Errorf("λ ratio %.4f, want %d", byBytes.Lambda/ops.Lambda, payload)
+	}
+	if math.Abs(byBytes.Alpha-ops.Alpha) > 1e-9 || math.Abs(byBytes.Beta-ops.Beta) > 1e-9 {
+		t.Errorf("α/β differ: bytes %.6f/%.6f, ops %.6f/%.6f", byBytes.Alpha, byBytes.Beta, ops.Alpha, ops.Beta)
+	}
+	if results[0].Throughput != 1000 {
+		t.Error("FitUSLBytes modified its input")
+	}
+}
+
+// TestUSLCoefficients_PeakConcurrency verifies the method matches CalculatePeakCapacity.
+func TestUSLCoefficients_PeakConcurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		alpha, beta float64
+	}{
+		{"retrograde", 0.05, 0.001},
+		{"no coherency", 0.1, 0},
+		{"negative beta", 0.1, -0.001},
+		{"full contention", 1.0, 0.001},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coeffs := USLCoefficients{Lambda: 1000, Alpha: tt.alpha, Beta: tt.beta}
+			got := coeffs.PeakConcurrency()
+			want := CalculatePeakCapacity(tt.alpha, tt.beta)
+			if got != want && !(math.IsInf(got, 1) && math.IsInf(want, 1)) {
+				t.Errorf("PeakConcurrency = %v, CalculatePeakCapacity = %v", got, want)
+			}
+		})
+	}
+}
+
+// TestUSLCoefficients_PeakThroughput verifies throughput at the rounded peak.
+func TestUSLCoefficients_PeakThroughput(t *testing.T) {
+	coeffs := USLCoefficients{Lambda: 1000, Alpha: 0.05, Beta: 0.001}
+
+	peak := coeffs.PeakConcurrency() // sqrt(950) ≈ 30.8
+	if math.Round(peak) != 31 {
+		t.Fatalf("Expected N_peak ≈ 31, got %.2f", peak)
+	}
+
+	if got, want := coeffs.PeakThroughput(), coeffs.PredictThroughput(31); got != want {
+		t.Errorf("PeakThroughput = %.2f, want %.2f", got, want)
+	}
+	for _, n := range []int{16, 64} {
+		if coeffs.PredictThroughput(n) > coeffs.PeakThroughput() {
+			t.Errorf("Throughput at N=%d exceeds peak", n)
+		}
+	}
+
+	// No peak: Amdahl asymptote λ/α
+	amdahl := USLCoefficients{Lambda: 1000, Alpha: 0.1}
+	if got := amdahl.PeakThroughput(); math.Abs(got-10000) > 1e-9 {
+		t.Errorf("Expected asymptote 10000, got %.2f", got)
+	}
+}
+
+// TestAssertPeakAbove verifies the assertion passes on data that scales past minN.
+func TestAssertPeakAbove(t *testing.T) {
+	results := make([]Result, 0)
+	for _, n := range []int{1, 2, 4, 8} {
+		results = append(results, Result{N: n, Throughput: uslModel(float64(n), 1000, 0.02, 0.0001)})
+	}
+
+	// N_peak = sqrt(0.98/0.0001) ≈ 99, well beyond the measured N=8
+	AssertPeakAbove(t, results, 64)
+}
+
+// TestAssertNoRetrograde_UnsortedResults verifies results are compared in N
+// order, not input order: reversed scalable data passes.
+func TestAssertNoRetrograde_UnsortedResults(t *testing.T) {
+	var results []Result
+	for _, n := range []int{16, 4, 1, 8, 2} {
+		results = append(results, Result{N: n, Throughput: uslModel(float64(n), 1000, 0.02, 0.0001)})
+	}
+
+	AssertNoRetrograde(t, results, DefaultAssertionConfig())
+	if results[0].N != 16 {
+		t.Error("AssertNoRetrograde reordered the caller's slice")
+	}
+}
+
+// TestEfficiencyIncreases_UnsortedAndJitter verifies levels are compared in
+// N order, not input order, and that a rise within
+// EfficiencyJitterTolerance is not reported.
+func TestEfficiencyIncreases_UnsortedAndJitter(t *testing.T) {
+	coeffs := USLCoefficients{Lambda: 1000}
+	flat := func(n int, scale float64) Result {
+		return Result{N: n, Throughput: 1000 * float64(n) * scale}
+	}
+
+	tests := []struct {
+		name    string
+		results []Result
+		want    int
+	}{
+		{"Sorted, flat", []Result{flat(1, 1), flat(2, 1), flat(4, 1), flat(8, 1)}, 0},
+		{"Reversed, flat", []Result{flat(8, 1), flat(4, 1), flat(2, 1), flat(1, 1)}, 0},
+		{"Shuffled, beyond MaxN first", []Result{flat(32, 1), flat(2, 0.99), flat(1, 1), flat(4, 0.98)}, 0},
+		{"0.05% jitter", []Result{flat(1, 1), flat(2, 0.999), flat(4, 0.9995), flat(8, 0.999)}, 0},
+		{"Shuffled, rise behind a level beyond MaxN", []Result{flat(32, 1), flat(2, 1.05), flat(1, 1)}, 1},
+		{"2% rise", []Result{flat(4, 1.02), flat(1, 1), flat(2, 1)}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures := efficiencyIncreases(tt.results, coeffs, 16)
+			if len(failures) != tt.want {
+				t.Errorf("Expected %d increases, got %q", tt.want, failures)
+			}
+		})
+	}
+}
+
+// TestAssertEfficiencyDecays verifies the assertion passes on USL-shaped data
+// and that superlinear data is reported at the N where efficiency rose.
+func TestAssertEfficiencyDecays(t *testing.T) {
+	cfg := DefaultAssertionConfig()
+
+	var sane []Result
+	for _, n := range []int{1, 2, 4, 8, 16} {
+		sane = append(sane, Result{N: n, Throughput: uslModel(float64(n), 1000, 0.02, 0.001)})
+	}
+	AssertEfficiencyDecays(t, sane, cfg)
+
+	// β < 0 data: FitUSL falls back to β=0 here, but measured efficiency
+	// still rises, so the step is reported
+	var superlinear []Result
+	for _, n := range []int{1, 2, 4, 8} {
+		superlinear = append(superlinear, Result{N: n, Throughput: uslModel(float64(n), 1000, 0.01, -0.002)})
+	}
+
+	coeffs, err := FitUSL(superlinear)
+	if err != nil {
+		t.Fatalf("FitUSL failed: %v", err)
+	}
+	failures := efficiencyIncreases(superlinear, coeffs, cfg.MaxN)
+	if len(failures) == 0 || !strings.Contains(failures[len(failures)-1], "N=4→8") {
+		t.Errorf("Expected the increase at N=4→8 to be reported, got %q", failures)
+	}
+
+	// With α < 0 the fit keeps β < 0
+	var negative []Result
+	for _, n := range []int{1, 2, 4, 8} {
+		negative = append(negative, Result{N: n, Throughput: uslModel(float64(n), 1000, -0.01, -0.001)})
+	}
+	if coeffs, _ := FitUSL(negative); coeffs.Beta >= 0 {
+		t.Fatalf("Expected a superlinear fit (β < 0), got β=%.6f", coeffs.Beta)
+	}
+
+	// Opted in: both pass
+	cfg.AllowSuperlinear = true
+	AssertEfficiencyDecays(t, superlinear, cfg)
+	AssertEfficiencyDecays(t, negative, cfg)
+}
+
+// TestAssertNoScalabilityRegression verifies an identical run passes and that
+// each regressed coefficient is reported.
+func TestAssertNoScalabilityRegression(t *testing.T) {
+	run := func(lambda, alpha, beta float64) []Result {
+		var results []Result
+		for _, n := range []int{1, 2, 4, 8, 16} {
+			results = append(results, Result{N: n, Throughput: uslModel(float64(n), lambda, alpha, beta)})
+		}
+		return results
+	}
+
+	cfg := DefaultRegressionConfig()
+	baseline := run(1000, 0.02, 0.0005)
+	AssertNoScalabilityRegression(t, baseline, baseline, cfg)
+
+	// Faster and less contended is not a regression
+	AssertNoScalabilityRegression(t, baseline, run(1100, 0.01, 0.0005), cfg)
+
+	base, _ := FitUSL(baseline)
+	tests := []struct {
+		name    string
+		current []Result
+		want    []string
+	}{
+		{"Contention up", run(1000, 0.05, 0.0005), []string{"α"}},
+		{"Coordination up", run(1000, 0.02, 0.005), []string{"β", "N_peak"}},
+		{"Serial slowdown", run(800, 0.02, 0.0005), []string{"λ"}},
+		{"Peak within tolerance", run(1000, 0.02, 0.00052), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			curr, err := FitUSL(tt.current)
+			if err != nil {
+				t.Fatalf("FitUSL failed: %v", err)
+			}
+
+			failures := scalabilityRegressions(base, curr, cfg)
+			if len(failures) != len(tt.want) {
+				t.Fatalf("Expected %d regressions, got %q", len(tt.want), failures)
+			}
+			for i, coeff := range tt.want {
+				if !strings.Contains(failures[i], coeff) {
+					t.Errorf("Regression %d should name %s: %s", i, coeff, failures[i])
+				}
+			}
+			t.Logf("✓ %s: %q", tt.name, failures)
+		})
+	}
+
+	// A β = 0 baseline has N_peak = ∞: fit noise within the β tolerance is
+	// not a regression, a real β increase still is
+	unbounded := USLCoefficients{Lambda: 1000, Alpha: 0.02}
+	noisy := USLCoefficients{Lambda: 1000, Alpha: 0.02, Beta: 0.0001}
+	if failures := scalabilityRegressions(unbounded, noisy, cfg); len(failures) != 0 {
+		t.Errorf("β 0 → 0.0001 is within tolerance, got %q", failures)
+	}
+	coordinated := USLCoefficients{Lambda: 1000, Alpha: 0.02, Beta: 0.005}
+	if failures := scalabilityRegressions(unbounded, coordinated, cfg); len(failures) != 2 {
+		t.Errorf("β 0 → 0.005 should report β and N_peak, got %q", failures)
+	}
+}
+
+// TestUSLCoefficients_CouplingR verifies a low-β fit stays well under 3.0 at
+// high N while a high-β fit crosses it as N grows.
+func TestUSLCoefficients_CouplingR(t *testing.T) {
+	lowBeta := USLCoefficients{Lambda: 1000, Alpha: 0.02, Beta: 0.00001}
+	highBeta := USLCoefficients{Lambda: 1000, Alpha: 0.02, Beta: 0.01}
+
+	if r := lowBeta.CouplingR(1000); r >= 2.0 {
+		t.Errorf("Low-β fit should stay well under 3.0 at N=1000, got r=%.4f", r)
+	}
+	AssertStableCoupling(t, lowBeta, 1000)
+
+	if r := highBeta.CouplingR(8); r >= StableDNAConstraint.MaxR {
+		t.Errorf("High-β fit should still be stable at N=8, got r=%.4f", r)
+	}
+	if r := highBeta.CouplingR(64); r < StableDNAConstraint.MaxR {
+		t.Errorf("High-β fit should cross 3.0 by N=64, got r=%.4f", r)
+	}
+
+	// Crossing at N = (2 − 2α)/(5β) = 39.2
+	if r := highBeta.CouplingR(39); r >= StableDNAConstraint.MaxR {
+		t.Errorf("Expected r < 3.0 at N=39, got %.4f", r)
+	}
+	if r := highBeta.CouplingR(40); r < StableDNAConstraint.MaxR {
+		t.Errorf("Expected r ≥ 3.0 at N=40, got %.4f", r)
+	}
+	AssertStableCoupling(t, highBeta, 39)
+
+	if got, want := CouplingRFromUSL(0.1, 0.02, 10), 1+0.2+1.0; math.Abs(got-want) > 1e-12 {
+		t.Errorf("CouplingRFromUSL(0.1, 0.02, 10) = %.4f, want %.4f", got, want)
+	}
+
+	t.Logf("✓ Low β: r(1000)=%.4f; high β: r(8)=%.4f, r(64)=%.4f",
+		lowBeta.CouplingR(1000), highBeta.CouplingR(8), highBeta.CouplingR(64))
+}
+
+// TestPredictThroughputSafe verifies full confidence within the measured
+// levels and confidence shrinking with distance beyond them.
+func TestPredictThroughputSafe(t *testing.T) {
+	var results []Result
+	for _, n := range []int{1, 2, 4, 8, 16} {
+		results = append(results, Result{N: n, Throughput: uslModel(float64(n), 1000, 0.03, 0.0005)})
+	}
+	coeffs, err := FitUSL(results)
+	if err != nil {
+		t.Fatalf("FitUSL failed: %v", err)
+	}
+	if coeffs.MaxMeasuredN != 16 {
+		t.Fatalf("MaxMeasuredN = %d, want 16", coeffs.MaxMeasuredN)
+	}
+
+	for _, n := range []int{1, 8, 16} {
+		tp, confidence := coeffs.PredictThroughputSafe(n)
+		if confidence != 1 || tp != coeffs.PredictThroughput(n) {
+			t.Errorf("N=%d in range: got %.2f at confidence %.3f", n, tp, confidence)
+		}
+	}
+
+	prev := 1.0
+	for _, n := range []int{17, 32, 64, 128} {
+		_, confidence := coeffs.PredictThroughputSafe(n)
+		if confidence >= prev {
+			t.Errorf("N=%d: confidence %.3f should be below %.3f", n, confidence, prev)
+		}
+		prev = confidence
+	}
+	if _, confidence := coeffs.PredictThroughputSafe(128); confidence != 0.125 {
+		t.Errorf("N=128 from N≤16: confidence %.3f, want 0.125", confidence)
+	}
+
+	if _, confidence := (USLCoefficients{Lambda: 1000, Alpha: 0.03}).PredictThroughputSafe(128); confidence != 1 {
+		t.Errorf("Unfitted coefficients should report confidence 1, got %.3f", confidence)
+	}
+
+	PrintAnalysis(t, results)
+}
+
+// TestCouplingRWithErrors verifies identical USL coefficients with a higher
+// error rate yield a higher r, closer to saturation, and that a zero error
+// rate reproduces CouplingRFromUSL.
+func TestCouplingRWithErrors(t *testing.T) {
+	const alpha, beta, n = 0.05, 0.02, 16
+
+	base := CouplingRFromUSL(alpha, beta, n)
+	if got := CouplingRWithErrors(alpha, beta, n, 0); got != base {
+		t.Errorf("Zero error rate: r = %.4f, want %.4f", got, base)
+	}
+
+	healthy := AutoScalerMetrics{Alpha: alpha, Beta: beta, CurrentN: n, ErrorRate: 0.01}
+	failing := healthy
+	failing.ErrorRate = 0.25
+
+	rHealthy, rFailing := healthy.CouplingR(), failing.CouplingR()
+	if rFailing <= rHealthy {
+		t.Errorf("Higher error rate should raise r: 1%% → %.4f, 25%% → %.4f", rHealthy, rFailing)
+	}
+	if rHealthy >= StableDNAConstraint.MaxR || rFailing < StableDNAConstraint.MaxR {
+		t.Errorf("Expected 1%% errors stable and 25%% saturated, got r=%.4f and r=%.4f", rHealthy, rFailing)
+	}
+
+	if got := CouplingRWithErrors(alpha, beta, n, 5); got != base+ErrorRWeight {
+		t.Errorf("Error rate should clamp to 1: r = %.4f, want %.4f", got, base+ErrorRWeight)
+	}
+
+	t.Logf("✓ Same α, β, N: 1%% errors → r=%.4f, 25%% errors → r=%.4f", rHealthy, rFailing)
+}
+
+// TestMergeResults_CrossHostFit verifies two hosts' result sets pool into one
+// set per level, including a level only one host measured, and fit a USL
+// whose R² is computed over the pooled data.
+func TestMergeResults_CrossHostFit(t *testing.T) {
+	host := func(lambda float64, levels []int) []Result {
+		var results []Result
+		for _, n := range levels {
+			tp := uslModel(float64(n), lambda, 0.03, 0.0005)
+			results = append(results, Result{
+				N:          n,
+				Duration:   time.Second,
+				Operations: int64(tp),
+				Throughput: float64(int64(tp)),
+				Errors:     int64(n),
+				Latencies:  []time.Duration{time.Duration(n) * time.Millisecond},
+				Recorder:   NewSliceRecorder(),
+			})
+		}
+		return results
+	}
+
+	// Host B is 10% slower and never ran N=16
+	a := host(1000, []int{1, 2, 4, 8, 16})
+	b := host(900, []int{1, 2, 4, 8})
+	merged := MergeResults(a, b)
+
+	if len(merged) != 5 {
+		t.Fatalf("Expected 5 levels, got %d", len(merged))
+	}
+	for i, r := range merged {
+		if i < 4 {
+			if r.Operations != a[i].Operations+b[i].Operations || r.Errors != 2*int64(r.N) || len(r.Latencies) != 2 {
+				t.Errorf("N=%d not pooled: ops=%d errors=%d latencies=%d", r.N, r.Operations, r.Errors, len(r.Latencies))
+			}
+			// Duration-weighted mean of equal-length runs
+			if want := float64(a[i].Operations+b[i].Operations) / 2; math.Abs(r.Throughput-want) > 1e-9 {
+				t.Errorf("N=%d: throughput %.2f, want %.2f", r.N, r.Throughput, want)
+			}
+		}
+		if r.Recorder == nil {
+			t.Errorf("N=%d: SliceRecorders should merge", r.N)
+		}
+	}
+	if last := merged[4]; last.N != 16 || last.Throughput != a[4].Throughput {
+		t.Errorf("N=16 should come from host A alone: %+v", last)
+	}
+
+	fit, err := FitUSL(merged)
+	if err != nil {
+		t.Fatalf("FitUSL failed: %v", err)
+	}
+	if fit.RSquared < 0.99 {
+		t.Errorf("Pooled fit should still explain the data: R² = %.4f", fit.RSquared)
+	}
+	if fit.Lambda < 900 || fit.Lambda > 1000 {
+		t.Errorf("Pooled λ should lie between the hosts: %.2f", fit.Lambda)
+	}
+
+	// Inputs untouched
+	if len(a[0].Latencies) != 1 || a[0].Recorder.Count() != 0 {
+		t.Error("MergeResults modified its input")
+	}
+
+	t.Logf("✓ Merged 2 hosts: λ=%.1f, α=%.4f, β=%.6f, R²=%.4f", fit.Lambda, fit.Alpha, fit.Beta, fit.RSquared)
+}
+
+// TestMergeResults_AllocsWithErrors verifies allocations are weighted by
+// Operations, which already excludes failed operations: a host with many
+// errors weighs in by its successes, not by successes minus errors.
+func TestMergeResults_AllocsWithErrors(t *testing.T) {
+	a := Result{N: 4, Duration: time.Second, Operations: 100, Errors: 300, AllocsPerOp: 2, BytesPerOp: 64}
+	b := Result{N: 4, Duration: time.Second, Operations: 300, Errors: 0, AllocsPerOp: 4, BytesPerOp: 128}
+
+	merged := MergeResults([]Result{a}, []Result{b})[0]
+	if want := (2.0*100 + 4*300) / 400; math.Abs(merged.AllocsPerOp-want) > 1e-9 {
+		t.Errorf("AllocsPerOp %.3f, want %.3f", merged.AllocsPerOp, want)
+	}
+	if want := (64.0*100 + 128*300) / 400; math.Abs(merged.BytesPerOp-want) > 1e-9 {
+		t.Errorf("BytesPerOp %.3f, want %.3f", merged.BytesPerOp, want)
+	}
+
+	t.Logf("✓ Merged %.2f allocs/op, %.1f B/op across a 75%% error host", merged.AllocsPerOp, merged.BytesPerOp)
+}
+
+// TestMergeResults_RecorderConfig verifies recorders pool only when their
+// configurations match: HDR histograms with another range or precision
+// have another bucket layout, and digests with another compression merge
+// into a different digest.
+func TestMergeResults_RecorderConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     LatencyRecorder
+		wantPool bool
+	}{
+		{"Same HDR", NewHDRRecorder(time.Second, 3), NewHDRRecorder(time.Second, 3), true},
+		{"HDR with a longer range", NewHDRRecorder(time.Second, 3), NewHDRRecorder(time.Minute, 3), false},
+		{"HDR with more digits", NewHDRRecorder(time.Second, 2), NewHDRRecorder(time.Second, 3), false},
+		{"Same t-digest", NewTDigestRecorder(100), NewTDigestRecorder(100), true},
+		{"t-digest with another compression", NewTDigestRecorder(100), NewTDigestRecorder(200), false},
+		{"Mixed types", NewSliceRecorder(), NewHDRRecorder(time.Second, 3), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.a.Record(time.Millisecond)
+			tt.b.Record(50 * time.Millisecond)
+
+			merged := MergeResults(
+				[]Result{{N: 1, Duration: time.Second, Recorder: tt.a}},
+				[]Result{{N: 1, Duration: time.Second, Recorder: tt.b}},
+			)[0]
+			if pooled := merged.Recorder != nil; pooled != tt.wantPool {
+				t.Fatalf("Pooled %v, want %v", pooled, tt.wantPool)
+			}
+			if tt.wantPool && merged.Recorder.Count() != 2 {
+				t.Errorf("Pooled recorder holds %d samples, want 2", merged.Recorder.Count())
+			}
+			t.Logf("✓ %s: pooled=%v", tt.name, tt.wantPool)
+		})
+	}
+}
+
+// TestMergeResults_KeepsRobustThroughput verifies merging does not undo a
+// robust ThroughputAggregation: a host whose median sub-window rate ignored
+// a stall keeps that rate instead of reverting to operations / duration.
+func TestMergeResults_KeepsRobustThroughput(t *testing.T) {
+	stalled := Result{N: 4, Duration: time.Second, Operations: 500, Throughput: 1000} // Half the run stalled
+	clean := Result{N: 4, Duration: 3 * time.Second, Operations: 3000, Throughput: 1000}
+
+	merged := MergeResults([]Result{stalled}, []Result{clean})
+	if got := merged[0].Throughput; math.Abs(got-1000) > 1e-9 {
+		t.Errorf("Merged throughput %.2f, want the hosts' robust 1000", got)
+	}
+	if got := merged[0].Operations; got != 3500 {
+		t.Errorf("Merged operations %d, want 3500", got)
+	}
+
+	t.Logf("✓ Robust throughput survives merging: %.0f ops/sec", merged[0].Throughput)
+}
@@ -0,0 +1,161 @@
+package lawbench
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// bifurcationJSON is the wire form of a BifurcationPoint.
+type bifurcationJSON struct {
+	R            float64 `json:"r"`
+	Period       int     `json:"period"`
+	Amplitude    float64 `json:"amplitude"`
+	Dimension    float64 `json:"dimension"`
+	AttractorMin float64 `json:"attractor_min"`
+	AttractorMax float64 `json:"attractor_max"`
+}
+
+// MarshalJSON encodes the point with its attractor summarized as the range
+// of its values: the period says how many there are, and a 128-cycle is
+// noise in a stored baseline. Attractor does not survive a round trip.
+func (b BifurcationPoint) MarshalJSON() ([]byte, error) {
+	wire := bifurcationJSON{
+		R:         b.R,
+		Period:    b.Period,
+		Amplitude: b.Amplitude,
+		Dimension: b.Dimension,
+	}
+	if len(b.Attractor) > 0 {
+		wire.AttractorMin, wire.AttractorMax = math.Inf(1), math.Inf(-1)
+		for _, x := range b.Attractor {
+			wire.AttractorMin = math.Min(wire.AttractorMin, x)
+			wire.AttractorMax = math.Max(wire.AttractorMax, x)
+		}
+	}
+	return json.Marshal(wire)
+}
+
+// UnmarshalJSON decodes a point written by MarshalJSON. Attractor is left
+// nil.
+func (b *BifurcationPoint) UnmarshalJSON(data []byte) error {
+	var wire bifurcationJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	*b = BifurcationPoint{
+		R:         wire.R,
+		Period:    wire.Period,
+		Amplitude: wire.Amplitude,
+		Dimension: wire.Dimension,
+	}
+	return nil
+}
+
+// analysisJSON is the wire form of a FeigenbaumAnalysis.
+type analysisJSON struct {
+	Bifurcations       []BifurcationPoint `json:"bifurcations"`
+	Delta              float64            `json:"delta"`
+	Alpha              float64            `json:"alpha"`
+	SaturationBoundary float64            `json:"saturation_boundary"`
+	FirstBifurcationR  float64            `json:"first_bifurcation_r"`
+	RecoveryTime       int                `json:"recovery_time"`
+	TransitTime        int                `json:"transit_time"`
+	FractalDimension   float64            `json:"fractal_dimension"`
+	BasinCompatible    bool               `json:"basin_compatible"`
+
+	LyapunovSpectrum         []LyapunovPoint `json:"lyapunov_spectrum,omitempty"`
+	LyapunovAtOperatingPoint float64         `json:"lyapunov_at_operating_point,omitempty"`
+}
+
+// MarshalJSON encodes the analysis with snake_case keys, so it can be stored
+// as a baseline and compared with DiffAnalysis after a refactor:
+//
+//	{
+//	  "bifurcations": [{"r": 3.0, "period": 2, ...}, ...],
+//	  "delta": 4.67,
+//	  "alpha": 2.5,
+//	  "saturation_boundary": 3.57,
+//	  ...
+//	}
+func (a FeigenbaumAnalysis) MarshalJSON() ([]byte, error) {
+	return json.Marshal(analysisJSON(a))
+}
+
+// UnmarshalJSON decodes an analysis written by MarshalJSON.
+func (a *FeigenbaumAnalysis) UnmarshalJSON(data []byte) error {
+	var wire analysisJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	*a = FeigenbaumAnalysis(wire)
+	return nil
+}
+
+// AnalysisDiff compares two FeigenbaumAnalysis results field by field (see
+// DiffAnalysis). Each pair is [old, new].
+type AnalysisDiff struct {
+	SaturationBoundary [2]float64 `json:"saturation_boundary"`
+	FirstBifurcationR  [2]float64 `json:"first_bifurcation_r"`
+	Bifurcations       [2]int     `json:"bifurcations"` // Number of period doublings seen
+	Delta              [2]float64 `json:"delta"`
+	Alpha              [2]float64 `json:"alpha"`
+	RecoveryTime       [2]int     `json:"recovery_time"`
+	TransitTime        [2]int     `json:"transit_time"`
+}
+
+// DiffAnalysis reports how the bifurcation structure changed from old to
+// new, e.g. a stored baseline against the current build:
+//
+//	diff := lawbench.DiffAnalysis(baseline, current)
+//	if diff.BoundaryApproached(operatingR) {
+//	    t.Errorf("saturation boundary moved %.3f → %.3f", diff.SaturationBoundary[0], diff.SaturationBoundary[1])
+//	}
+func DiffAnalysis(old, new FeigenbaumAnalysis) AnalysisDiff {
+	return AnalysisDiff{
+		SaturationBoundary: [2]float64{old.SaturationBoundary, new.SaturationBoundary},
+		FirstBifurcationR:  [2]float64{old.FirstBifurcationR, new.FirstBifurcationR},
+		Bifurcations:       [2]int{len(old.Bifurcations), len(new.Bifurcations)},
+		Delta:              [2]float64{old.Delta, new.Delta},
+		Alpha:              [2]float64{old.Alpha, new.Alpha},
+		RecoveryTime:       [2]int{old.RecoveryTime, new.RecoveryTime},
+		TransitTime:        [2]int{old.TransitTime, new.TransitTime},
+	}
+}
+
+// Changed reports whether any compared field differs.
+func (d AnalysisDiff) Changed() bool {
+	return d.SaturationBoundary[0] != d.SaturationBoundary[1] ||
+		d.FirstBifurcationR[0] != d.FirstBifurcationR[1] ||
+		d.Bifurcations[0] != d.Bifurcations[1] ||
+		d.Delta[0] != d.Delta[1] ||
+		d.Alpha[0] != d.Alpha[1] ||
+		d.RecoveryTime[0] != d.RecoveryTime[1] ||
+		d.TransitTime[0] != d.TransitTime[1]
+}
+
+// BoundaryShift returns the change in the saturation boundary (new − old):
+// negative means saturation now sets in at a lower control parameter.
+// A boundary of 0 (not reached within the sweep) counts as +Inf.
+func (d AnalysisDiff) BoundaryShift() float64 {
+	if d.SaturationBoundary[0] == d.SaturationBoundary[1] {
+		return 0
+	}
+	return boundaryOrInf(d.SaturationBoundary[1]) - boundaryOrInf(d.SaturationBoundary[0])
+}
+
+// BoundaryApproached reports whether the saturation boundary moved closer to
+// operatingR, the control parameter the system runs at: the margin to
+// saturation shrank, which is what a CI gate should fail on.
+func (d AnalysisDiff) BoundaryApproached(operatingR float64) bool {
+	oldMargin := math.Abs(boundaryOrInf(d.SaturationBoundary[0]) - operatingR)
+	newMargin := math.Abs(boundaryOrInf(d.SaturationBoundary[1]) - operatingR)
+	return newMargin < oldMargin
+}
+
+// boundaryOrInf maps an unset saturation boundary (0) to +Inf.
+func boundaryOrInf(boundary float64) float64 {
+	if boundary == 0 {
+		return math.Inf(1)
+	}
+	return boundary
+}
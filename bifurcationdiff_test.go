@@ -0,0 +1,96 @@
+package lawbench
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sampleAnalysis is a logistic-map-like analysis with its attractors.
+func sampleAnalysis(boundary float64) FeigenbaumAnalysis {
+	return FeigenbaumAnalysis{
+		Bifurcations: []BifurcationPoint{
+			{R: 3.0, Period: 2, Amplitude: 0.2, Attractor: []float64{0.51, 0.79}},
+			{R: 3.45, Period: 4, Amplitude: 0.08, Attractor: []float64{0.38, 0.83, 0.44, 0.87}},
+			{R: 3.54, Period: 8, Attractor: []float64{0.37, 0.83, 0.5, 0.89, 0.35, 0.82, 0.52, 0.88}},
+		},
+		Delta:              4.7,
+		Alpha:              2.5,
+		SaturationBoundary: boundary,
+		FirstBifurcationR:  3.0,
+		RecoveryTime:       12,
+		TransitTime:        40,
+		FractalDimension:   0.54,
+		BasinCompatible:    true,
+	}
+}
+
+// TestFeigenbaumAnalysis_JSONRoundTrip verifies everything but the
+// attractor values survives a round trip, and attractors are summarized.
+func TestFeigenbaumAnalysis_JSONRoundTrip(t *testing.T) {
+	original := sampleAnalysis(3.57)
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"saturation_boundary":3.57`) {
+		t.Errorf("Expected snake_case keys, got %s", data)
+	}
+	if !strings.Contains(string(data), `"attractor_min":0.35,"attractor_max":0.89`) {
+		t.Errorf("Expected the period-8 attractor summarized as its range, got %s", data)
+	}
+
+	var decoded FeigenbaumAnalysis
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	want := original
+	want.Bifurcations = make([]BifurcationPoint, len(original.Bifurcations))
+	for i, b := range original.Bifurcations {
+		b.Attractor = nil
+		want.Bifurcations[i] = b
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Errorf("Round trip:\n got  %+v\n want %+v", decoded, want)
+	}
+
+	if diff := DiffAnalysis(original, decoded); diff.Changed() {
+		t.Errorf("Round trip changed the analysis: %+v", diff)
+	}
+	t.Logf("✓ %d bytes: %s", len(data), data)
+}
+
+// TestDiffAnalysis_LoweredBoundary verifies a refactor that pulls saturation
+// down toward the operating point is detected.
+func TestDiffAnalysis_LoweredBoundary(t *testing.T) {
+	baseline := sampleAnalysis(3.57)
+	current := sampleAnalysis(3.2)
+	current.RecoveryTime = 30
+
+	diff := DiffAnalysis(baseline, current)
+	if !diff.Changed() {
+		t.Fatal("Expected a change")
+	}
+	if shift := diff.BoundaryShift(); shift > -0.36 || shift < -0.38 {
+		t.Errorf("BoundaryShift = %.3f, want -0.37", shift)
+	}
+	if !diff.BoundaryApproached(2.8) {
+		t.Error("Boundary moved 3.57 → 3.2, closer to r=2.8")
+	}
+	if diff.RecoveryTime != [2]int{12, 30} {
+		t.Errorf("RecoveryTime = %v, want [12 30]", diff.RecoveryTime)
+	}
+
+	if DiffAnalysis(current, baseline).BoundaryApproached(2.8) {
+		t.Error("Raising the boundary should not count as approaching")
+	}
+	if !DiffAnalysis(sampleAnalysis(0), current).BoundaryApproached(2.8) {
+		t.Error("A boundary appearing within the sweep should count as approaching")
+	}
+	if shift := DiffAnalysis(sampleAnalysis(0), sampleAnalysis(0)).BoundaryShift(); shift != 0 {
+		t.Errorf("No boundary on either side: shift %.3f, want 0", shift)
+	}
+}
@@ -0,0 +1,219 @@
+package lawbench
+
+import (
+	"sync"
+	"time"
+)
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "CLOSED"    // All requests admitted
+	BreakerOpen     BreakerState = "OPEN"      // All requests rejected
+	BreakerHalfOpen BreakerState = "HALF_OPEN" // A trickle of probes admitted
+)
+
+// Circuit breaker defaults, used when the corresponding field is zero.
+const (
+	DefaultBreakerOpenAfter     = 3
+	DefaultBreakerOpenDuration  = 30 * time.Second
+	DefaultBreakerProbeRequests = 5
+	DefaultBreakerStableFor     = 10 * time.Second
+)
+
+// CircuitBreaker turns the governor's decisions into a Closed/Open/HalfOpen
+// state machine, so a saturated service stops answering instead of inviting
+// a thundering herd of retries with a 503 on every other request.
+//
+//	Closed ──(OpenAfter consecutive ActionThrottle)──▶ Open
+//	Open ──(OpenDuration elapsed)──▶ HalfOpen
+//	HalfOpen ──(ActionStable held for StableFor)──▶ Closed
+//	HalfOpen ──(ActionThrottle or a failed probe)──▶ Open
+//
+// The breaker never reads r itself: it trusts the governor's throttle
+// hysteresis (stay in ActionThrottle until r drops well below 3.0), and adds
+// its own on top: a single throttle decision does not trip it, and a single
+// stable one does not close it.
+//
+// Feed every governor decision to Observe, ask Allow before serving, and
+// report the outcome with RecordResult:
+//
+//	cb.Observe(governor.Update(r, alpha, beta, inFlight))
+//	if !cb.Allow() {
+//	    http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+//	    return
+//	}
+//	err := serve(w, req)
+//	cb.RecordResult(err)
+//
+// Tunables are exported fields; zero means the default. CircuitBreaker is
+// safe for concurrent use.
+type CircuitBreaker struct {
+	OpenAfter     int           // Consecutive ActionThrottle decisions that trip the breaker
+	OpenDuration  time.Duration // Time to stay Open before probing
+	ProbeRequests int           // Concurrent probes admitted while HalfOpen
+	StableFor     time.Duration // How long the governor must report ActionStable to close
+
+	mu             sync.Mutex
+	state          BreakerState
+	throttleStreak int
+	openedAt       time.Time
+	stableSince    time.Time        // Zero while the governor is not stable
+	probes         int              // Probes admitted and not yet reported
+	now            func() time.Time // nil = time.Now
+}
+
+// NewCircuitBreaker creates a closed breaker with default tunables. The
+// zero value is equivalent.
+func NewCircuitBreaker() *CircuitBreaker {
+	return &CircuitBreaker{state: BreakerClosed}
+}
+
+// State returns the current state, moving Open to HalfOpen if OpenDuration
+// has elapsed.
+func (cb *CircuitBreaker) State() BreakerState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.advance(cb.clock())
+	return cb.state
+}
+
+// Observe feeds one governor decision into the state machine.
+func (cb *CircuitBreaker) Observe(action Action) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.clock()
+	cb.advance(now)
+
+	if action.Type == ActionThrottle {
+		cb.throttleStreak++
+	} else {
+		cb.throttleStreak = 0
+	}
+
+	switch cb.state {
+	case BreakerClosed:
+		if cb.throttleStreak >= cb.openAfter() {
+			cb.open(now)
+		}
+
+	case BreakerHalfOpen:
+		switch action.Type {
+		case ActionThrottle:
+			cb.open(now)
+		case ActionStable:
+			if cb.stableSince.IsZero() {
+				cb.stableSince = now
+			}
+			if now.Sub(cb.stableSince) >= cb.stableFor() {
+				cb.close()
+			}
+		default:
+			cb.stableSince = time.Time{} // Warning/pacing restarts the clock
+		}
+	}
+}
+
+// Allow reports whether a request may proceed. While HalfOpen it admits at
+// most ProbeRequests requests whose results have not yet been recorded.
+func (cb *CircuitBreaker) Allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.advance(cb.clock())
+
+	switch cb.state {
+	case BreakerClosed:
+		return true
+	case BreakerHalfOpen:
+		if cb.probes < cb.probeRequests() {
+			cb.probes++
+			return true
+		}
+	}
+	return false
+}
+
+// RecordResult reports the outcome of an admitted request. A failed probe
+// reopens a HalfOpen breaker; outside HalfOpen results are ignored, since
+// the governor, not the error rate, decides when to trip.
+func (cb *CircuitBreaker) RecordResult(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state != BreakerHalfOpen {
+		return
+	}
+
+	if cb.probes > 0 {
+		cb.probes--
+	}
+	if err != nil {
+		cb.open(cb.clock())
+	}
+}
+
+// advance moves Open to HalfOpen once OpenDuration has elapsed. The zero
+// value's empty state is Closed.
+func (cb *CircuitBreaker) advance(now time.Time) {
+	if cb.state == "" {
+		cb.state = BreakerClosed
+	}
+	if cb.state == BreakerOpen && now.Sub(cb.openedAt) >= cb.openDuration() {
+		cb.state = BreakerHalfOpen
+		cb.stableSince = time.Time{}
+		cb.probes = 0
+	}
+}
+
+func (cb *CircuitBreaker) open(now time.Time) {
+	cb.state = BreakerOpen
+	cb.openedAt = now
+	cb.stableSince = time.Time{}
+	cb.probes = 0
+}
+
+func (cb *CircuitBreaker) close() {
+	cb.state = BreakerClosed
+	cb.throttleStreak = 0
+	cb.stableSince = time.Time{}
+	cb.probes = 0
+}
+
+func (cb *CircuitBreaker) clock() time.Time {
+	if cb.now != nil {
+		return cb.now()
+	}
+	return time.Now()
+}
+
+func (cb *CircuitBreaker) openAfter() int {
+	if cb.OpenAfter > 0 {
+		return cb.OpenAfter
+	}
+	return DefaultBreakerOpenAfter
+}
+
+func (cb *CircuitBreaker) openDuration() time.Duration {
+	if cb.OpenDuration > 0 {
+		return cb.OpenDuration
+	}
+	return DefaultBreakerOpenDuration
+}
+
+func (cb *CircuitBreaker) probeRequests() int {
+	if cb.ProbeRequests > 0 {
+		return cb.ProbeRequests
+	}
+	return DefaultBreakerProbeRequests
+}
+
+func (cb *CircuitBreaker) stableFor() time.Duration {
+	if cb.StableFor > 0 {
+		return cb.StableFor
+	}
+	return DefaultBreakerStableFor
+}
@@ -0,0 +1,124 @@
+package lawbench
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreaker_FullCycle verifies Closed→Open→HalfOpen→Closed driven
+// by a scripted sequence of r values through a real governor.
+func TestCircuitBreaker_FullCycle(t *testing.T) {
+	g := NewGovernor(2.0)
+	g.throttleMinDuration = 0 // Exit throttle on r alone; the breaker supplies the dwell time
+
+	clock := time.Unix(0, 0)
+	cb := NewCircuitBreaker()
+	cb.now = func() time.Time { return clock }
+
+	steps := []struct {
+		name    string
+		r       float64
+		advance time.Duration
+		want    BreakerState
+	}{
+		{"Healthy", 2.0, 0, BreakerClosed},
+		{"First throttle", 3.2, time.Second, BreakerClosed},
+		{"Second throttle", 3.3, time.Second, BreakerClosed},
+		{"Sustained throttle trips", 3.4, time.Second, BreakerOpen},
+		{"Still saturated", 3.1, 10 * time.Second, BreakerOpen},
+		{"Cooling, governor hysteresis holds throttle", 2.5, 20 * time.Second, BreakerOpen},
+		{"Probing window opens, hysteresis reopens", 2.4, 30 * time.Second, BreakerOpen},
+		{"Probing again, stable", 1.5, 30 * time.Second, BreakerHalfOpen},
+		{"Stable, not long enough", 1.6, 5 * time.Second, BreakerHalfOpen},
+		{"Stable for StableFor", 1.5, 5 * time.Second, BreakerClosed},
+	}
+
+	for _, step := range steps {
+		clock = clock.Add(step.advance)
+		action := g.Update(step.r, 0.05, 0.01, 8)
+		cb.Observe(action)
+
+		if got := cb.State(); got != step.want {
+			t.Fatalf("%s (r=%.1f, governor %s): breaker %s, want %s", step.name, step.r, action.Type, got, step.want)
+		}
+		if allowed := cb.Allow(); allowed == (step.want == BreakerOpen) {
+			t.Errorf("%s: Allow()=%v in state %s", step.name, allowed, step.want)
+		}
+		cb.RecordResult(nil)
+		t.Logf("  %-45s r=%.1f %-9s → %s", step.name, step.r, action.Type, step.want)
+	}
+
+	t.Logf("✓ Closed → Open → HalfOpen → Closed, with governor hysteresis preventing an early close")
+}
+
+// TestCircuitBreaker_HalfOpenProbes verifies the probe trickle limit and that
+// a failed probe reopens the breaker.
+func TestCircuitBreaker_HalfOpenProbes(t *testing.T) {
+	clock := time.Unix(0, 0)
+	cb := NewCircuitBreaker()
+	cb.OpenAfter = 1
+	cb.ProbeRequests = 2
+	cb.now = func() time.Time { return clock }
+
+	cb.Observe(Action{Type: ActionThrottle})
+	if cb.Allow() {
+		t.Fatal("Open breaker should reject")
+	}
+
+	clock = clock.Add(DefaultBreakerOpenDuration)
+	if !cb.Allow() || !cb.Allow() {
+		t.Fatal("HalfOpen breaker should admit 2 probes")
+	}
+	if cb.Allow() {
+		t.Error("Third concurrent probe should be rejected")
+	}
+
+	cb.RecordResult(nil)
+	if !cb.Allow() {
+		t.Error("A completed probe should free a slot")
+	}
+
+	cb.RecordResult(errors.New("upstream timeout"))
+	if got := cb.State(); got != BreakerOpen {
+		t.Errorf("Failed probe should reopen, got %s", got)
+	}
+	if cb.Allow() {
+		t.Error("Reopened breaker should reject")
+	}
+
+	// Warning resets the stable clock in HalfOpen
+	clock = clock.Add(DefaultBreakerOpenDuration)
+	cb.Observe(Action{Type: ActionStable})
+	clock = clock.Add(DefaultBreakerStableFor - time.Second)
+	cb.Observe(Action{Type: ActionWarning})
+	clock = clock.Add(2 * time.Second)
+	cb.Observe(Action{Type: ActionStable})
+	if got := cb.State(); got != BreakerHalfOpen {
+		t.Errorf("Warning should restart the stable clock, got %s", got)
+	}
+}
+
+// TestCircuitBreaker_ZeroValue verifies a zero CircuitBreaker starts closed
+// with default tunables and the real clock.
+func TestCircuitBreaker_ZeroValue(t *testing.T) {
+	var cb CircuitBreaker
+
+	if got := cb.State(); got != BreakerClosed {
+		t.Fatalf("Expected CLOSED, got %q", got)
+	}
+	if !cb.Allow() {
+		t.Fatal("Zero-value breaker should admit requests")
+	}
+	cb.RecordResult(nil)
+
+	for i := 0; i < DefaultBreakerOpenAfter; i++ {
+		cb.Observe(Action{Type: ActionThrottle})
+	}
+	if got := cb.State(); got != BreakerOpen {
+		t.Errorf("Expected OPEN after %d throttles, got %q", DefaultBreakerOpenAfter, got)
+	}
+	if cb.Allow() {
+		t.Error("Open breaker should reject requests")
+	}
+}
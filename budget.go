@@ -0,0 +1,82 @@
+package lawbench
+
+import "fmt"
+
+// ComplexityBudget applies the 21% rule to a release instead of a single
+// deploy: the core work done so far earns MaxRatio units of complexity each,
+// and every deploy's DeltaComplexity is spent from that allowance. A feature
+// commit can overdraw its own ratio as long as earlier refactors left room.
+//
+//	budget := lawbench.NewComplexityBudget(800) // Core LOC at sprint start
+//	for _, deploy := range sprint {
+//	    if err := budget.Record(deploy); err != nil {
+//	        return err // Release has outgrown its core
+//	    }
+//	}
+//
+// Not safe for concurrent use.
+type ComplexityBudget struct {
+	MaxRatio float64 // Complexity earned per unit of core (default: MaxComplexityGrowthRatio)
+
+	core       float64 // Baseline plus Σ DeltaCriticalCore
+	complexity float64 // Σ DeltaComplexity
+}
+
+// NewComplexityBudget creates a budget seeded with coreBaseline units of
+// core work (e.g. Tier 1 LOC), worth coreBaseline × δ of complexity.
+func NewComplexityBudget(coreBaseline float64) *ComplexityBudget {
+	return &ComplexityBudget{
+		MaxRatio: MaxComplexityGrowthRatio,
+		core:     coreBaseline,
+	}
+}
+
+// Record applies one deploy's deltas: DeltaCriticalCore credits the budget
+// with MaxRatio × ΔCore and DeltaComplexity debits it (a negative delta,
+// such as deleting feature code, gives budget back). The deploy is recorded
+// either way; the error reports that the budget is now overdrawn. Deltas
+// that are not finite are rejected and not recorded.
+func (b *ComplexityBudget) Record(delta SystemIntegrityMetrics) error {
+	if !isFinite(delta.DeltaCriticalCore) || !isFinite(delta.DeltaComplexity) {
+		return fmt.Errorf("invalid deploy deltas: ΔCore=%v, ΔComplexity=%v",
+			delta.DeltaCriticalCore, delta.DeltaComplexity)
+	}
+
+	b.core += delta.DeltaCriticalCore
+	b.complexity += delta.DeltaComplexity
+
+	if remaining := b.Remaining(); remaining < 0 {
+		return fmt.Errorf(
+			"complexity budget exhausted: %.2f over the limit\n"+
+				"  ΣΔComplexity (Tier 2/3): %.2f\n"+
+				"  Core (Tier 1, baseline + ΣΔCore): %.2f\n"+
+				"  Ratio: %.4f > %.4f (δ)\n"+
+				"  Action: Add %.2f core work or remove complexity before the next deploy",
+			-remaining,
+			b.complexity, b.core,
+			b.complexity/b.core, b.maxRatio(),
+			-remaining/b.maxRatio(),
+		)
+	}
+	return nil
+}
+
+// Remaining returns the complexity that can still be added before the
+// release exceeds MaxRatio: core × MaxRatio − ΣΔComplexity. Negative when
+// overdrawn.
+func (b *ComplexityBudget) Remaining() float64 {
+	return b.core*b.maxRatio() - b.complexity
+}
+
+// Spent returns the cumulative DeltaComplexity recorded.
+func (b *ComplexityBudget) Spent() float64 {
+	return b.complexity
+}
+
+// maxRatio returns MaxRatio or its default.
+func (b *ComplexityBudget) maxRatio() float64 {
+	if b.MaxRatio <= 0 {
+		return MaxComplexityGrowthRatio
+	}
+	return b.MaxRatio
+}
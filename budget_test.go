@@ -0,0 +1,62 @@
+package lawbench
+
+import (
+	"math"
+	"testing"
+)
+
+// TestComplexityBudget_Sprint verifies feature commits deplete the budget,
+// refactors replenish it, and overdrawing it is an error.
+func TestComplexityBudget_Sprint(t *testing.T) {
+	budget := NewComplexityBudget(100) // 100 × δ ≈ 466.9 of complexity
+	initial := budget.Remaining()
+	if math.Abs(initial-100*FeigenbaumDelta) > 1e-9 {
+		t.Fatalf("Initial budget %.2f, want %.2f", initial, 100*FeigenbaumDelta)
+	}
+
+	steps := []struct {
+		name            string
+		deltaCore       float64
+		deltaComplexity float64
+		wantErr         bool
+	}{
+		{"feature A", 0, 300, false}, // 166.9 left: a single-deploy gate would block ΔCore=0
+		{"feature B", 5, 150, false}, // +23.3 −150 → 40.3
+		{"feature C", 0, 100, true},  // −59.7: overdrawn
+		{"refactor", 20, 0, false},   // +93.4 → 33.7
+		{"cleanup", 0, -50, false},   // Deleting features gives budget back → 83.7
+		{"feature D", 2, 80, false},  // +9.3 −80 → 13.0
+	}
+
+	prev := initial
+	for _, step := range steps {
+		err := budget.Record(SystemIntegrityMetrics{
+			DeltaCriticalCore: step.deltaCore,
+			DeltaComplexity:   step.deltaComplexity,
+		})
+		if (err != nil) != step.wantErr {
+			t.Fatalf("%s: error %v, want error=%v", step.name, err, step.wantErr)
+		}
+
+		remaining := budget.Remaining()
+		if want := prev + step.deltaCore*FeigenbaumDelta - step.deltaComplexity; math.Abs(remaining-want) > 1e-9 {
+			t.Errorf("%s: remaining %.2f, want %.2f", step.name, remaining, want)
+		}
+		if step.wantErr && remaining >= 0 {
+			t.Errorf("%s: error returned with %.2f remaining", step.name, remaining)
+		}
+		t.Logf("  %-10s ΔCore=%5.1f ΔComplexity=%6.1f → remaining %7.2f", step.name, step.deltaCore, step.deltaComplexity, remaining)
+		prev = remaining
+	}
+
+	if budget.Spent() != 580 {
+		t.Errorf("Spent %.2f, want 580", budget.Spent())
+	}
+
+	if err := budget.Record(SystemIntegrityMetrics{DeltaComplexity: math.NaN()}); err == nil {
+		t.Error("Expected an error for a NaN delta")
+	}
+	if budget.Remaining() != prev {
+		t.Errorf("NaN delta changed the budget: %.2f → %.2f", prev, budget.Remaining())
+	}
+}
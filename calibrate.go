@@ -0,0 +1,67 @@
+package lawbench
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// CalibratedFit is the result of CalibrateAndFit.
+type CalibratedFit struct {
+	Raw       USLCoefficients // Fit of the real operation
+	Scheduler USLCoefficients // Fit of a no-op at the same levels: harness and scheduler cost
+
+	// CorrectedAlpha is Raw.Alpha minus Scheduler.Alpha (never below 0): the
+	// contention attributable to the operation itself.
+	CorrectedAlpha float64
+
+	Calibration []Result // No-op measurements
+	Results     []Result // Real operation measurements
+}
+
+// Corrected returns Raw with Alpha replaced by CorrectedAlpha.
+func (f CalibratedFit) Corrected() USLCoefficients {
+	c := f.Raw
+	c.Alpha = f.CorrectedAlpha
+	return c
+}
+
+// CalibrateAndFit benchmarks a no-op at cfg.Levels to measure what the Go
+// scheduler and the harness cost on their own, then benchmarks op and fits
+// both. Above GOMAXPROCS, α conflates application contention with
+// scheduler overhead (see GOMAXPROCSWarning); the no-op's α is that
+// overhead, and subtracting it leaves the operation's own contention.
+//
+// The correction is first-order: it assumes the scheduler's share of α
+// doesn't depend on the operation. cfg.StrictGOMAXPROCS must be off for
+// levels above GOMAXPROCS, which is where the correction matters. Doubles
+// the benchmark time.
+func CalibrateAndFit(ctx context.Context, op Operation, cfg Config) (CalibratedFit, error) {
+	noop := func(ctx context.Context) error { return nil }
+
+	calibration, err := Run(ctx, noop, cfg)
+	if err != nil {
+		return CalibratedFit{}, fmt.Errorf("calibration: %w", err)
+	}
+	scheduler, err := FitUSL(calibration)
+	if err != nil {
+		return CalibratedFit{}, fmt.Errorf("calibration: %w", err)
+	}
+
+	results, err := Run(ctx, op, cfg)
+	if err != nil {
+		return CalibratedFit{}, err
+	}
+	raw, err := FitUSL(results)
+	if err != nil {
+		return CalibratedFit{}, err
+	}
+
+	return CalibratedFit{
+		Raw:            raw,
+		Scheduler:      scheduler,
+		CorrectedAlpha: math.Max(raw.Alpha-math.Max(scheduler.Alpha, 0), 0),
+		Calibration:    calibration,
+		Results:        results,
+	}, nil
+}
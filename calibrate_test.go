@@ -0,0 +1,51 @@
+package lawbench
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestCalibrateAndFit_LockFreeOverSubscribed verifies a lock-free CPU-bound
+// operation run above GOMAXPROCS has a corrected α below its raw α.
+func TestCalibrateAndFit_LockFreeOverSubscribed(t *testing.T) {
+	op := func(ctx context.Context) error {
+		x := 1.0
+		for i := 0; i < 2000; i++ {
+			x = x*1.0000001 + 1e-9
+		}
+		if x < 0 {
+			t.Error("unreachable")
+		}
+		return nil
+	}
+
+	cfg := DefaultConfig()
+	cfg.Duration = 100 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.MaxProcs = 2
+	cfg.Levels = []int{1, 2, 4, 8}
+
+	fit, err := CalibrateAndFit(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("CalibrateAndFit failed: %v", err)
+	}
+
+	if fit.Scheduler.Alpha <= 0 {
+		t.Fatalf("Expected positive scheduler α above GOMAXPROCS, got %.4f", fit.Scheduler.Alpha)
+	}
+	if fit.CorrectedAlpha >= fit.Raw.Alpha {
+		t.Errorf("Corrected α %.4f should be below raw α %.4f", fit.CorrectedAlpha, fit.Raw.Alpha)
+	}
+	if fit.CorrectedAlpha < 0 {
+		t.Errorf("Corrected α %.4f should not be negative", fit.CorrectedAlpha)
+	}
+	if c := fit.Corrected(); c.Alpha != fit.CorrectedAlpha || c.Beta != fit.Raw.Beta || c.Lambda != fit.Raw.Lambda {
+		t.Errorf("Corrected() = %+v, want raw with α=%.4f", c, fit.CorrectedAlpha)
+	}
+	if len(fit.Calibration) != 4 || len(fit.Results) != 4 {
+		t.Errorf("Expected 4 levels each, got %d/%d", len(fit.Calibration), len(fit.Results))
+	}
+
+	t.Logf("✓ Raw α=%.4f, scheduler α=%.4f → corrected α=%.4f", fit.Raw.Alpha, fit.Scheduler.Alpha, fit.CorrectedAlpha)
+}
@@ -0,0 +1,67 @@
+package lawbench
+
+// CapacityReport is a USL capacity plan: predicted throughput at chosen
+// concurrency levels and where the system peaks (see CapacityPlan).
+type CapacityReport struct {
+	Coeffs USLCoefficients
+
+	PeakN          float64 // N_peak (+Inf when β ≤ 0)
+	PeakThroughput float64 // Predicted throughput at the nearest integer N_peak
+
+	Levels []CapacityLevel // One per requested N, in request order
+}
+
+// CapacityLevel is the prediction for one concurrency level.
+type CapacityLevel struct {
+	N          int
+	Throughput float64 // Predicted ops/sec
+	Efficiency float64 // Throughput / (λ·N)
+
+	// Confidence is below 1 when N lies beyond the measured levels (see
+	// PredictThroughputSafe).
+	Confidence float64
+
+	// Retrograde is set when N is past N_peak: adding concurrency there
+	// lowers throughput.
+	Retrograde bool
+}
+
+// CapacityPlan fits results to the USL and predicts throughput at each of
+// targetNs, e.g. for a sizing API:
+//
+//	report, err := lawbench.CapacityPlan(results, []int{32, 64, 128})
+//	for _, level := range report.Levels {
+//	    if level.Retrograde { ... }
+//	}
+//
+// It returns the FitUSL error when results have fewer than three levels.
+func CapacityPlan(results []Result, targetNs []int) (CapacityReport, error) {
+	coeffs, err := FitUSL(results)
+	if err != nil {
+		return CapacityReport{}, err
+	}
+	return planCapacity(coeffs, targetNs), nil
+}
+
+// planCapacity builds the CapacityPlan report from fitted coefficients.
+func planCapacity(coeffs USLCoefficients, targetNs []int) CapacityReport {
+	report := CapacityReport{
+		Coeffs:         coeffs,
+		PeakN:          coeffs.PeakConcurrency(),
+		PeakThroughput: coeffs.PeakThroughput(),
+		Levels:         make([]CapacityLevel, 0, len(targetNs)),
+	}
+
+	for _, n := range targetNs {
+		throughput, confidence := coeffs.PredictThroughputSafe(n)
+		report.Levels = append(report.Levels, CapacityLevel{
+			N:          n,
+			Throughput: throughput,
+			Efficiency: coeffs.Efficiency(n),
+			Confidence: confidence,
+			Retrograde: float64(n) > report.PeakN,
+		})
+	}
+
+	return report
+}
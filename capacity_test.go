@@ -0,0 +1,53 @@
+package lawbench
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCapacityPlan_MarksRetrograde verifies levels straddling N_peak are
+// marked retrograde only past the peak, with predictions matching the fit.
+func TestCapacityPlan_MarksRetrograde(t *testing.T) {
+	var results []Result
+	for _, n := range []int{1, 2, 4, 8, 16, 32} {
+		results = append(results, Result{N: n, Throughput: uslModel(float64(n), 1000, 0.02, 0.002)})
+	}
+
+	report, err := CapacityPlan(results, []int{8, 16, 21, 23, 64})
+	if err != nil {
+		t.Fatalf("CapacityPlan failed: %v", err)
+	}
+
+	// N_peak = sqrt((1 − 0.02) / 0.002) ≈ 22.1
+	if math.Abs(report.PeakN-22.136) > 0.01 {
+		t.Errorf("PeakN = %.3f, want ≈ 22.136", report.PeakN)
+	}
+	if report.PeakThroughput != report.Coeffs.PredictThroughput(22) {
+		t.Errorf("PeakThroughput = %.2f, want prediction at N=22", report.PeakThroughput)
+	}
+
+	want := map[int]bool{8: false, 16: false, 21: false, 23: true, 64: true}
+	for i, level := range report.Levels {
+		if level.N != []int{8, 16, 21, 23, 64}[i] {
+			t.Fatalf("Levels out of request order: %+v", report.Levels)
+		}
+		if level.Retrograde != want[level.N] {
+			t.Errorf("N=%d: Retrograde = %v, want %v", level.N, level.Retrograde, want[level.N])
+		}
+		if level.Throughput != report.Coeffs.PredictThroughput(level.N) || level.Efficiency != report.Coeffs.Efficiency(level.N) {
+			t.Errorf("N=%d: prediction %+v doesn't match the fit", level.N, level)
+		}
+		if level.Retrograde && level.Throughput >= report.PeakThroughput {
+			t.Errorf("N=%d: retrograde throughput %.2f should be below the peak %.2f", level.N, level.Throughput, report.PeakThroughput)
+		}
+	}
+	if report.Levels[4].Confidence != 0.5 || report.Levels[3].Confidence != 1 {
+		t.Errorf("Expected confidence 1 within N≤32 and 0.5 at N=64, got %+v", report.Levels)
+	}
+
+	if _, err := CapacityPlan(results[:2], []int{8}); err == nil {
+		t.Error("Expected a fit error with two levels")
+	}
+
+	t.Logf("✓ N_peak %.1f: N=21 scaling, N=23 retrograde", report.PeakN)
+}
@@ -1,8 +1,15 @@
 package lawbench
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
 )
 
 // Feigenbaum constant: δ ≈ 4.669201609...
@@ -14,10 +21,21 @@ import (
 const FeigenbaumDelta = 4.6692
 
 // CriticalityScalingRatio is the inverse of Feigenbaum delta: 1/δ ≈ 0.214
-// Maximum permissible ratio of complexity added to extensible layers
-// relative to changes in critical core components.
+// The minimum share of a change that must be critical core work (the "21%
+// rule", see MaxComplexityGrowthRatio), and the largest correction to r
+// per recovery pulse.
 const CriticalityScalingRatio = 1.0 / FeigenbaumDelta // ≈ 0.214 (21.4%)
 
+// MaxComplexityGrowthRatio is the deployment gate shared by
+// CriticalityScalingConstraint, AnalyzeDeployment and the Governor:
+//
+//	ΔComplexity (Tier 2/3) / ΔCore (Tier 1) ≤ δ
+//
+// Each unit of core work earns δ ≈ 4.67 units of feature work. Stated the
+// other way round, core work must be at least 1/δ ≈ 21.4% of the
+// complexity added (the "21% rule").
+const MaxComplexityGrowthRatio = FeigenbaumDelta
+
 // SystemDNAConstraint defines the stable equilibrium range for coupling parameter r.
 // The logistic map equation: x_next = r·x·(1-x)
 // Stable equilibrium requires: 1 < r < 3
@@ -32,39 +50,68 @@ var StableDNAConstraint = SystemDNAConstraint{
 	MaxR: 3.0, // Above this: period-doubling cascade begins
 }
 
+// StabilityModel is the stable range of r for one RDynamics,
+// CriticalityScalingConstraint or Governor.
+//
+// The logistic map bifurcates at r = 3, but a real system may need a more
+// conservative ceiling (e.g. MaxR = 2.5 for a payment path). The zero value
+// means StableDNAConstraint, so existing instances keep the global default.
+type StabilityModel = SystemDNAConstraint
+
+// orDefault returns m, or StableDNAConstraint if m is the zero value.
+func (m StabilityModel) orDefault() StabilityModel {
+	if m == (StabilityModel{}) {
+		return StableDNAConstraint
+	}
+	return m
+}
+
 // CriticalityScalingConstraint enforces the Feigenbaum scaling law.
 // Ensures that complexity growth respects the universal rate constant.
 //
 // Mathematical formulation:
 //
-//	Δ Complexity (Tier 2/3) / Δ Critical Core (Tier 1) ≤ 1/δ
+//	Δ Complexity (Tier 2/3) / Δ Critical Core (Tier 1) ≤ δ
 //
 // Where:
 //   - Tier 1 (Critical Core): Immutable state, verified by Abstract Algebra (Law I)
 //   - Tier 2/3 (Extensible): High-churn components, supervised for failure (Law II)
 //   - δ ≈ 4.669: Universal constant from bifurcation theory
-//   - 1/δ ≈ 0.214: Maximum safe scaling ratio (21.4%)
+//   - 1/δ ≈ 0.214: Minimum share of core work in a change (21.4%)
+//
+// See MaxComplexityGrowthRatio.
 type CriticalityScalingConstraint struct {
 	DeltaCriticalCore float64 // Changes to Tier 1 (lines, complexity, API surface)
 	DeltaComplexity   float64 // Changes to Tier 2/3 (extensible layers)
-	MaxRatio          float64 // Maximum allowed ratio (default: 1/δ)
+	MaxRatio          float64 // Maximum allowed ratio (default: δ)
 	CurrentCouplingR  float64 // Current system coupling parameter
 	TargetCouplingR   float64 // Desired coupling parameter (< 3.0)
+
+	// Model is the stable range of r (zero = StableDNAConstraint).
+	Model StabilityModel
 }
 
 // NewCriticalityConstraint creates a constraint with Feigenbaum scaling law.
 func NewCriticalityConstraint(deltaCritical, deltaComplex float64) CriticalityScalingConstraint {
+	return NewCriticalityConstraintWithModel(deltaCritical, deltaComplex, StableDNAConstraint)
+}
+
+// NewCriticalityConstraintWithModel creates a constraint whose stable range
+// of r is model instead of the global StableDNAConstraint.
+func NewCriticalityConstraintWithModel(deltaCritical, deltaComplex float64, model StabilityModel) CriticalityScalingConstraint {
+	model = model.orDefault()
 	return CriticalityScalingConstraint{
 		DeltaCriticalCore: deltaCritical,
 		DeltaComplexity:   deltaComplex,
-		MaxRatio:          CriticalityScalingRatio,
-		CurrentCouplingR:  0.0,                      // Unknown initially
-		TargetCouplingR:   StableDNAConstraint.MaxR, // Default: stay below 3.0
+		MaxRatio:          MaxComplexityGrowthRatio,
+		CurrentCouplingR:  0.0,        // Unknown initially
+		TargetCouplingR:   model.MaxR, // Default: stay below the boundary
+		Model:             model,
 	}
 }
 
 // Validate checks if the scaling respects the Feigenbaum constraint.
-// Returns error if ratio exceeds 1/δ ≈ 0.214.
+// Returns error if ratio exceeds MaxRatio (δ ≈ 4.669 by default).
 func (c CriticalityScalingConstraint) Validate() error {
 	if c.DeltaCriticalCore == 0 {
 		return fmt.Errorf("zero critical core changes: cannot divide by zero")
@@ -74,7 +121,7 @@ func (c CriticalityScalingConstraint) Validate() error {
 
 	if ratio > c.MaxRatio {
 		return fmt.Errorf(
-			"criticality scaling violation: ratio %.4f exceeds Feigenbaum limit %.4f (1/δ)\n"+
+			"criticality scaling violation: ratio %.4f exceeds Feigenbaum limit %.4f (δ)\n"+
 				"  ΔComplexity (Tier 2/3): %.2f\n"+
 				"  ΔCritical Core (Tier 1): %.2f\n"+
 				"  Ratio: %.4f > %.4f\n"+
@@ -105,14 +152,15 @@ func (c CriticalityScalingConstraint) Headroom() float64 {
 
 // IsStableEquilibrium checks if coupling parameter r is in stable DNA range.
 func (c CriticalityScalingConstraint) IsStableEquilibrium() bool {
-	return c.CurrentCouplingR > StableDNAConstraint.MinR &&
-		c.CurrentCouplingR < StableDNAConstraint.MaxR
+	model := c.Model.orDefault()
+	return c.CurrentCouplingR > model.MinR &&
+		c.CurrentCouplingR < model.MaxR
 }
 
 // DistanceToInstabilityBoundary returns how close the system is to bifurcation cascade.
 // Returns negative if already in unstable region (r ≥ 3.0).
 func (c CriticalityScalingConstraint) DistanceToInstabilityBoundary() float64 {
-	return StableDNAConstraint.MaxR - c.CurrentCouplingR
+	return c.Model.orDefault().MaxR - c.CurrentCouplingR
 }
 
 // PredictCouplingImpact estimates how adding complexity affects coupling parameter r.
@@ -130,6 +178,184 @@ func (c CriticalityScalingConstraint) PredictCouplingImpact() float64 {
 	return c.CurrentCouplingR + couplingIncrease
 }
 
+// DeploymentVerdict is the outcome of the deployment gate, suitable for
+// printing as JSON from a pre-push hook. Non-finite numbers (the ∞ ratio of
+// a change with no core work) encode as null.
+type DeploymentVerdict struct {
+	Allowed    bool    `json:"allowed"`
+	Ratio      float64 `json:"ratio"`       // ΔComplexity / ΔCore (∞ when ΔCore = 0)
+	Limit      float64 `json:"limit"`       // Maximum allowed ratio (δ)
+	Headroom   float64 `json:"headroom"`    // Complexity that can still be added (< 0 when blocked)
+	ProjectedR float64 `json:"projected_r"` // r after the change (0 if currentR is unknown)
+	Message    string  `json:"message"`
+}
+
+// AnalyzeDeployment applies the deployment gate used by
+// Governor.CheckStructuralIntegrity to a single change: a deployment is
+// blocked when its complexity growth exceeds δ× its core work
+// (MaxComplexityGrowthRatio), or when it adds complexity with no core work
+// at all. currentR ≤ 0 means unknown and leaves ProjectedR at 0.
+func AnalyzeDeployment(deltaCore, deltaComplexity, currentR float64) DeploymentVerdict {
+	c := NewCriticalityConstraint(deltaCore, deltaComplexity)
+	c.CurrentCouplingR = math.Max(currentR, 0)
+
+	v := DeploymentVerdict{
+		Allowed:  true,
+		Limit:    c.MaxRatio,
+		Headroom: c.Headroom(),
+	}
+
+	// No changes on either side: nothing to gate
+	if deltaCore <= 0 && deltaComplexity <= 0 {
+		v.ProjectedR = c.CurrentCouplingR
+		v.Message = "No changes to gate"
+		return v
+	}
+
+	v.Ratio = c.Ratio()
+	v.ProjectedR = c.PredictCouplingImpact()
+
+	switch err := c.Validate(); {
+	case deltaCore == 0:
+		v.Allowed = false
+		v.Message = fmt.Sprintf("Pure technical debt: %.0f LOC of complexity with no core changes", deltaComplexity)
+	case err != nil:
+		v.Allowed = false
+		v.Message = fmt.Sprintf("Complexity growth ratio %.2f exceeds Feigenbaum limit %.2f", v.Ratio, v.Limit)
+	default:
+		v.Message = fmt.Sprintf("Complexity growth ratio %.2f within Feigenbaum limit %.2f (headroom %.0f LOC)",
+			v.Ratio, v.Limit, v.Headroom)
+	}
+
+	return v
+}
+
+// MarshalJSON encodes non-finite Ratio and ProjectedR as null.
+func (v DeploymentVerdict) MarshalJSON() ([]byte, error) {
+	type verdict DeploymentVerdict
+	return json.Marshal(struct {
+		verdict
+		Ratio      *float64 `json:"ratio"`
+		ProjectedR *float64 `json:"projected_r"`
+	}{verdict(v), finiteOrNil(v.Ratio), finiteOrNil(v.ProjectedR)})
+}
+
+// finiteOrNil returns &x, or nil if x is ±Inf or NaN.
+func finiteOrNil(x float64) *float64 {
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		return nil
+	}
+	return &x
+}
+
+// Tier classifies code by criticality. Lower tiers are more critical;
+// callers may define tiers beyond Tier3 for finer gradations.
+type Tier int
+
+const (
+	Tier1 Tier = iota + 1 // Critical core (kernel, state, algebra)
+	Tier2                 // Extensible (drivers, adapters)
+	Tier3                 // Peripheral (UI, plugins)
+)
+
+// WeightedCriticalityConstraint generalizes CriticalityScalingConstraint to
+// any number of tiers with per-tier risk weights.
+//
+// Mathematical formulation:
+//
+//	Σ(w_i · Δ_i, extensible tiers) / Σ(Δ_j, core tiers) ≤ δ
+//
+// A weight of 3 on the payment module's tier makes each changed line there
+// count three times toward complexity. With Tier1 as the only core tier and
+// all weights 1, this is exactly CriticalityScalingConstraint.
+type WeightedCriticalityConstraint struct {
+	Deltas    map[Tier]float64 // Changes per tier (lines, complexity, API surface)
+	Weights   map[Tier]float64 // Risk weight per extensible tier (missing = 1.0)
+	CoreTiers []Tier           // Tiers counted as critical core (nil = Tier1 only)
+	MaxRatio  float64          // Maximum allowed ratio (default: δ)
+}
+
+// NewWeightedCriticalityConstraint creates a weighted constraint with Tier1
+// as the critical core and the Feigenbaum scaling law as the limit.
+func NewWeightedCriticalityConstraint(deltas, weights map[Tier]float64) WeightedCriticalityConstraint {
+	return WeightedCriticalityConstraint{
+		Deltas:   deltas,
+		Weights:  weights,
+		MaxRatio: MaxComplexityGrowthRatio,
+	}
+}
+
+// isCore reports whether changes in tier count toward the critical core.
+func (c WeightedCriticalityConstraint) isCore(tier Tier) bool {
+	if c.CoreTiers == nil {
+		return tier == Tier1
+	}
+	for _, core := range c.CoreTiers {
+		if tier == core {
+			return true
+		}
+	}
+	return false
+}
+
+// split returns the core delta and the risk-weighted extensible delta.
+func (c WeightedCriticalityConstraint) split() (core, weighted float64) {
+	for tier, delta := range c.Deltas {
+		if c.isCore(tier) {
+			core += delta
+			continue
+		}
+
+		weight, ok := c.Weights[tier]
+		if !ok {
+			weight = 1.0
+		}
+		weighted += weight * delta
+	}
+	return core, weighted
+}
+
+// Validate checks if the weighted scaling respects the Feigenbaum constraint.
+// Returns error if the weighted ratio exceeds MaxRatio (δ ≈ 4.669 by default).
+func (c WeightedCriticalityConstraint) Validate() error {
+	core, weighted := c.split()
+	if core == 0 {
+		return fmt.Errorf("zero critical core changes: cannot divide by zero")
+	}
+
+	ratio := weighted / core
+	if ratio > c.MaxRatio {
+		return fmt.Errorf(
+			"weighted criticality scaling violation: ratio %.4f exceeds Feigenbaum limit %.4f (δ)\n"+
+				"  Weighted ΔComplexity (extensible tiers): %.2f\n"+
+				"  ΔCritical Core (core tiers): %.2f\n"+
+				"  Ratio: %.4f > %.4f\n"+
+				"  Action: Reduce high-risk tier changes or strengthen critical core",
+			ratio, c.MaxRatio,
+			weighted, core,
+			ratio, c.MaxRatio,
+		)
+	}
+
+	return nil
+}
+
+// Ratio returns the weighted complexity-to-core ratio.
+func (c WeightedCriticalityConstraint) Ratio() float64 {
+	core, weighted := c.split()
+	if core == 0 {
+		return math.Inf(1) // Infinite ratio (violation)
+	}
+	return weighted / core
+}
+
+// Headroom returns how much more weighted complexity can be added before
+// hitting the limit. Divide by a tier's weight for that tier's line budget.
+func (c WeightedCriticalityConstraint) Headroom() float64 {
+	core, weighted := c.split()
+	return core*c.MaxRatio - weighted
+}
+
 // SystemIntegrityMetrics captures the three-law enforcement status.
 type SystemIntegrityMetrics struct {
 	// Law I: Isolation (Abstract Algebra)
@@ -145,22 +371,45 @@ type SystemIntegrityMetrics struct {
 	// Law III: Criticality Scaling (Feigenbaum)
 	CriticalCoreLOC      int     // Tier 1 lines of code
 	ExtensibleLOC        int     // Tier 2/3 lines of code
-	ScalingRatio         float64 // Extensible/Critical ratio
-	FeigenbaumCompliance bool    // True if ratio ≤ 1/δ
+	ScalingRatio         float64 // Extensible/Critical ratio, gated like ΔComplexity/ΔCore
+	FeigenbaumCompliance bool    // True if ratio ≤ δ (MaxComplexityGrowthRatio)
 
 	// Deployment deltas (for Σ_R constraint checking)
 	DeltaCriticalCore float64 // Change in Tier 1 (LOC, API surface)
 	DeltaComplexity   float64 // Change in Tier 2/3 (LOC, dependencies)
 
 	// Derived: System DNA (coupling parameter r)
-	EstimatedCoupling     float64 // Current r value
+	EstimatedCoupling           float64 // Current r value
 	InstabilityBoundaryDistance float64 // Distance to r = 3.0
-	StableEquilibrium     bool    // True if 1 < r < 3
+	StableEquilibrium           bool    // True if 1 < r < 3
 }
 
+// DNAWeights scales each law's penalty in CalculateSystemDNAWeighted.
+//
+// Weights express how sensitive a system is to each kind of violation
+// (e.g. 2.0 on Supervision for a system where an unsupervised process
+// takes everything down). They do not move the stable range: r is still
+// judged against [1, 3), so heavier weights just reach 3.0 sooner.
+type DNAWeights struct {
+	Isolation   float64 // Law I: shared mutable state
+	Supervision float64 // Law II: unsupervised processes
+	Scaling     float64 // Law III: Feigenbaum scaling ratio
+}
+
+// DefaultDNAWeights weights the three laws equally.
+var DefaultDNAWeights = DNAWeights{Isolation: 1.0, Supervision: 1.0, Scaling: 1.0}
+
 // CalculateSystemDNA derives the coupling parameter r from metrics.
 // This is a model that combines all three laws into a single r estimate.
 func CalculateSystemDNA(metrics SystemIntegrityMetrics) float64 {
+	return CalculateSystemDNAWeighted(metrics, DefaultDNAWeights)
+}
+
+// CalculateSystemDNAWeighted is CalculateSystemDNA with each law's penalty
+// scaled by its weight:
+//
+//	r = 1 + w_I·isolation + w_S·supervision + w_F·scaling
+func CalculateSystemDNAWeighted(metrics SystemIntegrityMetrics, weights DNAWeights) float64 {
 	// Base coupling from isolation violations (Law I)
 	isolationPenalty := float64(metrics.MutableSharedState) /
 		float64(max(metrics.ImmutableOpsVerified, 1))
@@ -169,12 +418,16 @@ func CalculateSystemDNA(metrics SystemIntegrityMetrics) float64 {
 	supervisionPenalty := float64(metrics.UnsupervisedProcesses) /
 		float64(max(metrics.SupervisedProcesses, 1))
 
-	// Scaling penalty (Law III)
-	scalingPenalty := metrics.ScalingRatio / CriticalityScalingRatio
+	// Scaling penalty (Law III): 1.0 at the deployment gate
+	scalingPenalty := metrics.ScalingRatio / MaxComplexityGrowthRatio
 
 	// Model: r starts at 1.0 (minimum), increases with violations
-	// Each penalty can add up to 1.0, so worst case r ≈ 4.0 (deep instability)
-	r := 1.0 + isolationPenalty + supervisionPenalty + scalingPenalty
+	// With unit weights each penalty can add up to 1.0, so worst case
+	// r ≈ 4.0 (deep instability)
+	r := 1.0 +
+		weights.Isolation*isolationPenalty +
+		weights.Supervision*supervisionPenalty +
+		weights.Scaling*scalingPenalty
 
 	return r
 }
@@ -183,6 +436,10 @@ func CalculateSystemDNA(metrics SystemIntegrityMetrics) float64 {
 func ValidateSystemDNA(metrics SystemIntegrityMetrics) error {
 	r := CalculateSystemDNA(metrics)
 
+	if !isFinite(r) {
+		return fmt.Errorf("system coupling undefined: r=%v (non-finite ScalingRatio %v?)", r, metrics.ScalingRatio)
+	}
+
 	if r < StableDNAConstraint.MinR {
 		return fmt.Errorf("system coupling too low: r=%.4f < %.1f (trivial dynamics)",
 			r, StableDNAConstraint.MinR)
@@ -197,7 +454,7 @@ func ValidateSystemDNA(metrics SystemIntegrityMetrics) error {
 			r, StableDNAConstraint.MaxR,
 			metrics.MutableSharedState,
 			metrics.UnsupervisedProcesses,
-			metrics.ScalingRatio, CriticalityScalingRatio,
+			metrics.ScalingRatio, MaxComplexityGrowthRatio,
 		)
 	}
 
@@ -214,30 +471,62 @@ func max(a, b int) int {
 
 // RDynamics tracks the evolution of coupling parameter r over time.
 type RDynamics struct {
-	InitialR             float64   // Starting coupling parameter
-	CurrentR             float64   // Current coupling parameter
-	TargetR              float64   // Desired stable r (< 3.0)
-	History              []float64 // Historical r values
-	RecoveryEvents int       // Count of corrections applied
-	InSaturationZone          bool      // True if r ≥ 3.0
+	InitialR         float64   // Starting coupling parameter
+	CurrentR         float64   // Current coupling parameter
+	TargetR          float64   // Desired stable r (< 3.0)
+	History          []float64 // Historical r values
+	RecoveryEvents   int       // Count of corrections applied
+	InSaturationZone bool      // True if r ≥ 3.0
+
+	// PID controller state (see ApplyPIDRecovery)
+	PIDIntegral  float64 // Accumulated ∫e dt
+	PIDLastError float64 // Error at the previous PID step
+
+	// Model is the stable range of r (zero = StableDNAConstraint).
+	Model StabilityModel
+
+	// CeilingR caps r when events push it up (0 = Model.MaxR + 1, the
+	// r = 4.0 edge of the logistic map for the standard model). Beyond the
+	// ceiling the system is simply fully chaotic: more coupling cannot make
+	// it any less stable, and an unbounded r only skews downstream formulas.
+	CeilingR float64
+}
+
+// ceiling returns CeilingR or its default.
+func (rd *RDynamics) ceiling() float64 {
+	if rd.CeilingR > 0 {
+		return rd.CeilingR
+	}
+	return rd.Model.orDefault().MaxR + 1
 }
 
 // NewRDynamics creates r dynamics tracker with initial state.
 func NewRDynamics(initialR float64) RDynamics {
-	// At r = 3.0, system is AT instability threshold (fixed point loses stability)
-	// We treat r >= 3.0 as unstable region
-	inInstability := initialR >= StableDNAConstraint.MaxR
+	return NewRDynamicsWithModel(initialR, StableDNAConstraint)
+}
+
+// NewRDynamicsWithModel creates an r dynamics tracker whose saturation
+// boundary and recovery floor come from model instead of the global
+// StableDNAConstraint.
+func NewRDynamicsWithModel(initialR float64, model StabilityModel) RDynamics {
+	model = model.orDefault()
+
+	// At r = MaxR, system is AT instability threshold (fixed point loses stability)
+	// We treat r >= MaxR as unstable region
+	inInstability := initialR >= model.MaxR
 	return RDynamics{
-		InitialR:             initialR,
-		CurrentR:             initialR,
-		TargetR:              StableDNAConstraint.MaxR * 0.8, // Target 80% of limit (r ≈ 2.4)
-		History:              []float64{initialR},
-		RecoveryEvents: 0,
-		InSaturationZone:          inInstability,
+		InitialR:         initialR,
+		CurrentR:         initialR,
+		TargetR:          model.MaxR * 0.8, // Target 80% of limit (r ≈ 2.4)
+		History:          []float64{initialR},
+		RecoveryEvents:   0,
+		InSaturationZone: inInstability,
+		Model:            model,
 	}
 }
 
-// ApplyRecovery corrects r by enforcing Law I (Isolation).
+// ApplyRecovery corrects r by enforcing Law I (Isolation) and Law II
+// (Supervision).
 // This is INCREMENTAL correction: small adjustments, not large disruptions.
 //
 // Like a adaptive controller applying gentle pacing (gradual reduction):
@@ -249,6 +538,18 @@ func NewRDynamics(initialR float64) RDynamics {
 // - Maximum safe correction = 1/δ ≈ 0.214 per iteration
 // - Prevents throttling from being worse than the instability
 //
+// Correction strength combines both laws:
+//
+//	correctionFactor = 1/(1 + MutableSharedState/ImmutableOpsVerified)
+//	                 × 1/(1 + UnsupervisedProcesses/SupervisedProcesses)
+//	pulse            = min(0.5 × (r − 3.0) × correctionFactor, 1/δ)
+//
+// Isolation keeps a correction from propagating through shared state;
+// supervision lets failed components restart and shed their coupling. A
+// system with unsupervised processes therefore recovers more slowly than an
+// equally isolated, fully supervised one. Without supervision data (no
+// unsupervised processes) the factor is isolation alone.
+//
 // Returns the new r value after ONE small correction pulse.
 func (rd *RDynamics) ApplyRecovery(metrics SystemIntegrityMetrics) float64 {
 	if !rd.InSaturationZone {
@@ -259,14 +560,21 @@ func (rd *RDynamics) ApplyRecovery(metrics SystemIntegrityMetrics) float64 {
 	isolationRatio := float64(metrics.MutableSharedState) /
 		float64(max(metrics.ImmutableOpsVerified, 1))
 
+	// Calculate supervision quality (Law II compliance)
+	supervisionRatio := float64(metrics.UnsupervisedProcesses) /
+		float64(max(metrics.SupervisedProcesses, 1))
+
+	model := rd.Model.orDefault()
+
 	// How far into instability we are
-	instabilityDepth := rd.CurrentR - StableDNAConstraint.MaxR
+	instabilityDepth := rd.CurrentR - model.MaxR
 
 	// Correction strength based on isolation quality
 	// Perfect isolation (ratio = 0) → correction_factor = 1.0
 	// Poor isolation (ratio = 1) → correction_factor = 0.5
 	// No isolation (ratio >> 1) → correction_factor ≈ 0
-	correctionFactor := 1.0 / (1.0 + isolationRatio)
+	// Supervision scales it the same way: fully supervised → ×1.0
+	correctionFactor := 1.0 / (1.0 + isolationRatio) / (1.0 + supervisionRatio)
 
 	// CRITICAL: Correction pulse limited by 1/δ (Feigenbaum constraint)
 	// This is the maximum safe change per iteration
@@ -283,19 +591,19 @@ func (rd *RDynamics) ApplyRecovery(metrics SystemIntegrityMetrics) float64 {
 
 	// If we're exactly at boundary (r = 3.0), apply one more small pulse
 	// to ensure we're safely below (like incremental correction: one more beat)
-	if math.Abs(newR-StableDNAConstraint.MaxR) < 0.0001 {
-		newR = StableDNAConstraint.MaxR * 0.999 // 0.1% below boundary
+	if math.Abs(newR-model.MaxR) < 0.0001 {
+		newR = model.MaxR * 0.999 // 0.1% below boundary
 	}
 
 	// Enforce bounds
-	if newR < StableDNAConstraint.MinR {
-		newR = StableDNAConstraint.MinR
+	if newR < model.MinR {
+		newR = model.MinR
 	}
 
 	rd.CurrentR = newR
 	rd.History = append(rd.History, newR)
 	rd.RecoveryEvents++
-	rd.InSaturationZone = newR >= StableDNAConstraint.MaxR
+	rd.InSaturationZone = newR >= model.MaxR
 
 	return newR
 }
@@ -316,32 +624,166 @@ func (rd *RDynamics) ApplyRecoveryUntilStable(metrics SystemIntegrityMetrics, ma
 	return rd.CurrentR, iterations
 }
 
+// PIDGains are the proportional, integral and derivative gains for ApplyPIDRecovery.
+type PIDGains struct {
+	Kp float64 // Proportional: reacts to the current distance from setpoint
+	Ki float64 // Integral: removes steady-state offset near the boundary
+	Kd float64 // Derivative: damps the response as r approaches setpoint
+}
+
+// DefaultPIDGains returns gains tuned for r ∈ [1, 4] with dt = 1s.
+//
+// Kp = 0.5 saturates the 1/δ pulse limit when r is more than ~0.43 above
+// setpoint, so deep saturation recovers at the maximum safe rate, while
+// Ki keeps the controller moving near the boundary where the proportional
+// term alone would crawl.
+func DefaultPIDGains() PIDGains {
+	return PIDGains{Kp: 0.5, Ki: 0.1, Kd: 0.1}
+}
+
+// ApplyPIDRecovery applies ONE correction pulse computed by a PID controller.
+//
+// ApplyRecovery corrects 50% of the depth beyond 3.0 per pulse, which is
+// capped by 1/δ on deep saturation and approaches the boundary
+// asymptotically (halving the remaining distance each step). A PID
+// controller instead drives r toward an explicit setpoint below the
+// boundary:
+//
+//	e(t) = setpoint - r(t)
+//	u(t) = Kp·e + Ki·∫e dt + Kd·de/dt
+//
+// The correction is clamped to [-1/δ, 0]: recovery only removes coupling,
+// never faster than the Feigenbaum safety limit, and never past the
+// setpoint (removed coupling cannot be added back). While the output is
+// clamped the integral is frozen (anti-windup). Integral and last-error
+// state persist on RDynamics between calls; the derivative term is skipped
+// on the first step from a zero state.
+//
+// Returns the new r value.
+func (rd *RDynamics) ApplyPIDRecovery(setpoint float64, dt time.Duration, gains PIDGains) float64 {
+	dtSeconds := dt.Seconds()
+	if dtSeconds <= 0 {
+		dtSeconds = 1
+	}
+
+	e := setpoint - rd.CurrentR
+
+	var derivative float64
+	if rd.PIDIntegral != 0 || rd.PIDLastError != 0 {
+		derivative = (e - rd.PIDLastError) / dtSeconds
+	}
+
+	integral := rd.PIDIntegral + e*dtSeconds
+	u := gains.Kp*e + gains.Ki*integral + gains.Kd*derivative
+
+	// CRITICAL: Correction pulse limited by 1/δ (Feigenbaum constraint)
+	correction := math.Max(u, -CriticalityScalingRatio)
+	if correction > 0 {
+		correction = 0 // Recovery cannot add coupling
+	}
+	correction = math.Max(correction, math.Min(e, 0)) // Never drive r past the setpoint
+	if correction == u {
+		rd.PIDIntegral = integral // Anti-windup: integrate only when unclamped
+	}
+	rd.PIDLastError = e
+
+	model := rd.Model.orDefault()
+	newR := rd.CurrentR + correction
+	if newR < model.MinR {
+		newR = model.MinR
+	}
+
+	rd.CurrentR = newR
+	rd.History = append(rd.History, newR)
+	rd.RecoveryEvents++
+	rd.InSaturationZone = newR >= model.MaxR
+
+	return newR
+}
+
 // ApplyFeigenbaumGovernance prevents r from growing due to scaling.
-// This is the preventive constraint: ensure Δr < 1/δ threshold.
+// This is the preventive constraint: ensure Δr ≤ 1/δ per step.
 //
 // Mathematical model:
 //
-//	r_next = r_current + (scalingRatio / δ)
+//	r_next = r_current + (scalingRatio / δ²)
 //
-// If scalingRatio ≤ 1/δ, then Δr is bounded and r stays stable.
-// If scalingRatio > 1/δ, then Δr accelerates and r → instability.
+// If scalingRatio ≤ δ (MaxComplexityGrowthRatio), then Δr ≤ 1/δ and r stays stable.
+// If scalingRatio > δ, then Δr exceeds 1/δ and r → instability.
+// r never rises past CeilingR.
 func (rd *RDynamics) ApplyFeigenbaumGovernance(scalingRatio float64) float64 {
 	// Calculate r increment from scaling
 	// Model: Each unit of scaling ratio adds (1/δ²) to r
 	// This reflects that complexity growth accelerates coupling nonlinearly
 	rIncrement := scalingRatio * (1.0 / (FeigenbaumDelta * FeigenbaumDelta))
 
-	// Apply increment
-	newR := rd.CurrentR + rIncrement
+	// Apply increment, up to the ceiling
+	newR := math.Min(rd.CurrentR+rIncrement, math.Max(rd.CurrentR, rd.ceiling()))
 
 	// Update state
 	rd.CurrentR = newR
 	rd.History = append(rd.History, newR)
-	rd.InSaturationZone = newR >= StableDNAConstraint.MaxR
+	rd.InSaturationZone = newR >= rd.Model.orDefault().MaxR
 
 	return newR
 }
 
+// TrendSlope returns the least-squares slope of History per step.
+//
+// A positive slope means r is creeping up release over release even while
+// every individual value sits in a safe zone. Returns 0 with fewer than two
+// samples.
+func (rd *RDynamics) TrendSlope() float64 {
+	return historySlope(rd.History)
+}
+
+// IsEscalating reports whether r has a sustained upward trend: the
+// least-squares slope over the last window samples is positive.
+//
+// This is an early warning: it fires while r is still below 3.0, before
+// the zone checks in Governor.CheckStructuralIntegrity would react.
+// Returns false when window < 2 or History holds fewer than window samples.
+func (rd *RDynamics) IsEscalating(window int) bool {
+	if window < 2 || len(rd.History) < window {
+		return false
+	}
+	return historySlope(rd.History[len(rd.History)-window:]) > escalationSlopeEpsilon
+}
+
+// escalationSlopeEpsilon absorbs rounding in the slope of a flat history.
+const escalationSlopeEpsilon = 1e-9
+
+// historySlope fits r against its index.
+func historySlope(history []float64) float64 {
+	if len(history) < 2 {
+		return 0
+	}
+
+	steps := make([]float64, len(history))
+	for i := range steps {
+		steps[i] = float64(i)
+	}
+	return leastSquaresSlope(steps, history)
+}
+
+// ExportHistory writes History as "index,r" CSV with a header row, for
+// tracking the trajectory across releases in a spreadsheet or plot.
+func (rd *RDynamics) ExportHistory(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"index", "r"}); err != nil {
+		return err
+	}
+
+	for i, r := range rd.History {
+		if err := cw.Write([]string{strconv.Itoa(i), strconv.FormatFloat(r, 'g', -1, 64)}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
 // CorrectRAfterRecovery combines both mechanisms:
 // 1. Recovery (active correction via Law I)
 // 2. Feigenbaum governance (preventive constraint via Law III)
@@ -366,32 +808,36 @@ func CorrectRAfterRecovery(rd *RDynamics, metrics SystemIntegrityMetrics, scalin
 //
 // Mathematical formulation:
 //
-//	Σ_R ≡ Enforce { 1 < r_eff(x, ΔC) < 3 } via { ΔComplexity/ΔCore ≤ 1/δ }
+//	Σ_R ≡ Enforce { 1 < r_eff(x, ΔC) < 3 } via { ΔComplexity/ΔCore ≤ δ }
+//
+// metrics.ScalingRatio is held to the same MaxComplexityGrowthRatio as the
+// deployment gate.
 func PerpetualStructuralIntegrity(rd *RDynamics, metrics SystemIntegrityMetrics) error {
 	// Check DNA constraint
-	if rd.CurrentR < StableDNAConstraint.MinR {
+	model := rd.Model.orDefault()
+	if rd.CurrentR < model.MinR {
 		return fmt.Errorf("Σ_R violation: r=%.4f < %.1f (system trivial/dead)",
-			rd.CurrentR, StableDNAConstraint.MinR)
+			rd.CurrentR, model.MinR)
 	}
 
-	if rd.CurrentR >= StableDNAConstraint.MaxR {
+	if rd.CurrentR >= model.MaxR {
 		return fmt.Errorf("Σ_R violation: r=%.4f ≥ %.1f (unstable region)\n"+
 			"  Recovery required: Enforce Law I (Isolation)\n"+
 			"  Current isolation ratio: %.4f (mutable/immutable)\n"+
-			"  Target: Reduce mutable state to achieve r < 3.0",
-			rd.CurrentR, StableDNAConstraint.MaxR,
+			"  Target: Reduce mutable state to achieve r < %.1f",
+			rd.CurrentR, model.MaxR, model.MaxR,
 			float64(metrics.MutableSharedState)/float64(max(metrics.ImmutableOpsVerified, 1)))
 	}
 
 	// Check Feigenbaum constraint
 	scalingRatio := metrics.ScalingRatio
-	if scalingRatio > CriticalityScalingRatio {
-		return fmt.Errorf("Σ_R violation: scaling ratio %.4f > %.4f (1/δ)\n"+
+	if scalingRatio > MaxComplexityGrowthRatio {
+		return fmt.Errorf("Σ_R violation: scaling ratio %.4f > %.4f (δ)\n"+
 			"  Risk: r will increase toward instability threshold\n"+
 			"  Current r: %.4f\n"+
 			"  Predicted r (if unchecked): %.4f\n"+
 			"  Action: Reduce complexity growth or strengthen critical core",
-			scalingRatio, CriticalityScalingRatio,
+			scalingRatio, MaxComplexityGrowthRatio,
 			rd.CurrentR,
 			rd.CurrentR+scalingRatio*(1.0/(FeigenbaumDelta*FeigenbaumDelta)))
 	}
@@ -415,6 +861,10 @@ type REvent struct {
 
 // SimulateRTrajectory models how r evolves under a sequence of architectural decisions.
 // This is the predictive tool: "What happens to r if we add this feature?"
+//
+// Scaling and violation events never push r past the RDynamics ceiling
+// (4.0, see RDynamics.CeilingR): a trajectory that reaches it is fully
+// chaotic, and recovery events climb down from there.
 func SimulateRTrajectory(initialR float64, events []REvent) RTrajectory {
 	rd := NewRDynamics(initialR)
 	trajectory := RTrajectory{
@@ -423,25 +873,145 @@ func SimulateRTrajectory(initialR float64, events []REvent) RTrajectory {
 	}
 
 	for _, event := range events {
-		switch event.Type {
-		case "scaling":
-			// Apply Feigenbaum governance
-			rd.ApplyFeigenbaumGovernance(event.ScalingRatio)
-
-		case "recovery":
-			// Apply active correction
-			rd.ApplyRecovery(event.Metrics)
-
-		case "violation":
-			// Isolation violation increases r directly
-			violationPenalty := float64(event.Metrics.MutableSharedState) /
-				float64(max(event.Metrics.ImmutableOpsVerified, 1))
-			rd.CurrentR += violationPenalty
-			rd.InSaturationZone = rd.CurrentR >= StableDNAConstraint.MaxR
-		}
-
+		applyREvent(&rd, event)
 		trajectory.R = append(trajectory.R, rd.CurrentR)
 	}
 
 	return trajectory
 }
+
+// applyREvent advances rd by one architectural event.
+func applyREvent(rd *RDynamics, event REvent) {
+	switch event.Type {
+	case "scaling":
+		// Apply Feigenbaum governance
+		rd.ApplyFeigenbaumGovernance(event.ScalingRatio)
+
+	case "recovery":
+		// Apply active correction
+		rd.ApplyRecovery(event.Metrics)
+
+	case "violation":
+		// Isolation violation increases r directly
+		violationPenalty := float64(event.Metrics.MutableSharedState) /
+			float64(max(event.Metrics.ImmutableOpsVerified, 1))
+		rd.CurrentR = math.Min(rd.CurrentR+violationPenalty, math.Max(rd.CurrentR, rd.ceiling()))
+		rd.InSaturationZone = rd.CurrentR >= rd.Model.orDefault().MaxR
+	}
+}
+
+// WeightedEvent pairs an REvent with its relative probability of being the
+// next architectural change. Probabilities need not sum to 1.
+type WeightedEvent struct {
+	Event       REvent
+	Probability float64
+}
+
+// MonteCarloTrajectory is the distribution of outcomes over many sampled
+// event sequences.
+type MonteCarloTrajectory struct {
+	Trials int
+
+	// Fraction of trials in which r reached 3.0 at any step, even if a later
+	// recovery brought it back down
+	InstabilityProbability float64
+
+	FinalR       []float64 // Final r of every trial, sorted ascending
+	MedianFinalR float64
+
+	MedianTrajectory []float64   // Per-step median r across trials
+	WorstCase        RTrajectory // Trial with the highest final r
+}
+
+// SimulateRTrajectoryMonteCarlo samples trials sequences of steps events
+// from eventDist and runs each through SimulateRTrajectory.
+//
+// This answers "what's the chance our roadmap pushes us into instability?"
+// when the roadmap is a mix ("likely 3 features and 1 refactor") rather than
+// a fixed sequence. A nil rng is seeded from the clock; pass a seeded one for
+// reproducible plans.
+func SimulateRTrajectoryMonteCarlo(initialR float64, eventDist []WeightedEvent, steps, trials int, rng *rand.Rand) (MonteCarloTrajectory, error) {
+	if steps < 1 || trials < 1 {
+		return MonteCarloTrajectory{}, fmt.Errorf("need at least 1 step and 1 trial, got steps=%d trials=%d", steps, trials)
+	}
+
+	cumulative := make([]float64, len(eventDist))
+	total := 0.0
+	for i, we := range eventDist {
+		if we.Probability < 0 || math.IsNaN(we.Probability) {
+			return MonteCarloTrajectory{}, fmt.Errorf("event %d (%s): invalid probability %v", i, we.Event.Type, we.Probability)
+		}
+		total += we.Probability
+		cumulative[i] = total
+	}
+	if total == 0 || math.IsInf(total, 1) {
+		return MonteCarloTrajectory{}, fmt.Errorf("event distribution has total probability %v", total)
+	}
+
+	if rng == nil {
+		rng = newRand()
+	}
+
+	result := MonteCarloTrajectory{
+		Trials: trials,
+		FinalR: make([]float64, trials),
+	}
+	byStep := make([][]float64, steps+1)
+	for i := range byStep {
+		byStep[i] = make([]float64, trials)
+	}
+
+	crossed := 0
+	events := make([]REvent, steps)
+	for trial := 0; trial < trials; trial++ {
+		for i := range events {
+			u := rng.Float64() * total
+			j := sort.SearchFloat64s(cumulative, u)
+			for j < len(eventDist)-1 && cumulative[j] <= u {
+				j++ // Land on an event with non-zero probability
+			}
+			events[i] = eventDist[j].Event
+		}
+
+		trajectory := SimulateRTrajectory(initialR, events)
+		for step, r := range trajectory.R {
+			byStep[step][trial] = r
+		}
+
+		for _, r := range trajectory.R {
+			if r >= StableDNAConstraint.MaxR {
+				crossed++
+				break
+			}
+		}
+
+		final := trajectory.R[len(trajectory.R)-1]
+		result.FinalR[trial] = final
+		if trial == 0 || final > result.WorstCase.R[len(result.WorstCase.R)-1] {
+			trajectory.Events = append([]REvent(nil), events...)
+			result.WorstCase = trajectory
+		}
+	}
+
+	result.InstabilityProbability = float64(crossed) / float64(trials)
+
+	sort.Float64s(result.FinalR)
+	result.MedianFinalR = median(result.FinalR)
+
+	result.MedianTrajectory = make([]float64, steps+1)
+	for step, rs := range byStep {
+		sort.Float64s(rs)
+		result.MedianTrajectory[step] = median(rs)
+	}
+
+	return result, nil
+}
+
+// median returns the middle of a sorted, non-empty slice.
+func median(sorted []float64) float64 {
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
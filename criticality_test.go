@@ -1,7 +1,9 @@
 package lawbench
 
 import (
+	"encoding/json"
 	"math"
+	"strings"
 	"testing"
 )
 
@@ -54,9 +56,9 @@ func TestSystemDNAConstraint(t *testing.T) {
 
 // TestCriticalityConstraint_ValidScaling verifies compliant scaling passes.
 func TestCriticalityConstraint_ValidScaling(t *testing.T) {
-	// Scenario: Add 10 units to critical core, 2 units to extensible
-	// Ratio: 2/10 = 0.20 < 0.214 ✓
-	constraint := NewCriticalityConstraint(10.0, 2.0)
+	// Scenario: Add 10 units to critical core, 40 units to extensible
+	// Ratio: 40/10 = 4.0 < 4.669 ✓
+	constraint := NewCriticalityConstraint(10.0, 40.0)
 
 	err := constraint.Validate()
 	if err != nil {
@@ -64,41 +66,41 @@ func TestCriticalityConstraint_ValidScaling(t *testing.T) {
 	}
 
 	ratio := constraint.Ratio()
-	if ratio >= CriticalityScalingRatio {
-		t.Errorf("Ratio %.4f should be < %.4f", ratio, CriticalityScalingRatio)
+	if ratio >= MaxComplexityGrowthRatio {
+		t.Errorf("Ratio %.4f should be < %.4f", ratio, MaxComplexityGrowthRatio)
 	}
 
 	t.Logf("✓ Valid scaling: ΔCore=%.0f, ΔComplex=%.0f, ratio=%.4f < %.4f",
 		constraint.DeltaCriticalCore, constraint.DeltaComplexity,
-		ratio, CriticalityScalingRatio)
+		ratio, MaxComplexityGrowthRatio)
 }
 
 // TestCriticalityConstraint_ViolatesScaling verifies excessive scaling fails.
 func TestCriticalityConstraint_ViolatesScaling(t *testing.T) {
-	// Scenario: Add 10 units to critical core, 5 units to extensible
-	// Ratio: 5/10 = 0.50 > 0.214 ✗
-	constraint := NewCriticalityConstraint(10.0, 5.0)
+	// Scenario: Add 10 units to critical core, 50 units to extensible
+	// Ratio: 50/10 = 5.0 > 4.669 ✗
+	constraint := NewCriticalityConstraint(10.0, 50.0)
 
 	err := constraint.Validate()
 	if err == nil {
-		t.Error("Invalid scaling was accepted (should reject ratio > 1/δ)")
+		t.Error("Invalid scaling was accepted (should reject ratio > δ)")
 	}
 
 	ratio := constraint.Ratio()
-	if ratio <= CriticalityScalingRatio {
-		t.Errorf("Ratio %.4f should be > %.4f", ratio, CriticalityScalingRatio)
+	if ratio <= MaxComplexityGrowthRatio {
+		t.Errorf("Ratio %.4f should be > %.4f", ratio, MaxComplexityGrowthRatio)
 	}
 
-	t.Logf("✓ Correctly rejected: ratio=%.4f > %.4f (1/δ)\n  Error: %v",
-		ratio, CriticalityScalingRatio, err)
+	t.Logf("✓ Correctly rejected: ratio=%.4f > %.4f (δ)\n  Error: %v",
+		ratio, MaxComplexityGrowthRatio, err)
 }
 
-// TestCriticalityConstraint_BoundaryCase tests exact 1/δ ratio.
+// TestCriticalityConstraint_BoundaryCase tests exact δ ratio.
 func TestCriticalityConstraint_BoundaryCase(t *testing.T) {
 	// Scenario: Exactly at the Feigenbaum limit
-	// Ratio: 0.214 = 1/δ (boundary)
+	// Ratio: 4.669 = δ (boundary)
 	deltaCritical := 100.0
-	deltaComplex := deltaCritical * CriticalityScalingRatio
+	deltaComplex := deltaCritical * MaxComplexityGrowthRatio
 
 	constraint := NewCriticalityConstraint(deltaCritical, deltaComplex)
 
@@ -108,24 +110,24 @@ func TestCriticalityConstraint_BoundaryCase(t *testing.T) {
 	}
 
 	ratio := constraint.Ratio()
-	expected := CriticalityScalingRatio
+	expected := MaxComplexityGrowthRatio
 	if math.Abs(ratio-expected) > 1e-10 {
 		t.Errorf("Boundary ratio incorrect: got %.10f, expected %.10f",
 			ratio, expected)
 	}
 
-	t.Logf("✓ Boundary case: ratio=%.10f = 1/δ (exactly at limit)",
+	t.Logf("✓ Boundary case: ratio=%.10f = δ (exactly at limit)",
 		ratio)
 }
 
 // TestCriticalityConstraint_Headroom verifies headroom calculation.
 func TestCriticalityConstraint_Headroom(t *testing.T) {
-	// Scenario: Core=100, Complex=10, ratio=0.10
-	// Headroom: (100 * 0.214) - 10 = 21.4 - 10 = 11.4
-	constraint := NewCriticalityConstraint(100.0, 10.0)
+	// Scenario: Core=100, Complex=400, ratio=4.0
+	// Headroom: (100 * 4.6692) - 400 = 466.92 - 400 = 66.92
+	constraint := NewCriticalityConstraint(100.0, 400.0)
 
 	headroom := constraint.Headroom()
-	expected := 100.0*CriticalityScalingRatio - 10.0
+	expected := 100.0*MaxComplexityGrowthRatio - 400.0
 
 	if math.Abs(headroom-expected) > 1e-6 {
 		t.Errorf("Headroom incorrect: got %.4f, expected %.4f",
@@ -139,6 +141,176 @@ func TestCriticalityConstraint_Headroom(t *testing.T) {
 	t.Logf("✓ Headroom: %.4f units of complexity can be added", headroom)
 }
 
+// TestDeployGate_GovernorMatchesConstraint verifies the governor blocks
+// deployments exactly where the default CriticalityScalingConstraint and
+// WeightedCriticalityConstraint reject them: one ratio, ΔComplexity/ΔCore ≤ δ.
+func TestDeployGate_GovernorMatchesConstraint(t *testing.T) {
+	const core = 100.0
+	limit := NewCriticalityConstraint(core, 0).MaxRatio
+
+	if limit != FeigenbaumDelta {
+		t.Fatalf("Constraint MaxRatio %.4f, want δ = %.4f", limit, FeigenbaumDelta)
+	}
+	if w := NewWeightedCriticalityConstraint(nil, nil).MaxRatio; w != limit {
+		t.Errorf("Weighted MaxRatio %.4f differs from %.4f", w, limit)
+	}
+	if math.Abs(limit*CriticalityScalingRatio-1) > 1e-12 {
+		t.Errorf("δ × (1/δ) = %.12f, want 1 (the 21%% rule is the same gate)", limit*CriticalityScalingRatio)
+	}
+
+	for _, ratio := range []float64{0.2, 1, 4, limit - 0.01, limit, limit + 0.01, 5, 10} {
+		complexity := core * ratio
+		constraintOK := NewCriticalityConstraint(core, complexity).Validate() == nil
+
+		action := NewGovernor(2.0).CheckStructuralIntegrity(SystemIntegrityMetrics{
+			ImmutableOpsVerified: 100,
+			SupervisedProcesses:  50,
+			DeltaCriticalCore:    core,
+			DeltaComplexity:      complexity,
+		})
+		governorOK := action.Type != ActionBlockDeploy
+
+		if constraintOK != governorOK {
+			t.Errorf("Ratio %.4f: constraint allows=%v, governor allows=%v", ratio, constraintOK, governorOK)
+		}
+		if want := ratio <= limit; governorOK != want {
+			t.Errorf("Ratio %.4f: governor allows=%v, want %v", ratio, governorOK, want)
+		}
+		if !governorOK && action.RatioLimit != limit {
+			t.Errorf("Ratio %.4f: governor reports limit %.4f, want %.4f", ratio, action.RatioLimit, limit)
+		}
+
+		// Σ_R holds metrics.ScalingRatio to the same limit
+		rd := RDynamics{CurrentR: 2.0}
+		sigmaOK := PerpetualStructuralIntegrity(&rd, SystemIntegrityMetrics{
+			ImmutableOpsVerified: 100,
+			ScalingRatio:         ratio,
+		}) == nil
+		if sigmaOK != constraintOK {
+			t.Errorf("Ratio %.4f: Σ_R allows=%v, constraint allows=%v", ratio, sigmaOK, constraintOK)
+		}
+	}
+
+	// The DNA scaling penalty reaches 1 exactly at the gate
+	atGate := CalculateSystemDNA(SystemIntegrityMetrics{ImmutableOpsVerified: 100, ScalingRatio: limit})
+	if math.Abs(atGate-2) > 1e-12 {
+		t.Errorf("r at the gate = %.12f, want 2 (scaling penalty 1)", atGate)
+	}
+
+	t.Logf("✓ Governor, constraints and Σ_R share the gate ΔComplexity/ΔCore ≤ %.4f", limit)
+}
+
+// TestAnalyzeDeployment_MatchesGovernor verifies the verdict agrees with the
+// governor's block decision at the boundary ratios and encodes as JSON.
+func TestAnalyzeDeployment_MatchesGovernor(t *testing.T) {
+	tests := []struct {
+		name        string
+		core        float64
+		complexity  float64
+		wantAllowed bool
+	}{
+		{"Ratio 4.6", 100, 460, true},
+		{"Ratio 4.7", 100, 470, false},
+		{"Ratio ∞", 0, 470, false},
+		{"No changes", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict := AnalyzeDeployment(tt.core, tt.complexity, 2.0)
+			if verdict.Allowed != tt.wantAllowed {
+				t.Errorf("Expected allowed=%v, got %+v", tt.wantAllowed, verdict)
+			}
+			if !verdict.Allowed && verdict.Headroom >= 0 {
+				t.Errorf("Blocked deployment should have negative headroom, got %.2f", verdict.Headroom)
+			}
+
+			action := NewGovernor(2.0).CheckStructuralIntegrity(SystemIntegrityMetrics{
+				DeltaCriticalCore: tt.core,
+				DeltaComplexity:   tt.complexity,
+			})
+			if blocked := action.Type == ActionBlockDeploy; blocked == verdict.Allowed {
+				t.Errorf("Governor blocked=%v disagrees with verdict allowed=%v", blocked, verdict.Allowed)
+			}
+
+			data, err := json.Marshal(verdict)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			var decoded map[string]any
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if decoded["allowed"] != tt.wantAllowed || decoded["message"] != verdict.Message {
+				t.Errorf("JSON lost fields: %s", data)
+			}
+			if math.IsInf(verdict.Ratio, 1) && decoded["ratio"] != nil {
+				t.Errorf("Infinite ratio should encode as null: %s", data)
+			}
+
+			t.Logf("✓ %s", data)
+		})
+	}
+
+	// Ratio 4.6 raises r by 4.6/δ
+	if v := AnalyzeDeployment(100, 460, 2.0); math.Abs(v.ProjectedR-(2.0+4.6*CriticalityScalingRatio)) > 1e-9 {
+		t.Errorf("Expected projected r %.4f, got %.4f", 2.0+4.6*CriticalityScalingRatio, v.ProjectedR)
+	}
+	if v := AnalyzeDeployment(0, 470, 2.0); !strings.Contains(v.Message, "technical debt") {
+		t.Errorf("Expected technical debt message, got %q", v.Message)
+	}
+}
+
+// TestWeightedCriticalityConstraint verifies a change that passes the
+// binary core/extensible model fails once the payment tier is weighted 3x.
+func TestWeightedCriticalityConstraint(t *testing.T) {
+	const payments = Tier3
+	deltas := map[Tier]float64{
+		Tier1:    10, // Kernel
+		Tier2:    20, // Drivers
+		payments: 20, // Payment module
+	}
+
+	// Binary model: (20 + 20) / 10 = 4.0 < 4.669 ✓
+	binary := NewCriticalityConstraint(deltas[Tier1], deltas[Tier2]+deltas[payments])
+	if err := binary.Validate(); err != nil {
+		t.Fatalf("Binary model should pass: %v", err)
+	}
+
+	// Unweighted tiers reproduce the binary model
+	unweighted := NewWeightedCriticalityConstraint(deltas, nil)
+	if math.Abs(unweighted.Ratio()-binary.Ratio()) > 1e-12 || math.Abs(unweighted.Headroom()-binary.Headroom()) > 1e-12 {
+		t.Errorf("Unweighted ratio %.4f / headroom %.2f should match binary %.4f / %.2f",
+			unweighted.Ratio(), unweighted.Headroom(), binary.Ratio(), binary.Headroom())
+	}
+
+	// Weighted: (20 + 3×20) / 10 = 8.0 > 4.669 ✗
+	weighted := NewWeightedCriticalityConstraint(deltas, map[Tier]float64{payments: 3})
+	if err := weighted.Validate(); err == nil {
+		t.Errorf("Weighted model should fail at ratio %.4f", weighted.Ratio())
+	}
+	if math.Abs(weighted.Ratio()-8.0) > 1e-12 {
+		t.Errorf("Expected weighted ratio 8.0, got %.4f", weighted.Ratio())
+	}
+	if weighted.Headroom() >= 0 {
+		t.Errorf("Expected negative headroom, got %.2f", weighted.Headroom())
+	}
+
+	// Counting drivers as core too: 60 / 30 = 2.0 ✓
+	weighted.CoreTiers = []Tier{Tier1, Tier2}
+	if err := weighted.Validate(); err != nil {
+		t.Errorf("Two core tiers should pass: %v", err)
+	}
+
+	// No core changes at all
+	if err := NewWeightedCriticalityConstraint(map[Tier]float64{Tier2: 1}, nil).Validate(); err == nil {
+		t.Error("Expected error with zero core changes")
+	}
+
+	t.Logf("✓ Binary ratio %.4f passes, weighted ratio 8.0000 fails (limit %.4f)",
+		binary.Ratio(), MaxComplexityGrowthRatio)
+}
+
 // TestCriticalityConstraint_IsStableEquilibrium verifies DNA range check.
 func TestCriticalityConstraint_IsStableEquilibrium(t *testing.T) {
 	tests := []struct {
@@ -225,7 +397,7 @@ func TestCalculateSystemDNA(t *testing.T) {
 				UnsupervisedProcesses: 0,
 				CriticalCoreLOC:       1000,
 				ExtensibleLOC:         200,
-				ScalingRatio:          0.20,
+				ScalingRatio:          4.36,
 			},
 			minR: 1.0,
 			maxR: 2.0, // Should be in stable range
@@ -239,7 +411,7 @@ func TestCalculateSystemDNA(t *testing.T) {
 				UnsupervisedProcesses: 5, // 10% unsupervised
 				CriticalCoreLOC:       1000,
 				ExtensibleLOC:         300,
-				ScalingRatio:          0.30, // Above 1/δ
+				ScalingRatio:          6.54, // Above δ
 			},
 			minR: 1.5,
 			maxR: 3.0, // Near boundary
@@ -253,7 +425,7 @@ func TestCalculateSystemDNA(t *testing.T) {
 				UnsupervisedProcesses: 40, // 400% unsupervised!
 				CriticalCoreLOC:       100,
 				ExtensibleLOC:         500,
-				ScalingRatio:          5.0, // 23x over limit!
+				ScalingRatio:          109.0, // 23x over limit!
 			},
 			minR: 3.0,
 			maxR: 50.0, // Deep in chaos (model allows high r for extreme violations)
@@ -278,6 +450,39 @@ func TestCalculateSystemDNA(t *testing.T) {
 	}
 }
 
+// TestCalculateSystemDNAWeighted verifies default weights match
+// CalculateSystemDNA and a doubled supervision weight tips a
+// supervision-heavy system over 3.0.
+func TestCalculateSystemDNAWeighted(t *testing.T) {
+	metrics := SystemIntegrityMetrics{
+		ImmutableOpsVerified:  100,
+		MutableSharedState:    10, // isolation penalty 0.1
+		SupervisedProcesses:   10,
+		UnsupervisedProcesses: 8, // supervision penalty 0.8
+		ScalingRatio:          3.27,
+	}
+
+	base := CalculateSystemDNA(metrics)
+	if got := CalculateSystemDNAWeighted(metrics, DefaultDNAWeights); got != base {
+		t.Errorf("Default weights should match CalculateSystemDNA: %.4f vs %.4f", got, base)
+	}
+	if base >= StableDNAConstraint.MaxR {
+		t.Fatalf("Unweighted r=%.4f should be stable", base)
+	}
+
+	heavy := DefaultDNAWeights
+	heavy.Supervision = 2.0
+	weighted := CalculateSystemDNAWeighted(metrics, heavy)
+	if weighted < StableDNAConstraint.MaxR {
+		t.Errorf("Doubled supervision weight should push r ≥ 3.0, got %.4f", weighted)
+	}
+	if math.Abs(weighted-base-0.8) > 1e-12 {
+		t.Errorf("Doubling supervision should add exactly its penalty (0.8): %.4f → %.4f", base, weighted)
+	}
+
+	t.Logf("✓ Supervision-heavy system: r=%.4f (equal weights) → r=%.4f (supervision ×2)", base, weighted)
+}
+
 // TestValidateSystemDNA verifies three-law enforcement.
 func TestValidateSystemDNA(t *testing.T) {
 	tests := []struct {
@@ -292,7 +497,7 @@ func TestValidateSystemDNA(t *testing.T) {
 				MutableSharedState:    0,
 				SupervisedProcesses:   50,
 				UnsupervisedProcesses: 0,
-				ScalingRatio:          0.20,
+				ScalingRatio:          4.36,
 			},
 			shouldErr: false,
 		},
@@ -303,7 +508,7 @@ func TestValidateSystemDNA(t *testing.T) {
 				MutableSharedState:    100, // 1000% violations
 				SupervisedProcesses:   50,
 				UnsupervisedProcesses: 0,
-				ScalingRatio:          0.20,
+				ScalingRatio:          4.36,
 			},
 			shouldErr: true,
 		},
@@ -314,7 +519,7 @@ func TestValidateSystemDNA(t *testing.T) {
 				MutableSharedState:    0,
 				SupervisedProcesses:   10,
 				UnsupervisedProcesses: 100, // 1000% unsupervised
-				ScalingRatio:          0.20,
+				ScalingRatio:          4.36,
 			},
 			shouldErr: true,
 		},
@@ -325,7 +530,16 @@ func TestValidateSystemDNA(t *testing.T) {
 				MutableSharedState:    0,
 				SupervisedProcesses:   50,
 				UnsupervisedProcesses: 0,
-				ScalingRatio:          5.0, // 23x over Feigenbaum limit
+				ScalingRatio:          109.0, // 23x over Feigenbaum limit
+			},
+			shouldErr: true,
+		},
+		{
+			name: "Non-finite scaling ratio (0/0 upstream)",
+			metrics: SystemIntegrityMetrics{
+				ImmutableOpsVerified: 100,
+				SupervisedProcesses:  50,
+				ScalingRatio:         math.NaN(),
 			},
 			shouldErr: true,
 		},
@@ -361,15 +575,16 @@ func TestFeigenbaumPhilosophy(t *testing.T) {
 	t.Logf("1/δ (Criticality ratio) = %.20f (≈ 21.4%%)", CriticalityScalingRatio)
 	t.Log("")
 	t.Log("The Universal Scaling Law:")
-	t.Logf("  ΔComplexity (Tier 2/3) / ΔCritical (Tier 1) ≤ 1/δ ≈ 0.214")
+	t.Logf("  ΔComplexity (Tier 2/3) / ΔCritical (Tier 1) ≤ δ ≈ 4.669")
 	t.Log("")
 	t.Log("Interpretation:")
 	t.Log("  For every 1 unit of change to critical core (Tier 1),")
-	t.Log("  you may add at most 0.214 units to extensible layers (Tier 2/3).")
+	t.Log("  you may add at most 4.669 units to extensible layers (Tier 2/3);")
+	t.Log("  equivalently, core work must be at least 1/δ ≈ 21.4% of the change.")
 	t.Log("")
-	t.Log("Why 1/δ?")
+	t.Log("Why δ?")
 	t.Log("  δ ≈ 4.669 is the universal rate of structural decay toward chaos.")
-	t.Log("  Its inverse, 1/δ ≈ 0.214, is the maximum safe scaling factor.")
+	t.Log("  It is the maximum safe growth of complexity per unit of core.")
 	t.Log("  Exceeding this ratio accelerates the coupling parameter (r)")
 	t.Log("  toward the chaos boundary (r = 3.0), triggering period-doubling")
 	t.Log("  cascade and eventual geometric system failure.")
@@ -381,7 +596,37 @@ func TestFeigenbaumPhilosophy(t *testing.T) {
 	t.Log("Three Laws of Architectural Integrity:")
 	t.Log("  Law I (Isolation):  Enforce immutability via Abstract Algebra")
 	t.Log("  Law II (Supervision): Erlang-style failure handling")
-	t.Log("  Law III (Scaling):    Feigenbaum criticality constraint (1/δ)")
+	t.Log("  Law III (Scaling):    Feigenbaum criticality constraint (δ)")
 	t.Log("")
 	t.Log("Together, these laws maintain: 1 < r < 3 (Perpetual Structural Integrity)")
 }
+
+// TestRDynamics_StabilityModel verifies recovery and the constraint's
+// stability checks use the per-instance model, not the global default.
+func TestRDynamics_StabilityModel(t *testing.T) {
+	model := StabilityModel{MinR: 1.0, MaxR: 2.5}
+
+	rd := NewRDynamicsWithModel(2.7, model)
+	if !rd.InSaturationZone {
+		t.Fatal("r=2.7 should be saturated with MaxR=2.5")
+	}
+	finalR, iterations := rd.ApplyRecoveryUntilStable(SystemIntegrityMetrics{ImmutableOpsVerified: 100}, 50)
+	if finalR >= model.MaxR {
+		t.Errorf("Recovery should settle below MaxR=2.5, got r=%.4f after %d iterations", finalR, iterations)
+	}
+
+	if def := NewRDynamics(2.7); def.InSaturationZone {
+		t.Error("r=2.7 should be stable under the global default")
+	}
+
+	c := NewCriticalityConstraintWithModel(100, 10, model)
+	c.CurrentCouplingR = 2.6
+	if c.IsStableEquilibrium() {
+		t.Error("r=2.6 should be outside the stable range with MaxR=2.5")
+	}
+	if d := c.DistanceToInstabilityBoundary(); math.Abs(d+0.1) > 1e-9 {
+		t.Errorf("Expected distance −0.1, got %.4f", d)
+	}
+
+	t.Logf("✓ MaxR=2.5: recovered 2.7 → %.4f in %d iterations", finalR, iterations)
+}
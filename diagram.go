@@ -0,0 +1,71 @@
+package lawbench
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// DiagramPoint is one column of a bifurcation diagram: the distinct
+// attractor values observed at control parameter R.
+type DiagramPoint struct {
+	R      float64
+	Values []float64 // Sorted, deduplicated within cfg.Tolerance
+}
+
+// BifurcationDiagram sweeps r like AnalyzeBifurcation and returns the full
+// post-warmup attractor at every r: the classic fork diagram.
+//
+// A fixed point contributes one value, a period-n orbit n values, and a
+// chaotic band up to cfg.Iterations values. cfg.Workers parallelizes the
+// sweep (f must then be safe for concurrent use).
+func BifurcationDiagram(f MapFunction, x0 float64, cfg FeigenbaumConfig) []DiagramPoint {
+	rValues := sweepRValues(cfg)
+	diagram := make([]DiagramPoint, len(rValues))
+
+	parallelSweep(len(rValues), cfg.Workers, func(i int) {
+		diagram[i] = DiagramPoint{
+			R:      rValues[i],
+			Values: dedupSorted(IterateMap(f, x0, rValues[i], cfg), cfg.Tolerance),
+		}
+	})
+
+	return diagram
+}
+
+// dedupSorted sorts values and drops any within tolerance of the previous kept value.
+func dedupSorted(values []float64, tolerance float64) []float64 {
+	sort.Float64s(values)
+
+	unique := values[:0]
+	for _, v := range values {
+		if len(unique) == 0 || v-unique[len(unique)-1] > tolerance {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// WriteBifurcationCSV writes one "r,value" row per attractor point, with a
+// header row, ready for gnuplot or matplotlib:
+//
+//	plot "diagram.csv" using 1:2 with dots
+func WriteBifurcationCSV(w io.Writer, diagram []DiagramPoint) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"r", "value"}); err != nil {
+		return err
+	}
+
+	for _, point := range diagram {
+		r := strconv.FormatFloat(point.R, 'g', -1, 64)
+		for _, v := range point.Values {
+			if err := cw.Write([]string{r, strconv.FormatFloat(v, 'g', -1, 64)}); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
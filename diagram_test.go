@@ -0,0 +1,73 @@
+package lawbench
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestBifurcationDiagram_PeriodDoubling verifies attractor sizes across the cascade.
+func TestBifurcationDiagram_PeriodDoubling(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.MinR = 2.8
+	cfg.MaxR = 3.95
+	cfg.StepR = 0.1
+	cfg.Warmup = 2000
+	cfg.Tolerance = 1e-4
+
+	diagram := BifurcationDiagram(LogisticMap, 0.5, cfg)
+
+	counts := make(map[string]int)
+	for _, p := range diagram {
+		switch {
+		case p.R > 2.75 && p.R < 2.85:
+			counts["r=2.8"] = len(p.Values)
+		case p.R > 3.15 && p.R < 3.25:
+			counts["r=3.2"] = len(p.Values)
+		case p.R > 3.45 && p.R < 3.55:
+			counts["r=3.5"] = len(p.Values)
+		case p.R > 3.85:
+			counts["r=3.9"] = len(p.Values)
+		}
+
+		for i := 1; i < len(p.Values); i++ {
+			if p.Values[i] <= p.Values[i-1] {
+				t.Fatalf("r=%.2f: values not sorted/deduplicated", p.R)
+			}
+		}
+	}
+
+	want := map[string]int{"r=2.8": 1, "r=3.2": 2, "r=3.5": 4}
+	for key, n := range want {
+		if counts[key] != n {
+			t.Errorf("%s: expected %d attractor values, got %d", key, n, counts[key])
+		}
+	}
+	if counts["r=3.9"] < 100 {
+		t.Errorf("r=3.9: expected a chaotic band, got %d values", counts["r=3.9"])
+	}
+
+	t.Logf("✓ Attractor sizes: %v", counts)
+}
+
+// TestWriteBifurcationCSV verifies one row per attractor point.
+func TestWriteBifurcationCSV(t *testing.T) {
+	diagram := []DiagramPoint{
+		{R: 2.8, Values: []float64{0.642857}},
+		{R: 3.2, Values: []float64{0.513, 0.799}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteBifurcationCSV(&buf, diagram); err != nil {
+		t.Fatalf("WriteBifurcationCSV failed: %v", err)
+	}
+
+	want := "r,value\n2.8,0.642857\n3.2,0.513\n3.2,0.799\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected CSV:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	if rows := strings.Count(buf.String(), "\n"); rows != 4 {
+		t.Errorf("Expected 4 rows, got %d", rows)
+	}
+}
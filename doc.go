@@ -53,13 +53,13 @@
 //	case lawbench.ActionStable:
 //	    // System healthy, no action needed
 //	case lawbench.ActionWarning:
-//	    log.Printf("WARNING: r = %.2f, approaching instability", action.CurrentR)
+//	    log.Printf("WARNING: r = %.2f, approaching instability", action.Metrics.EstimatedCoupling)
 //	case lawbench.ActionPacing:
-//	    // Shed 10-20% load
-//	    shedLoad(0.2)
+//	    // Shed up to 30% load, rising with r (see DefaultShedCurve)
+//	    shedLoad(action.ShedFraction)
 //	case lawbench.ActionThrottle:
-//	    // Emergency: shed 50% load immediately
-//	    shedLoad(0.5)
+//	    // Emergency: shed 50% load or more, capped at 70%
+//	    shedLoad(action.ShedFraction)
 //	case lawbench.ActionBlockDeploy:
 //	    // Deployment exceeds capacity limits
 //	    return errors.New("deployment blocked: exceeds capacity")
@@ -69,11 +69,12 @@
 //
 // Capacity constraint based on system stability threshold:
 //
-//	ΔComplexity / ΔCore ≤ 4.669
+//	ΔComplexity / ΔCore ≤ δ ≈ 4.669 (MaxComplexityGrowthRatio)
 //
 // Practical meaning: For every unit of core work, you can add ~4.7 units
-// of feature work before hitting capacity limits. This prevents r from climbing
-// toward instability (r ≥ 3.0).
+// of feature work before hitting capacity limits; equivalently, core work
+// must be at least 1/δ ≈ 21% of the change.
+// This prevents r from climbing toward instability (r ≥ 3.0).
 //
 // Example:
 //
@@ -82,8 +83,8 @@
 //	    DeltaComplexity:   500,  // Added 500 LOC features
 //	}
 //
-//	ratio := lawbench.CriticalityScalingRatio(metrics)
-//	if ratio > 4.669 {
+//	verdict := lawbench.AnalyzeDeployment(metrics.DeltaCriticalCore, metrics.DeltaComplexity, 0)
+//	if !verdict.Allowed { // ratio 5.0 > MaxComplexityGrowthRatio (4.669)
 //	    // VIOLATION: Exceeding capacity, r climbing
 //	    return errors.New("deployment rejected: exceeds capacity limit")
 //	}
@@ -123,6 +124,7 @@
 //	r = 1 + 2·α + 5·β·N
 //
 // This formula connects scalability measurement (USL) to stability prediction.
+// It is implemented by USLCoefficients.CouplingR and CouplingRFromUSL.
 //
 // # Testing
 //
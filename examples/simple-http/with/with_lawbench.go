@@ -26,7 +26,15 @@ func init() {
 
 func main() {
 	// Create lawbench middleware (THE ONLY CHANGE)
-	governor := NewLawBenchMiddleware(slog.Default())
+	governor := lawbench.NewGovernor(1.5)
+	governor.OnTransition(func(from, to lawbench.ActionType, action lawbench.Action) {
+		slog.Warn("governor transition",
+			"from", from,
+			"to", to,
+			"r", action.Metrics.EstimatedCoupling,
+			"shed", action.ShedFraction)
+	})
+	guard := &lawbench.Middleware{Governor: governor}
 
 	// Your existing handlers
 	mux := http.NewServeMux()
@@ -35,13 +43,21 @@ func main() {
 
 	// NEW: lawbench monitoring endpoint
 	mux.HandleFunc("/lawbench", func(w http.ResponseWriter, r *http.Request) {
-		status := governor.GetStatus()
+		action := guard.LastAction()
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(status)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"r":      action.Metrics.EstimatedCoupling,
+			"status": string(action.Type),
+			"shed":   action.ShedFraction,
+			"governor": map[string]interface{}{
+				"action": string(action.Type),
+				"reason": action.Reason,
+			},
+		})
 	})
 
 	// Wrap entire mux with lawbench
-	protected := governor.Wrap(mux)
+	protected := guard.Middleware(mux)
 
 	slog.Info("Server starting with lawbench protection", "addr", ":8080")
 	slog.Info("Monitor at: http://localhost:8080/lawbench")
@@ -119,90 +135,3 @@ MATHEMATICAL GUARANTEE:
 
 This is the lawbench promise: Graceful degradation instead of catastrophic failure.
 */
-
-// LawBenchMiddleware (copy from cmd/hive/lawbench_middleware.go for standalone example)
-type LawBenchMiddleware struct {
-	governor *lawbench.Governor
-	logger   *slog.Logger
-
-	requestCount   int64
-	errorCount     int64
-	totalLatencyMs int64
-	currentR       float64
-	lastAction     lawbench.Action
-}
-
-func NewLawBenchMiddleware(logger *slog.Logger) *LawBenchMiddleware {
-	return &LawBenchMiddleware{
-		governor: lawbench.NewGovernor(1.5),
-		logger:   logger,
-		currentR: 1.5,
-	}
-}
-
-func (m *LawBenchMiddleware) Wrap(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-
-		// Calculate current r(t) from metrics
-		avgLatency := float64(0)
-		if m.requestCount > 0 {
-			avgLatency = float64(m.totalLatencyMs) / float64(m.requestCount)
-		}
-		errorRate := float64(0)
-		if m.requestCount > 0 {
-			errorRate = float64(m.errorCount) / float64(m.requestCount)
-		}
-
-		estimatedR := 1.5 + (avgLatency / 100.0) + (errorRate * 2.0)
-
-		metrics := lawbench.SystemIntegrityMetrics{
-			EstimatedCoupling:           estimatedR,
-			InstabilityBoundaryDistance: 3.0 - estimatedR,
-			StableEquilibrium:           estimatedR < 3.0,
-		}
-
-		action := m.governor.CheckStructuralIntegrity(metrics)
-
-		m.requestCount++
-		m.lastAction = action
-		m.currentR = estimatedR
-
-		// CRITICAL: If Governor says SHOCK, reject request (503)
-		if action.Type == lawbench.ActionThrottle {
-			m.logger.Warn("governor shock - shedding load",
-				"r", estimatedR,
-				"reason", action.Reason)
-
-			http.Error(w, "Service temporarily overloaded", http.StatusServiceUnavailable)
-			m.errorCount++
-			return
-		}
-
-		// Process request normally
-		next.ServeHTTP(w, r)
-
-		duration := time.Since(start)
-		m.totalLatencyMs += duration.Milliseconds()
-	})
-}
-
-func (m *LawBenchMiddleware) GetStatus() map[string]interface{} {
-	status := "STABLE"
-	if m.currentR >= 3.0 {
-		status = "SATURATED"
-	} else if m.currentR >= 2.8 {
-		status = "WARNING"
-	}
-
-	return map[string]interface{}{
-		"r":             m.currentR,
-		"status":        status,
-		"request_count": m.requestCount,
-		"error_count":   m.errorCount,
-		"governor": map[string]interface{}{
-			"action": string(m.lastAction.Type),
-			"reason": m.lastAction.Reason,
-		},
-	}
-}
@@ -3,6 +3,8 @@ package lawbench
 import (
 	"context"
 	"math"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 )
@@ -11,9 +13,9 @@ import (
 type BifurcationPoint struct {
 	R         float64   // Control parameter (load, pressure, etc.)
 	Period    int       // Period detected (1, 2, 4, 8, ...)
-	Amplitude float64   // Oscillation amplitude
+	Amplitude float64   // Splitting d_n of the 2^n-cycle (0 for the last; see AnalyzeBifurcation)
 	Attractor []float64 // Observed attractor values
-	Dimension float64   // Fractal dimension (2.0 = stable, >2.0 = chaotic)
+	Dimension float64   // Box-counting dimension (0 = periodic orbit, ≈1 = chaotic 1-D map)
 }
 
 // FeigenbaumAnalysis contains the full bifurcation cascade.
@@ -21,11 +23,52 @@ type FeigenbaumAnalysis struct {
 	Bifurcations       []BifurcationPoint
 	Delta              float64 // δ ≈ 4.669 (period-doubling rate)
 	Alpha              float64 // α ≈ 2.502 (amplitude scaling)
-	SaturationBoundary      float64 // Control parameter where saturation begins
-	RecoveryTime int     // Iterations to exit saturation
+	SaturationBoundary float64 // Control parameter where saturation begins
+	FirstBifurcationR  float64 // Where the fixed point first loses stability (0 = not seen)
+	RecoveryTime       int     // Iterations to exit saturation
 	TransitTime        int     // Iterations through saturation
 	FractalDimension   float64 // Actual measured dimension
 	BasinCompatible    bool    // True if stays in life-compatible basin
+
+	// Lyapunov exponents, measured only when cfg.Derivative is set (see
+	// ChaosMargin). LyapunovAtOperatingPoint is λ at cfg.OperatingR (0 when
+	// not measured).
+	LyapunovSpectrum         []LyapunovPoint // λ at each swept r
+	LyapunovAtOperatingPoint float64
+}
+
+// LyapunovPoint is the Lyapunov exponent measured at one control parameter.
+type LyapunovPoint struct {
+	R        float64 `json:"r"`
+	Exponent float64 `json:"exponent"` // λ: < 0 converges, > 0 chaotic
+}
+
+// ChaosMargin returns how far the system at operatingR is from chaos, as
+// −λ: positive when perturbations decay (the larger, the faster they die
+// out), zero or negative when they don't. It is a more fundamental margin
+// than the distance in r, since λ < 0 guarantees convergence whatever the
+// thresholds say. λ also touches 0 at each bifurcation before the boundary,
+// so read the margin near the operating point, not as a global distance.
+//
+// λ is interpolated linearly from LyapunovSpectrum, clamped to the swept
+// range. Returns NaN when the spectrum was not measured.
+func (a FeigenbaumAnalysis) ChaosMargin(operatingR float64) float64 {
+	spectrum := a.LyapunovSpectrum
+	if len(spectrum) == 0 || math.IsNaN(operatingR) {
+		return math.NaN()
+	}
+
+	i := sort.Search(len(spectrum), func(i int) bool { return spectrum[i].R >= operatingR })
+	switch {
+	case i == 0:
+		return -spectrum[0].Exponent
+	case i == len(spectrum):
+		return -spectrum[len(spectrum)-1].Exponent
+	}
+
+	lo, hi := spectrum[i-1], spectrum[i]
+	w := (operatingR - lo.R) / (hi.R - lo.R)
+	return -(lo.Exponent + w*(hi.Exponent-lo.Exponent))
 }
 
 // MapFunction represents the iterative map: x_n+1 = f(x_n, r)
@@ -34,29 +77,55 @@ type MapFunction func(x, r float64) float64
 
 // FeigenbaumConfig controls bifurcation analysis.
 type FeigenbaumConfig struct {
-	MinR                    float64 // Starting control parameter
-	MaxR                    float64 // Ending control parameter
-	StepR                   float64 // Control parameter increment
-	Iterations              int     // Map iterations per R value
-	Warmup                  int     // Iterations to skip (transient)
-	Tolerance               float64 // Period detection tolerance
-	MaxPeriod               int     // Maximum period to detect
+	MinR              float64 // Starting control parameter
+	MaxR              float64 // Ending control parameter
+	StepR             float64 // Control parameter increment
+	Iterations        int     // Map iterations per R value
+	Warmup            int     // Iterations to skip (transient)
+	Tolerance         float64 // Period detection tolerance
+	MaxPeriod         int     // Maximum period to detect
+	AllowOddPeriods   bool    // DetectPeriod tests every period, not just 2^k
 	RecoveryThreshold float64 // Distance to attractor for "recovery"
-	BasinRadius             float64 // Maximum amplitude for "life-compatible"
+	BasinRadius       float64 // Maximum amplitude for "life-compatible"
+
+	// DimensionMethod selects the FractalDimension estimator (default: box-counting).
+	// MaxDimensionSamples caps the points used by the O(n²) correlation
+	// estimator (0 = DefaultCorrelationSamples).
+	DimensionMethod     DimensionMethod
+	MaxDimensionSamples int
+
+	// Workers parallelizes the r-sweep in AnalyzeBifurcation (≤ 1 = serial).
+	// Each r value is independent, so results are identical to the serial
+	// sweep. The MapFunction must be safe for concurrent use.
+	Workers int
+
+	// Derivative is ∂f/∂x of the analyzed map. When set, AnalyzeBifurcation
+	// also measures the Lyapunov exponent at every swept r, and at
+	// OperatingR when that is non-zero (see FeigenbaumAnalysis.ChaosMargin).
+	Derivative MapDerivative
+	OperatingR float64
 }
 
+// DimensionMethod selects the fractal dimension estimator.
+type DimensionMethod string
+
+const (
+	DimensionBoxCounting DimensionMethod = "BOX_COUNTING" // CalculateFractalDimension (default)
+	DimensionCorrelation DimensionMethod = "CORRELATION"  // CorrelationDimension, embedding dimension 2
+)
+
 // DefaultFeigenbaumConfig returns sensible defaults.
 func DefaultFeigenbaumConfig() FeigenbaumConfig {
 	return FeigenbaumConfig{
-		MinR:                    0.0,
-		MaxR:                    4.0,
-		StepR:                   0.01,
-		Iterations:              1000,
-		Warmup:                  200,
-		Tolerance:               1e-6,
-		MaxPeriod:               128,
+		MinR:              0.0,
+		MaxR:              4.0,
+		StepR:             0.01,
+		Iterations:        1000,
+		Warmup:            200,
+		Tolerance:         1e-6,
+		MaxPeriod:         128,
 		RecoveryThreshold: 0.1,
-		BasinRadius:             2.0,
+		BasinRadius:       2.0,
 	}
 }
 
@@ -82,13 +151,23 @@ func IterateMap(f MapFunction, x0, r float64, cfg FeigenbaumConfig) []float64 {
 
 // DetectPeriod finds the period of oscillation in the trajectory.
 // Period-1 = stable, Period-2 = alternating, Period-4/8/... = complex, >MaxPeriod = saturation
+//
+// By default only powers of two are tested (the period-doubling cascade).
+// With cfg.AllowOddPeriods every period 1..MaxPeriod is tested and the
+// smallest match is returned, so windows such as the logistic map's
+// period-3 window at r ≈ 3.83 are not misreported as chaos (-1).
 func DetectPeriod(trajectory []float64, cfg FeigenbaumConfig) int {
 	if len(trajectory) < 2*cfg.MaxPeriod {
 		return -1 // Not enough data
 	}
 
-	// Test periods 1, 2, 4, 8, 16, ... up to MaxPeriod
-	for period := 1; period <= cfg.MaxPeriod; period *= 2 {
+	nextPeriod := func(p int) int { return p * 2 }
+	if cfg.AllowOddPeriods {
+		nextPeriod = func(p int) int { return p + 1 }
+	}
+
+	// Test periods 1, 2, 4, 8, 16, ... (or 1, 2, 3, 4, ...) up to MaxPeriod
+	for period := 1; period <= cfg.MaxPeriod; period = nextPeriod(period) {
 		isPeriodicPeriod := true
 
 		// Check if trajectory repeats every 'period' steps
@@ -107,40 +186,187 @@ func DetectPeriod(trajectory []float64, cfg FeigenbaumConfig) int {
 	return -1 // Chaotic (no period detected)
 }
 
-// CalculateFractalDimension estimates the attractor dimension using box-counting.
-// Stable: D ≈ 0 (point), Periodic: D ≈ 1 (loop), Chaotic: 2 < D < 3 (strange attractor)
+// CalculateFractalDimension estimates the attractor dimension by box-counting.
+//
+// The 1-D trajectory is embedded in 2-D with delay coordinates (x_i, x_{i+1}),
+// normalized to the unit square, and covered with grids of box size
+// ε = 2^-k. The dimension is the least-squares slope of
+//
+//	log N(ε) vs log(1/ε)
+//
+// where N(ε) is the number of occupied boxes. Only scales with enough points
+// per box to be meaningful are used (at most n/4 boxes for n points).
+//
+// For a map (not a flow), a fixed point or periodic orbit is a finite set of
+// points: N(ε) is constant and D ≈ 0. A chaotic orbit of a 1-D map fills a
+// curve segment in the delay plane, so 0 < D ≤ 1 up to sampling error;
+// dimensions between 1 and 2 need a map with a folded attractor (Hénon: 1.26).
 func CalculateFractalDimension(trajectory []float64) float64 {
 	if len(trajectory) < 100 {
 		return 0.0
 	}
 
-	// Simple estimation: count unique values in trajectory
-	// For true fractal dimension, we'd use box-counting or correlation dimension
-	uniqueMap := make(map[int]bool)
-	resolution := 1000.0 // Discretization resolution
+	lo, hi := trajectory[0], trajectory[0]
+	for _, x := range trajectory {
+		lo = math.Min(lo, x)
+		hi = math.Max(hi, x)
+	}
+	span := hi - lo
+	if span == 0 || math.IsNaN(span) || math.IsInf(span, 0) {
+		return 0.0 // Fixed point (or diverged)
+	}
+
+	points := len(trajectory) - 1
+	var logInvEps, logN []float64
+	for k := 1; ; k++ {
+		boxes := 1 << k
+		if boxes*boxes > 1<<20 || boxes > points/4 {
+			break
+		}
+
+		occupied := make(map[[2]int]struct{})
+		for i := 0; i < points; i++ {
+			occupied[[2]int{
+				boxIndex(trajectory[i], lo, span, boxes),
+				boxIndex(trajectory[i+1], lo, span, boxes),
+			}] = struct{}{}
+		}
+
+		logInvEps = append(logInvEps, math.Log(float64(boxes)))
+		logN = append(logN, math.Log(float64(len(occupied))))
+	}
 
+	if len(logN) < 2 {
+		return 0.0
+	}
+
+	return leastSquaresSlope(logInvEps, logN)
+}
+
+// DefaultCorrelationSamples caps the embedded points CorrelationDimension
+// compares pairwise (≈ 500k distances).
+const DefaultCorrelationSamples = 1000
+
+// fractalDimension estimates the dimension with the method selected in cfg.
+func fractalDimension(trajectory []float64, cfg FeigenbaumConfig) float64 {
+	if cfg.DimensionMethod == DimensionCorrelation {
+		return correlationDimension(trajectory, 2, cfg.MaxDimensionSamples)
+	}
+	return CalculateFractalDimension(trajectory)
+}
+
+// CorrelationDimension estimates the attractor dimension with the
+// Grassberger–Procaccia correlation integral.
+//
+// The trajectory is embedded in embeddingDim dimensions with delay
+// coordinates v_i = (x_i, ..., x_{i+m-1}). The correlation integral
+//
+//	C(ε) = (fraction of pairs i < j with ‖v_i - v_j‖∞ < ε)
+//
+// scales as ε^D on the attractor, so D is the least-squares slope of
+// log C(ε) vs log ε over dyadic radii ε = span·2^-k. Radii where fewer than
+// 20 pairs fall inside are too noisy to use and are skipped.
+//
+// Pairwise distances cost O(n²), so at most DefaultCorrelationSamples
+// embedded points are used, taken at an even stride through the trajectory.
+// Like box-counting, a periodic orbit gives D ≈ 0 and a chaotic 1-D map
+// D ≈ 1, but C(ε) uses every pair rather than box occupancy, so the
+// estimate is far less sensitive to short trajectories.
+func CorrelationDimension(trajectory []float64, embeddingDim int) float64 {
+	return correlationDimension(trajectory, embeddingDim, DefaultCorrelationSamples)
+}
+
+func correlationDimension(trajectory []float64, embeddingDim, maxSamples int) float64 {
+	if embeddingDim < 1 {
+		embeddingDim = 1
+	}
+	if maxSamples <= 0 {
+		maxSamples = DefaultCorrelationSamples
+	}
+
+	points := len(trajectory) - embeddingDim + 1
+	if points < 10 {
+		return 0.0
+	}
+
+	lo, hi := trajectory[0], trajectory[0]
 	for _, x := range trajectory {
-		bucket := int(x * resolution)
-		uniqueMap[bucket] = true
+		lo = math.Min(lo, x)
+		hi = math.Max(hi, x)
+	}
+	span := hi - lo
+	if span == 0 || math.IsNaN(span) || math.IsInf(span, 0) {
+		return 0.0 // Fixed point (or diverged)
 	}
 
-	uniqueCount := float64(len(uniqueMap))
-	totalCount := float64(len(trajectory))
+	// Subsample embedded points at an even stride
+	stride := 1
+	if points > maxSamples {
+		stride = (points + maxSamples - 1) / maxSamples
+	}
+	var starts []int
+	for i := 0; i < points; i += stride {
+		starts = append(starts, i)
+	}
 
-	// Heuristic dimension estimate
-	// If uniqueCount ≈ totalCount, high dimension (chaotic)
-	// If uniqueCount is small, low dimension (periodic)
-	ratio := uniqueCount / totalCount
+	// All pairwise Chebyshev distances, sorted for counting by radius
+	distances := make([]float64, 0, len(starts)*(len(starts)-1)/2)
+	for a := 0; a < len(starts); a++ {
+		for b := a + 1; b < len(starts); b++ {
+			var d float64
+			for k := 0; k < embeddingDim; k++ {
+				d = math.Max(d, math.Abs(trajectory[starts[a]+k]-trajectory[starts[b]+k]))
+			}
+			distances = append(distances, d)
+		}
+	}
+	sort.Float64s(distances)
+
+	const minPairs = 20
+	total := float64(len(distances))
+	var logEps, logC []float64
+	for k := 1; k <= 30; k++ {
+		eps := span * math.Pow(2, -float64(k))
+		inside := sort.SearchFloat64s(distances, eps) // pairs with d < ε
+		if inside < minPairs {
+			break
+		}
+		logEps = append(logEps, math.Log(eps))
+		logC = append(logC, math.Log(float64(inside)/total))
+	}
 
-	if ratio < 0.01 {
-		return 0.0 // Point attractor (stable)
-	} else if ratio < 0.1 {
-		return 1.0 // Limit cycle (periodic)
-	} else {
-		// Approximate fractal dimension
-		// Lorenz: 2.06, Rössler: 2.01, Hénon: 1.26
-		return 1.0 + math.Log(ratio)/math.Log(2.0)
+	if len(logC) < 2 {
+		return 0.0
+	}
+
+	return leastSquaresSlope(logEps, logC)
+}
+
+// boxIndex returns the grid cell of x on a grid of n boxes over [lo, lo+span].
+func boxIndex(x, lo, span float64, n int) int {
+	i := int((x - lo) / span * float64(n))
+	if i >= n {
+		i = n - 1 // x == hi
+	}
+	return i
+}
+
+// leastSquaresSlope returns the OLS slope of y on x.
+func leastSquaresSlope(x, y []float64) float64 {
+	n := float64(len(x))
+	var sumX, sumY, sumXX, sumXY float64
+	for i := range x {
+		sumX += x[i]
+		sumY += y[i]
+		sumXX += x[i] * x[i]
+		sumXY += x[i] * y[i]
+	}
+
+	det := n*sumXX - sumX*sumX
+	if det == 0 {
+		return 0
 	}
+	return (n*sumXY - sumX*sumY) / det
 }
 
 // CalculateAmplitude returns the oscillation amplitude (max - min).
@@ -184,6 +410,13 @@ func DistanceToAttractor(current float64, attractor []float64) float64 {
 // MeasureRecoveryTime counts iterations needed to return to stable basin after saturation.
 // Simulates: system enters saturation at r_saturation, can it recover?
 func MeasureRecoveryTime(f MapFunction, x0, rSaturation, rStable float64, cfg FeigenbaumConfig) int {
+	iterations, _ := MeasureRecoveryTimeCtx(context.Background(), f, x0, rSaturation, rStable, cfg)
+	return iterations
+}
+
+// MeasureRecoveryTimeCtx is MeasureRecoveryTime with cancellation, checked
+// between recovery iterations. On cancellation it returns 0 and ctx.Err().
+func MeasureRecoveryTimeCtx(ctx context.Context, f MapFunction, x0, rSaturation, rStable float64, cfg FeigenbaumConfig) (int, error) {
 	// Start in saturation
 	x := x0
 	for i := 0; i < 100; i++ {
@@ -197,26 +430,41 @@ func MeasureRecoveryTime(f MapFunction, x0, rSaturation, rStable float64, cfg Fe
 	stableAttractor := IterateMap(f, 0.5, rStable, cfg)
 
 	for iterations < maxIterations {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
 		x = f(x, rStable)
 		iterations++
 
 		dist := DistanceToAttractor(x, stableAttractor)
 		if dist < cfg.RecoveryThreshold {
-			return iterations // Recovered!
+			return iterations, nil // Recovered!
 		}
 	}
 
-	return -1 // Failed to recover (trapped in saturation)
+	return -1, nil // Failed to recover (trapped in saturation)
 }
 
 // MeasureTransitTime counts iterations to pass through saturation and reach stable basin on other side.
 func MeasureTransitTime(f MapFunction, x0, rSaturation float64, cfg FeigenbaumConfig) int {
+	iterations, _ := MeasureTransitTimeCtx(context.Background(), f, x0, rSaturation, cfg)
+	return iterations
+}
+
+// MeasureTransitTimeCtx is MeasureTransitTime with cancellation, checked
+// between iterations. On cancellation it returns 0 and ctx.Err().
+func MeasureTransitTimeCtx(ctx context.Context, f MapFunction, x0, rSaturation float64, cfg FeigenbaumConfig) (int, error) {
 	x := x0
 	iterations := 0
 	maxIterations := 10000
 
 	// Transit through saturation
 	for iterations < maxIterations {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
 		x = f(x, rSaturation)
 		iterations++
 
@@ -237,29 +485,136 @@ func MeasureTransitTime(f MapFunction, x0, rSaturation float64, cfg FeigenbaumCo
 			}
 
 			if allBounded {
-				return iterations // Successfully transited!
+				return iterations, nil // Successfully transited!
 			}
 		}
 	}
 
-	return -1 // Failed to transit (diverged or trapped)
+	return -1, nil // Failed to transit (diverged or trapped)
+}
+
+// sweepSample is the attractor measured at one r value of the sweep.
+type sweepSample struct {
+	r          float64
+	trajectory []float64
+	period     int
+	dimension  float64
+	lyapunov   float64 // Only with cfg.Derivative
+}
+
+// sweepBifurcation measures every r in [MinR, MaxR] step StepR, in r-order.
+//
+// The r values are generated serially (r += StepR, exactly as a plain loop
+// would) so results are bit-identical regardless of cfg.Workers.
+//
+// ctx is checked before each r-step. On cancellation the samples measured
+// so far form an r-ordered prefix, returned along with ctx.Err().
+func sweepBifurcation(ctx context.Context, f MapFunction, x0 float64, cfg FeigenbaumConfig) ([]sweepSample, error) {
+	rValues := sweepRValues(cfg)
+	samples := make([]sweepSample, len(rValues))
+	measured := make([]bool, len(rValues))
+
+	parallelSweep(len(samples), cfg.Workers, func(i int) {
+		if ctx.Err() != nil {
+			return
+		}
+		s := &samples[i]
+		s.r = rValues[i]
+		s.trajectory = IterateMap(f, x0, s.r, cfg)
+		s.period = DetectPeriod(s.trajectory, cfg)
+		s.dimension = fractalDimension(s.trajectory, cfg)
+		if cfg.Derivative != nil {
+			s.lyapunov = LyapunovExponentAnalytic(f, cfg.Derivative, x0, s.r, cfg)
+		}
+		measured[i] = true
+	})
+
+	if err := ctx.Err(); err != nil {
+		// Workers may finish out of order: keep the contiguous prefix
+		n := 0
+		for n < len(measured) && measured[n] {
+			n++
+		}
+		return samples[:n], err
+	}
+
+	return samples, nil
+}
+
+// sweepRValues returns the r values of a sweep, accumulated as r += StepR.
+func sweepRValues(cfg FeigenbaumConfig) []float64 {
+	var rValues []float64
+	for r := cfg.MinR; r <= cfg.MaxR; r += cfg.StepR {
+		rValues = append(rValues, r)
+	}
+	return rValues
+}
+
+// parallelSweep calls measure(i) for every i in [0, n) on up to workers
+// goroutines (serially when workers ≤ 1).
+func parallelSweep(n, workers int, measure func(i int)) {
+	workers = min(workers, n)
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			measure(i)
+		}
+		return
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				measure(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
 }
 
 // AnalyzeBifurcation performs full Feigenbaum analysis on a map function.
+//
+// With cfg.Workers > 1 the r-sweep runs in parallel; f must then be safe for
+// concurrent use.
 func AnalyzeBifurcation(f MapFunction, x0 float64, cfg FeigenbaumConfig) FeigenbaumAnalysis {
+	analysis, _ := AnalyzeBifurcationCtx(context.Background(), f, x0, cfg)
+	return analysis
+}
+
+// AnalyzeBifurcationCtx is AnalyzeBifurcation with a deadline or cancellation.
+//
+// ctx is checked between r-steps and between recovery/transit iterations.
+// On cancellation it returns the analysis of the r-range swept so far
+// (bifurcations, δ and α from that prefix; recovery and transit unmeasured)
+// together with ctx.Err(). Use it to cap CI runs or analyses over expensive
+// maps such as AdaptPerformanceToMap:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	analysis, err := AnalyzeBifurcationCtx(ctx, f, 0.5, cfg)
+func AnalyzeBifurcationCtx(ctx context.Context, f MapFunction, x0 float64, cfg FeigenbaumConfig) (FeigenbaumAnalysis, error) {
 	analysis := FeigenbaumAnalysis{
 		Bifurcations: make([]BifurcationPoint, 0),
 	}
 
 	var previousPeriod int = -1
 	var bifurcationRValues []float64
+	var bifurcationSamples []int // Sweep index of each bifurcation
 
 	// Sweep through control parameter
-	for r := cfg.MinR; r <= cfg.MaxR; r += cfg.StepR {
-		trajectory := IterateMap(f, x0, r, cfg)
-		period := DetectPeriod(trajectory, cfg)
-		amplitude := CalculateAmplitude(trajectory)
-		dimension := CalculateFractalDimension(trajectory)
+	samples, sweepErr := sweepBifurcation(ctx, f, x0, cfg)
+	for i, sample := range samples {
+		r := sample.r
+		trajectory := sample.trajectory
+		period := sample.period
+		dimension := sample.dimension
 
 		// Detect bifurcation (period doubling from 2^n sequence)
 		if period != previousPeriod && previousPeriod > 0 {
@@ -268,11 +623,14 @@ func AnalyzeBifurcation(f MapFunction, x0 float64, cfg FeigenbaumConfig) Feigenb
 			isDoubling := period == previousPeriod*2
 
 			if isPowerOf2 && (isDoubling || previousPeriod == 1) {
+				if previousPeriod == 1 && analysis.FirstBifurcationR == 0 {
+					analysis.FirstBifurcationR = r // Onset: period 1 → 2 (3.0 for the logistic map)
+				}
 				bifurcationRValues = append(bifurcationRValues, r)
+				bifurcationSamples = append(bifurcationSamples, i)
 				analysis.Bifurcations = append(analysis.Bifurcations, BifurcationPoint{
 					R:         r,
 					Period:    period,
-					Amplitude: amplitude,
 					Attractor: trajectory[len(trajectory)-period:],
 					Dimension: dimension,
 				})
@@ -318,19 +676,42 @@ func AnalyzeBifurcation(f MapFunction, x0 float64, cfg FeigenbaumConfig) Feigenb
 	}
 
 	// Calculate Feigenbaum alpha (amplitude scaling)
-	if len(analysis.Bifurcations) >= 2 {
-		amp1 := analysis.Bifurcations[len(analysis.Bifurcations)-2].Amplitude
-		amp2 := analysis.Bifurcations[len(analysis.Bifurcations)-1].Amplitude
-		if amp2 != 0 {
-			analysis.Alpha = amp1 / amp2
+	// α_n = d_n / d_{n+1}, averaged like δ (converges to 2.502)
+	//
+	// d_n is measured mid-window, so only bifurcations followed by another
+	// have one; the last is left at 0.
+	for n := 0; n+1 < len(bifurcationSamples); n++ {
+		window := samples[bifurcationSamples[n]:bifurcationSamples[n+1]]
+		analysis.Bifurcations[n].Amplitude = windowSplitting(window)
+	}
+	analysis.Alpha = averageAlpha(analysis.Bifurcations)
+
+	if cfg.Derivative != nil {
+		analysis.LyapunovSpectrum = make([]LyapunovPoint, len(samples))
+		for i, sample := range samples {
+			analysis.LyapunovSpectrum[i] = LyapunovPoint{R: sample.r, Exponent: sample.lyapunov}
+		}
+		if cfg.OperatingR != 0 {
+			analysis.LyapunovAtOperatingPoint = LyapunovExponentAnalytic(f, cfg.Derivative, x0, cfg.OperatingR, cfg)
 		}
 	}
 
+	if sweepErr != nil {
+		return analysis, sweepErr
+	}
+
 	// Measure recovery and transit times
 	if analysis.SaturationBoundary > 0 {
+		var err error
 		rStable := cfg.MinR + (cfg.MaxR-cfg.MinR)*0.3 // 30% load (stable region)
-		analysis.RecoveryTime = MeasureRecoveryTime(f, x0, analysis.SaturationBoundary, rStable, cfg)
-		analysis.TransitTime = MeasureTransitTime(f, x0, analysis.SaturationBoundary, cfg)
+		analysis.RecoveryTime, err = MeasureRecoveryTimeCtx(ctx, f, x0, analysis.SaturationBoundary, rStable, cfg)
+		if err != nil {
+			return analysis, err
+		}
+		analysis.TransitTime, err = MeasureTransitTimeCtx(ctx, f, x0, analysis.SaturationBoundary, cfg)
+		if err != nil {
+			return analysis, err
+		}
 
 		// Check basin compatibility
 		testTrajectory := IterateMap(f, x0, analysis.SaturationBoundary, cfg)
@@ -343,9 +724,85 @@ func AnalyzeBifurcation(f MapFunction, x0 float64, cfg FeigenbaumConfig) Feigenb
 		}
 	}
 
-	return analysis
+	return analysis, nil
+}
+
+// windowSplitting measures the splitting d_n of the cycle born at window[0].
+//
+// d_n is the widest gap between cycle points x_j and x_{j+P/2} that were a
+// single point of the parent cycle; for the logistic map it is the pair
+// straddling the critical point, whose gap shrinks by α per doubling. The
+// gap is 0 at the bifurcation itself and grows until the next one, so it is
+// measured at the same relative position in every window (its r-midpoint)
+// for the ratios to be comparable.
+//
+// window runs from the bifurcation up to the next one. Samples near the
+// midpoint that did not resolve the cycle (critical
+// slowing) are skipped in favour of the nearest one that did.
+func windowSplitting(window []sweepSample) float64 {
+	period := window[0].period
+	mid := len(window) / 2
+	for offset := 0; offset <= mid; offset++ {
+		for _, j := range []int{mid - offset, mid + offset} {
+			if j >= 0 && j < len(window) && window[j].period == period {
+				return cycleSplitting(window[j].trajectory, period)
+			}
+		}
+	}
+	return 0
+}
+
+// cycleSplitting returns max_j |x_j − x_{j+period/2}| over one cycle.
+func cycleSplitting(trajectory []float64, period int) float64 {
+	if period < 2 || len(trajectory) < period+period/2 {
+		return 0
+	}
+
+	split := 0.0
+	for j := 0; j < period; j++ {
+		split = math.Max(split, math.Abs(trajectory[j]-trajectory[j+period/2]))
+	}
+	return split
+}
+
+// averageAlpha averages α_n = d_n / d_{n+1} over consecutive bifurcations.
+//
+// When the sweep skips a level (e.g. 4 → 16) the ratio spans k doublings
+// and contributes its k-th root. Returns 0 with fewer than two usable
+// amplitudes.
+func averageAlpha(bifurcations []BifurcationPoint) float64 {
+	var sum float64
+	var count int
+	for i := 0; i+1 < len(bifurcations); i++ {
+		d1, d2 := bifurcations[i].Amplitude, bifurcations[i+1].Amplitude
+		if d1 <= 0 || d2 <= 0 {
+			continue
+		}
+
+		doublings := math.Log2(float64(bifurcations[i+1].Period) / float64(bifurcations[i].Period))
+		alpha := math.Pow(d1/d2, 1/doublings)
+		if alpha > 1 && alpha < 100 { // Sanity check
+			sum += alpha
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
 }
 
+// FeigenbaumDeltaTolerance is the accepted error on a measured δ (≈10%).
+// Finite StepR and critical slowing near each bifurcation bias the measured
+// bifurcation points, so δ is only recovered approximately.
+const FeigenbaumDeltaTolerance = 0.5
+
+// FeigenbaumAlphaTolerance is the accepted error on a measured α (≈20%).
+// The first few ratios d_n/d_{n+1} approach α from above, so a short cascade
+// overestimates it.
+const FeigenbaumAlphaTolerance = 0.5
+
 // AssertFeigenbaumCascade verifies the system exhibits correct period-doubling.
 func AssertFeigenbaumCascade(t *testing.T, analysis FeigenbaumAnalysis) {
 	t.Helper()
@@ -380,7 +837,7 @@ func AssertFeigenbaumCascade(t *testing.T, analysis FeigenbaumAnalysis) {
 	// Check Feigenbaum delta (should be ≈ 4.669)
 	if analysis.Delta > 0 {
 		expectedDelta := 4.669
-		tolerance := 0.5 // Allow 10% error
+		tolerance := FeigenbaumDeltaTolerance
 		if math.Abs(analysis.Delta-expectedDelta) > tolerance {
 			t.Errorf("Feigenbaum δ = %.3f (expected ≈ %.3f ± %.1f)",
 				analysis.Delta, expectedDelta, tolerance)
@@ -392,7 +849,7 @@ func AssertFeigenbaumCascade(t *testing.T, analysis FeigenbaumAnalysis) {
 	// Check Feigenbaum alpha (should be ≈ 2.502)
 	if analysis.Alpha > 0 {
 		expectedAlpha := 2.502
-		tolerance := 0.5
+		tolerance := FeigenbaumAlphaTolerance
 		if math.Abs(analysis.Alpha-expectedAlpha) > tolerance {
 			t.Errorf("Feigenbaum α = %.3f (expected ≈ %.3f ± %.1f)",
 				analysis.Alpha, expectedAlpha, tolerance)
@@ -409,6 +866,31 @@ func AssertFeigenbaumCascade(t *testing.T, analysis FeigenbaumAnalysis) {
 	}
 }
 
+// AssertFirstBifurcation verifies stability first breaks (period 1 → 2)
+// within tolerance of expected, e.g. 3.0 for the logistic map.
+//
+// FirstBifurcationR is the first swept r with a doubled period, so it is
+// only as precise as StepR. Near the onset transients decay slowly
+// (critical slowing): with a short Warmup a still-decaying alternation reads
+// as period 2 a little before it (2.95 instead of 3.0 for the logistic map
+// with DefaultFeigenbaumConfig). The onset is not detected when the sweep
+// starts above it.
+func AssertFirstBifurcation(t *testing.T, analysis FeigenbaumAnalysis, expected, tolerance float64) {
+	t.Helper()
+
+	if analysis.FirstBifurcationR == 0 {
+		t.Errorf("❌ No period-doubling onset detected (expected r ≈ %.4f)", expected)
+		return
+	}
+
+	if math.Abs(analysis.FirstBifurcationR-expected) > tolerance {
+		t.Errorf("❌ First bifurcation at r = %.4f (expected %.4f ± %.4f)",
+			analysis.FirstBifurcationR, expected, tolerance)
+	} else {
+		t.Logf("✓ First bifurcation: r = %.4f (expected %.4f)", analysis.FirstBifurcationR, expected)
+	}
+}
+
 // AssertRecovery verifies the system can exit saturation and return to stability.
 func AssertRecovery(t *testing.T, analysis FeigenbaumAnalysis, maxIterations int) {
 	t.Helper()
@@ -505,38 +987,159 @@ func LogisticMap(x, r float64) float64 {
 	return r * x * (1 - x)
 }
 
+// LogisticMapDerivative is the analytic derivative of LogisticMap: f'(x) = r(1 - 2x)
+func LogisticMapDerivative(x, r float64) float64 {
+	return r * (1 - 2*x)
+}
+
+// MapDerivative is ∂f/∂x for a MapFunction.
+type MapDerivative func(x, r float64) float64
+
+// LyapunovExponent measures the average exponential rate at which nearby
+// trajectories separate:
+//
+//	λ = (1/n) Σ log|f'(x_i)|
+//
+// λ < 0: perturbations decay (stable or periodic). λ > 0: perturbations grow
+// (chaos). For the logistic map, λ ≈ ln 2 ≈ 0.69 at r = 4 and ≈ 0.49 at r = 3.9.
+//
+// f' is estimated by a central difference. Use LyapunovExponentAnalytic when
+// the derivative is known. cfg.Warmup iterations are discarded and
+// cfg.Iterations are averaged.
+func LyapunovExponent(f MapFunction, x0, r float64, cfg FeigenbaumConfig) float64 {
+	const h = 1e-7
+	numeric := func(x, r float64) float64 {
+		return (f(x+h, r) - f(x-h, r)) / (2 * h)
+	}
+	return LyapunovExponentAnalytic(f, numeric, x0, r, cfg)
+}
+
+// LyapunovExponentAnalytic is LyapunovExponent with an exact derivative.
+func LyapunovExponentAnalytic(f MapFunction, df MapDerivative, x0, r float64, cfg FeigenbaumConfig) float64 {
+	if cfg.Iterations <= 0 {
+		return 0
+	}
+
+	x := x0
+	for i := 0; i < cfg.Warmup; i++ {
+		x = f(x, r)
+	}
+
+	// Superstable points have f'(x) = 0; floor |f'| so one exact hit
+	// cannot drive the average to -Inf
+	const minSlope = 1e-300
+
+	var sum float64
+	for i := 0; i < cfg.Iterations; i++ {
+		sum += math.Log(math.Max(math.Abs(df(x, r)), minSlope))
+		x = f(x, r)
+	}
+
+	return sum / float64(cfg.Iterations)
+}
+
+// AssertPositiveLyapunov verifies the map is genuinely chaotic at r.
+//
+// DetectPeriod returns -1 both for chaos and for periods longer than
+// cfg.MaxPeriod. A positive Lyapunov exponent distinguishes the two:
+// high-period orbits are still stable (λ < 0).
+func AssertPositiveLyapunov(t *testing.T, f MapFunction, x0, r float64, cfg FeigenbaumConfig) {
+	t.Helper()
+
+	lambda := LyapunovExponent(f, x0, r, cfg)
+	if lambda <= 0 {
+		t.Errorf("Not chaotic at r=%.4f: Lyapunov exponent λ = %.4f ≤ 0\n"+
+			"Perturbations decay: the orbit is stable or periodic.", r, lambda)
+		return
+	}
+
+	t.Logf("✓ Chaotic at r=%.4f: Lyapunov exponent λ = %.4f > 0", r, lambda)
+}
+
 // PerformanceMap converts performance metrics to iterative map.
 // Example: latency as function of load
 type PerformanceMap func(ctx context.Context, load float64) (float64, error)
 
+// PerformanceMapConfig controls how AdaptPerformanceToMapWithConfig turns
+// measurements into a MapFunction. Zero values select the defaults.
+type PerformanceMapConfig struct {
+	// Scale divides each raw measurement to normalize it
+	// (default 1000: latency in µs → coupling of order 1).
+	Scale float64
+
+	// Combine computes x_{n+1} from the state x, the control parameter r and
+	// the normalized measurement m (default: m·x·(1−x), logistic-like).
+	Combine func(x, r, m float64) float64
+
+	// Smoothing is the weight of each new measurement in the per-r moving
+	// average, in (0, 1] (default 1: every iteration uses only the latest
+	// measurement). Lower values damp measurement noise.
+	Smoothing float64
+
+	// Timeout bounds each measurement (default 100ms).
+	Timeout time.Duration
+}
+
+// Defaults for PerformanceMapConfig.
+const (
+	DefaultPerformanceMapScale   = 1000.0
+	DefaultPerformanceMapTimeout = 100 * time.Millisecond
+)
+
 // AdaptPerformanceToMap converts real performance measurements to mathematical map.
+// It is AdaptPerformanceToMapWithConfig with the default configuration.
 func AdaptPerformanceToMap(perfMap PerformanceMap) MapFunction {
-	// Cache for performance measurements
-	cache := make(map[float64]float64)
-
-	return func(x, r float64) float64 {
-		// x = current latency (normalized)
-		// r = load parameter (0 to 4.0)
+	return AdaptPerformanceToMapWithConfig(perfMap, PerformanceMapConfig{})
+}
 
-		// Check cache first
-		if val, ok := cache[r]; ok {
-			// Apply map transformation
-			return val * x * (1 - x) // Logistic-like behavior
-		}
+// AdaptPerformanceToMapWithConfig converts real performance measurements to
+// a MapFunction.
+//
+// Every iteration re-measures perfMap at load r, folds the result into a
+// moving average kept per r, and combines the normalized average with x.
+// If a measurement fails, the last average for r is used (or x is returned
+// unchanged if r was never measured). The returned map is safe for
+// concurrent use, so it works with FeigenbaumConfig.Workers > 1.
+func AdaptPerformanceToMapWithConfig(perfMap PerformanceMap, cfg PerformanceMapConfig) MapFunction {
+	if cfg.Scale == 0 {
+		cfg.Scale = DefaultPerformanceMapScale
+	}
+	if cfg.Combine == nil {
+		cfg.Combine = func(x, r, m float64) float64 { return m * x * (1 - x) }
+	}
+	if cfg.Smoothing <= 0 || cfg.Smoothing > 1 {
+		cfg.Smoothing = 1
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultPerformanceMapTimeout
+	}
 
-		// Measure actual performance (expensive)
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
-		defer cancel()
+	var mu sync.Mutex
+	averages := make(map[float64]float64) // Moving average of raw measurements per r
 
-		latency, err := perfMap(ctx, r)
-		if err != nil {
+	return func(x, r float64) float64 {
+		// x = current state (normalized)
+		// r = load parameter
+
+		// Measure actual performance (expensive), outside the lock
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		measurement, err := perfMap(ctx, r)
+		cancel()
+
+		mu.Lock()
+		avg, seen := averages[r]
+		switch {
+		case err != nil && !seen:
+			mu.Unlock()
 			return x // Keep current value on error
+		case err == nil && seen:
+			avg += cfg.Smoothing * (measurement - avg)
+		case err == nil:
+			avg = measurement
 		}
+		averages[r] = avg
+		mu.Unlock()
 
-		// Normalize and cache
-		normalized := latency / 1000.0 // Assume latency in microseconds
-		cache[r] = normalized
-
-		return normalized * x * (1 - x)
+		return cfg.Combine(x, r, avg/cfg.Scale)
 	}
 }
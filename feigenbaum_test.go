@@ -1,8 +1,13 @@
 package lawbench
 
 import (
+	"context"
+	"errors"
 	"math"
+	"reflect"
+	"runtime"
 	"testing"
+	"time"
 )
 
 // TestLogisticMap_Recovery verifies system can exit saturation.
@@ -12,8 +17,8 @@ func TestLogisticMap_Recovery(t *testing.T) {
 	cfg.RecoveryThreshold = 0.01
 
 	x0 := 0.5
-	rSaturation := 3.9  // Deep in saturation
-	rStable := 2.8 // Stable period-1
+	rSaturation := 3.9 // Deep in saturation
+	rStable := 2.8     // Stable period-1
 
 	iterations := MeasureRecoveryTime(LogisticMap, x0, rSaturation, rStable, cfg)
 
@@ -224,3 +229,479 @@ func TestFeigenbaum_LorenzButterfly(t *testing.T) {
 	t.Logf("  3. Can it transit through without diverging?")
 	t.Logf("  4. Does it stay in life-compatible basin?")
 }
+
+// TestCalculateFractalDimension_BoxCounting verifies box-counting on known attractors.
+func TestCalculateFractalDimension_BoxCounting(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.Iterations = 5000
+	// Chaotic: orbit fills a segment of the parabola x_{i+1} = r·x_i(1-x_i)
+	chaotic := CalculateFractalDimension(IterateMap(LogisticMap, 0.3, 3.9, cfg))
+	if chaotic <= 0.9 || chaotic >= 2.0 {
+		t.Errorf("r=3.9: expected 0.9 < D < 2, got %.3f", chaotic)
+	}
+
+	// Period-2: two points in the delay plane, N(ε) is constant
+	periodic := CalculateFractalDimension(IterateMap(LogisticMap, 0.3, 3.2, cfg))
+	if periodic > 0.1 {
+		t.Errorf("r=3.2 (period-2): expected D ≈ 0, got %.3f", periodic)
+	}
+
+	if chaotic <= periodic {
+		t.Errorf("Chaotic dimension %.3f should exceed periodic %.3f", chaotic, periodic)
+	}
+
+	t.Logf("✓ Box-counting dimension: chaotic (r=3.9) D=%.3f, period-2 (r=3.2) D=%.3f", chaotic, periodic)
+}
+
+// TestCalculateFractalDimension_Henon verifies a dimension between 1 and 2 on the Hénon attractor.
+func TestCalculateFractalDimension_Henon(t *testing.T) {
+	// x_{n+1} = 1 - a·x_n² + y_n, y_{n+1} = b·x_n  ⇒  x_{n+1} = 1 - a·x_n² + b·x_{n-1}
+	const a, b = 1.4, 0.3
+	x, prev := 0.1, 0.0
+	for i := 0; i < 1000; i++ {
+		x, prev = 1-a*x*x+b*prev, x
+	}
+
+	trajectory := make([]float64, 50000)
+	for i := range trajectory {
+		x, prev = 1-a*x*x+b*prev, x
+		trajectory[i] = x
+	}
+
+	d := CalculateFractalDimension(trajectory)
+	if d <= 1.0 || d >= 1.5 {
+		t.Errorf("Hénon: expected D ≈ 1.26, got %.3f", d)
+	}
+	t.Logf("✓ Hénon attractor: D=%.3f (literature: 1.26)", d)
+}
+
+// TestLyapunovExponent_LogisticMap verifies the sign of λ across regimes.
+func TestLyapunovExponent_LogisticMap(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.Iterations = 20000
+
+	tests := []struct {
+		name     string
+		r        float64
+		min, max float64
+	}{
+		{"stable fixed point", 2.8, -1, -0.05},
+		{"period-2", 3.2, -2, -0.05},
+		{"chaos", 3.9, 0.4, 0.6}, // Theoretical ≈ 0.49
+		{"fully developed chaos", 4.0, 0.6, 0.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			numeric := LyapunovExponent(LogisticMap, 0.3, tt.r, cfg)
+			analytic := LyapunovExponentAnalytic(LogisticMap, LogisticMapDerivative, 0.3, tt.r, cfg)
+
+			if numeric < tt.min || numeric > tt.max {
+				t.Errorf("r=%.2f: λ = %.4f outside [%.2f, %.2f]", tt.r, numeric, tt.min, tt.max)
+			}
+			if math.Abs(numeric-analytic) > 1e-3 {
+				t.Errorf("r=%.2f: numeric λ %.6f differs from analytic %.6f", tt.r, numeric, analytic)
+			}
+			t.Logf("✓ r=%.2f: λ = %.4f", tt.r, numeric)
+		})
+	}
+}
+
+// TestFeigenbaumAnalysis_ChaosMargin verifies the margin shrinks toward zero
+// as the operating point approaches the boundary, and that the operating
+// point's exponent is recorded.
+func TestFeigenbaumAnalysis_ChaosMargin(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.MinR = 2.0
+	cfg.Derivative = LogisticMapDerivative
+	cfg.OperatingR = 2.8
+
+	analysis := AnalyzeBifurcation(LogisticMap, 0.5, cfg)
+	if len(analysis.LyapunovSpectrum) == 0 {
+		t.Fatal("Expected a Lyapunov spectrum with cfg.Derivative set")
+	}
+	if want := math.Log(0.8); math.Abs(analysis.LyapunovAtOperatingPoint-want) > 0.01 {
+		t.Errorf("λ at r=2.8 = %.4f, want ln|2−r| = %.4f", analysis.LyapunovAtOperatingPoint, want)
+	}
+
+	prev := math.Inf(1)
+	for _, r := range []float64{2.2, 2.5, 2.8, 2.95, 2.99} {
+		margin := analysis.ChaosMargin(r)
+		if margin <= 0 || margin >= prev {
+			t.Errorf("r=%.2f: margin %.4f should be positive and below %.4f", r, margin, prev)
+		}
+		prev = margin
+	}
+
+	boundary := analysis.ChaosMargin(analysis.SaturationBoundary)
+	if math.Abs(boundary) > 0.1 {
+		t.Errorf("Margin at the saturation boundary %.3f = %.4f, want ≈ 0", analysis.SaturationBoundary, boundary)
+	}
+	if margin := analysis.ChaosMargin(3.9); margin >= 0 {
+		t.Errorf("Margin in chaos (r=3.9) = %.4f, want negative", margin)
+	}
+
+	if margin := AnalyzeBifurcation(LogisticMap, 0.5, DefaultFeigenbaumConfig()).ChaosMargin(2.8); !math.IsNaN(margin) {
+		t.Errorf("Margin without a derivative = %.4f, want NaN", margin)
+	}
+
+	t.Logf("✓ Margin %.4f at r=2.2 → %.4f at r=2.99 → %.4f at the boundary r=%.2f",
+		analysis.ChaosMargin(2.2), analysis.ChaosMargin(2.99), boundary, analysis.SaturationBoundary)
+}
+
+// TestAssertPositiveLyapunov verifies chaos confirmation at r=3.9.
+func TestAssertPositiveLyapunov(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.Iterations = 10000
+	AssertPositiveLyapunov(t, LogisticMap, 0.3, 3.9, cfg)
+}
+
+// TestAnalyzeBifurcation_ParallelIdentical verifies the parallel sweep matches the serial one exactly.
+func TestAnalyzeBifurcation_ParallelIdentical(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.MinR = 2.5
+
+	serial := AnalyzeBifurcation(LogisticMap, 0.5, cfg)
+
+	cfg.Workers = 8
+	parallel := AnalyzeBifurcation(LogisticMap, 0.5, cfg)
+
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Errorf("Parallel analysis differs from serial:\nserial:   δ=%v α=%v boundary=%v bifurcations=%d\nparallel: δ=%v α=%v boundary=%v bifurcations=%d",
+			serial.Delta, serial.Alpha, serial.SaturationBoundary, len(serial.Bifurcations),
+			parallel.Delta, parallel.Alpha, parallel.SaturationBoundary, len(parallel.Bifurcations))
+	}
+}
+
+// TestAnalyzeBifurcation_Alpha verifies α is recovered from the logistic
+// map on a fine sweep: each d_n is the cycle splitting mid-window, and α is
+// the average of d_n/d_{n+1} over the cascade.
+func TestAnalyzeBifurcation_Alpha(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.MinR, cfg.MaxR, cfg.StepR = 2.9, 3.58, 0.0001
+	cfg.Warmup = 10000 // Critical slowing: resolve cycles close to each bifurcation
+	cfg.Iterations = 1024
+	cfg.Workers = 4
+
+	analysis := AnalyzeBifurcation(LogisticMap, 0.5, cfg)
+
+	if len(analysis.Bifurcations) < 4 {
+		t.Fatalf("Expected at least 4 bifurcations (periods 2..16), got %d", len(analysis.Bifurcations))
+	}
+	for i, bif := range analysis.Bifurcations[:len(analysis.Bifurcations)-1] {
+		next := analysis.Bifurcations[i+1]
+		if bif.Amplitude <= next.Amplitude && next.Amplitude > 0 {
+			t.Errorf("Splitting should shrink: d(period %d)=%.5f, d(period %d)=%.5f",
+				bif.Period, bif.Amplitude, next.Period, next.Amplitude)
+		}
+	}
+
+	const expectedAlpha = 2.502
+	if math.Abs(analysis.Alpha-expectedAlpha) > FeigenbaumAlphaTolerance {
+		t.Errorf("α = %.3f, expected %.3f ± %.1f", analysis.Alpha, expectedAlpha, FeigenbaumAlphaTolerance)
+	}
+
+	AssertFeigenbaumCascade(t, analysis)
+	t.Logf("✓ α = %.3f, δ = %.3f over %d bifurcations", analysis.Alpha, analysis.Delta, len(analysis.Bifurcations))
+}
+
+// TestAnalyzeBifurcation_FirstBifurcation verifies the logistic map loses
+// its fixed point near r = 3.0, and that a sweep starting past the onset
+// reports none.
+func TestAnalyzeBifurcation_FirstBifurcation(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.MinR = 2.5
+	cfg.Warmup = 5000 // Let the decaying alternation below 3.0 die out
+
+	analysis := AnalyzeBifurcation(LogisticMap, 0.5, cfg)
+	AssertFirstBifurcation(t, analysis, 3.0, cfg.StepR)
+	if len(analysis.Bifurcations) == 0 || analysis.FirstBifurcationR != analysis.Bifurcations[0].R {
+		t.Errorf("Onset %.4f should be the first recorded bifurcation", analysis.FirstBifurcationR)
+	}
+
+	cfg.MinR = 3.2 // Already period 2
+	if late := AnalyzeBifurcation(LogisticMap, 0.5, cfg); late.FirstBifurcationR != 0 {
+		t.Errorf("Sweep from r=3.2 should miss the onset, got r=%.4f", late.FirstBifurcationR)
+	}
+
+	cfg.MinR, cfg.MaxR = 1.5, 2.9 // Stable throughout
+	if stable := AnalyzeBifurcation(LogisticMap, 0.5, cfg); stable.FirstBifurcationR != 0 {
+		t.Errorf("Stable sweep reported an onset at r=%.4f", stable.FirstBifurcationR)
+	}
+}
+
+func BenchmarkAnalyzeBifurcation_Serial(b *testing.B) {
+	cfg := DefaultFeigenbaumConfig()
+	for i := 0; i < b.N; i++ {
+		AnalyzeBifurcation(LogisticMap, 0.5, cfg)
+	}
+}
+
+func BenchmarkAnalyzeBifurcation_Parallel(b *testing.B) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.Workers = runtime.GOMAXPROCS(0)
+	for i := 0; i < b.N; i++ {
+		AnalyzeBifurcation(LogisticMap, 0.5, cfg)
+	}
+}
+
+// TestCorrelationDimension_LogisticMap verifies sub-integer dimensions for the logistic map.
+func TestCorrelationDimension_LogisticMap(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.Iterations = 2000
+
+	chaotic := CorrelationDimension(IterateMap(LogisticMap, 0.3, 3.9, cfg), 2)
+	if chaotic <= 0.5 || chaotic >= 1.05 {
+		t.Errorf("r=3.9: expected 0.5 < D₂ < 1.05, got %.3f", chaotic)
+	}
+
+	periodic := CorrelationDimension(IterateMap(LogisticMap, 0.3, 3.2, cfg), 2)
+	if math.Abs(periodic) > 0.05 {
+		t.Errorf("r=3.2 (period-2): expected D₂ ≈ 0, got %.3f", periodic)
+	}
+
+	t.Logf("✓ Correlation dimension: chaotic (r=3.9) D₂=%.3f, period-2 D₂=%.3f", chaotic, periodic)
+}
+
+// TestCorrelationDimension_Henon verifies the Grassberger–Procaccia value for the Hénon attractor.
+func TestCorrelationDimension_Henon(t *testing.T) {
+	const a, b = 1.4, 0.3
+	x, prev := 0.1, 0.0
+	for i := 0; i < 1000; i++ {
+		x, prev = 1-a*x*x+b*prev, x
+	}
+
+	trajectory := make([]float64, 200000) // Far beyond the sample cap
+	for i := range trajectory {
+		x, prev = 1-a*x*x+b*prev, x
+		trajectory[i] = x
+	}
+
+	d := CorrelationDimension(trajectory, 2)
+	if d <= 1.0 || d >= 1.4 {
+		t.Errorf("Hénon: expected D₂ ≈ 1.21, got %.3f", d)
+	}
+
+	capped := correlationDimension(trajectory, 2, 200)
+	if math.Abs(capped-d) > 0.2 {
+		t.Errorf("Sample cap 200 changed D₂ too much: %.3f vs %.3f", capped, d)
+	}
+
+	t.Logf("✓ Hénon attractor: D₂=%.3f (literature: 1.21), capped at 200 samples: %.3f", d, capped)
+}
+
+// TestAnalyzeBifurcation_CorrelationMethod verifies cfg.DimensionMethod selects the estimator.
+func TestAnalyzeBifurcation_CorrelationMethod(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.MinR = 2.8
+	cfg.DimensionMethod = DimensionCorrelation
+	cfg.MaxDimensionSamples = 300
+
+	analysis := AnalyzeBifurcation(LogisticMap, 0.5, cfg)
+	if analysis.SaturationBoundary == 0 {
+		t.Fatal("No saturation boundary detected")
+	}
+	if analysis.FractalDimension <= 0 || analysis.FractalDimension >= 1.05 {
+		t.Errorf("Expected sub-integer correlation dimension at the boundary, got %.3f", analysis.FractalDimension)
+	}
+	t.Logf("✓ Boundary r=%.4f, D₂=%.3f", analysis.SaturationBoundary, analysis.FractalDimension)
+}
+
+// TestDetectPeriod_Period3Window verifies AllowOddPeriods finds the period-3 window.
+func TestDetectPeriod_Period3Window(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.Warmup = 2000
+	cfg.Tolerance = 1e-6
+
+	trajectory := IterateMap(LogisticMap, 0.5, 3.835, cfg)
+
+	if period := DetectPeriod(trajectory, cfg); period != -1 {
+		t.Errorf("Power-of-2 mode should not match period 3, got %d", period)
+	}
+
+	cfg.AllowOddPeriods = true
+	if period := DetectPeriod(trajectory, cfg); period != 3 {
+		t.Errorf("Expected period 3 at r=3.835, got %d", period)
+	}
+
+	// Smallest period wins: the period-2 orbit also repeats every 4 and 6 steps
+	if period := DetectPeriod(IterateMap(LogisticMap, 0.5, 3.2, cfg), cfg); period != 2 {
+		t.Errorf("Expected period 2 at r=3.2, got %d", period)
+	}
+
+	// Chaos is still chaos
+	if period := DetectPeriod(IterateMap(LogisticMap, 0.5, 3.9, cfg), cfg); period != -1 {
+		t.Errorf("Expected -1 (chaos) at r=3.9, got %d", period)
+	}
+
+	t.Logf("✓ Period-3 window detected at r=3.835 (period three implies chaos)")
+}
+
+// TestAnalyzeBifurcationCtx_CancelMidSweep verifies cancellation returns the
+// analysis of the r-range swept so far plus ctx.Err().
+func TestAnalyzeBifurcationCtx_CancelMidSweep(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	callsPerR := cfg.Warmup + cfg.Iterations
+
+	// Cancel once the sweep has covered r ≈ 0..3.5 (past the 1→2→4 doublings)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	f := func(x, r float64) float64 {
+		calls++
+		if calls == 351*callsPerR {
+			cancel()
+		}
+		return LogisticMap(x, r)
+	}
+
+	analysis, err := AnalyzeBifurcationCtx(ctx, f, 0.5, cfg)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	if len(analysis.Bifurcations) < 2 {
+		t.Errorf("Partial analysis should keep the 1→2→4 doublings, got %d bifurcations", len(analysis.Bifurcations))
+	}
+	for _, b := range analysis.Bifurcations {
+		if b.R > 3.51 {
+			t.Errorf("Bifurcation at r=%.3f is beyond the cancelled sweep", b.R)
+		}
+	}
+	if analysis.RecoveryTime != 0 || analysis.TransitTime != 0 {
+		t.Errorf("Recovery and transit should be unmeasured, got %d/%d", analysis.RecoveryTime, analysis.TransitTime)
+	}
+
+	t.Logf("✓ Cancelled after %d r-steps: %d bifurcations kept", calls/callsPerR, len(analysis.Bifurcations))
+}
+
+// TestAnalyzeBifurcationCtx_Deadline verifies a deadline bounds wall-clock
+// time on an expensive map, serially and in parallel.
+func TestAnalyzeBifurcationCtx_Deadline(t *testing.T) {
+	slow := func(x, r float64) float64 {
+		time.Sleep(10 * time.Microsecond) // Stand-in for real I/O
+		return LogisticMap(x, r)
+	}
+
+	for _, workers := range []int{1, 4} {
+		cfg := DefaultFeigenbaumConfig()
+		cfg.Iterations = 100 // Cancellation is checked between r-steps
+		cfg.Warmup = 0
+		cfg.Workers = workers
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		start := time.Now()
+		_, err := AnalyzeBifurcationCtx(ctx, slow, 0.5, cfg)
+		elapsed := time.Since(start)
+		cancel()
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("workers=%d: expected context.DeadlineExceeded, got %v", workers, err)
+		}
+		if elapsed > time.Second {
+			t.Errorf("workers=%d: analysis ran %v past a 50ms deadline", workers, elapsed)
+		}
+		t.Logf("✓ workers=%d: stopped after %v", workers, elapsed)
+	}
+}
+
+// TestMeasureRecoveryTimeCtx_Cancelled verifies recovery and transit
+// measurements stop on a cancelled context.
+func TestMeasureRecoveryTimeCtx_Cancelled(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if n, err := MeasureRecoveryTimeCtx(ctx, LogisticMap, 0.5, 3.9, 2.8, cfg); n != 0 || !errors.Is(err, context.Canceled) {
+		t.Errorf("MeasureRecoveryTimeCtx = (%d, %v), want (0, context.Canceled)", n, err)
+	}
+	if n, err := MeasureTransitTimeCtx(ctx, LogisticMap, 0.5, 3.9, cfg); n != 0 || !errors.Is(err, context.Canceled) {
+		t.Errorf("MeasureTransitTimeCtx = (%d, %v), want (0, context.Canceled)", n, err)
+	}
+
+	// Without cancellation the wrappers agree with the Ctx variants
+	n, err := MeasureRecoveryTimeCtx(context.Background(), LogisticMap, 0.5, 3.9, 2.8, cfg)
+	if err != nil || n != MeasureRecoveryTime(LogisticMap, 0.5, 3.9, 2.8, cfg) {
+		t.Errorf("Background context should match MeasureRecoveryTime, got (%d, %v)", n, err)
+	}
+}
+
+// TestAdaptPerformanceToMap_Remeasures verifies every iteration reflects the
+// latest measurements (averaged per Smoothing), with the configured
+// normalization and combination.
+func TestAdaptPerformanceToMap_Remeasures(t *testing.T) {
+	// Latency at load r drifts up by 1000µs on every measurement
+	fake := func() PerformanceMap {
+		var calls int
+		return func(ctx context.Context, load float64) (float64, error) {
+			calls++
+			return 1000 * float64(calls), nil
+		}
+	}
+
+	tests := []struct {
+		name string
+		cfg  PerformanceMapConfig
+		want []float64 // f(0.5, 3) on successive calls
+	}{
+		{"Default (latest measurement)", PerformanceMapConfig{}, []float64{0.25, 0.5, 0.75}},
+		{"Moving average", PerformanceMapConfig{Smoothing: 0.5}, []float64{0.25, 0.375, 0.5625}},
+		{
+			"Custom scale and combine",
+			PerformanceMapConfig{Scale: 2000, Combine: func(x, r, m float64) float64 { return x + m }},
+			[]float64{1, 1.5, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := AdaptPerformanceToMapWithConfig(fake(), tt.cfg)
+			for i, want := range tt.want {
+				if got := f(0.5, 3); math.Abs(got-want) > 1e-12 {
+					t.Errorf("Call %d: f(0.5, 3) = %v, want %v", i+1, got, want)
+				}
+			}
+		})
+	}
+
+	// A failed measurement reuses the last average, or keeps x if none
+	fails := false
+	f := AdaptPerformanceToMap(func(ctx context.Context, load float64) (float64, error) {
+		if fails {
+			return 0, errors.New("probe failed")
+		}
+		return 2000, nil
+	})
+	fails = true
+	if got := f(0.3, 1); got != 0.3 {
+		t.Errorf("Unmeasured r on error should keep x=0.3, got %v", got)
+	}
+	fails = false
+	f(0.5, 2)
+	fails = true
+	if got := f(0.5, 2); got != 0.5 {
+		t.Errorf("Error should reuse the last measurement (f=0.5), got %v", got)
+	}
+}
+
+// TestAdaptPerformanceToMap_ConcurrentIteration verifies the adapted map is
+// safe for a parallel bifurcation sweep and matches the map it measures.
+func TestAdaptPerformanceToMap_ConcurrentIteration(t *testing.T) {
+	// Deterministic "service" whose normalized latency is exactly r, so the
+	// adapted map reproduces the logistic map
+	perf := func(ctx context.Context, load float64) (float64, error) { return load, nil }
+	f := AdaptPerformanceToMapWithConfig(perf, PerformanceMapConfig{Scale: 1, Smoothing: 0.3})
+
+	cfg := DefaultFeigenbaumConfig()
+	cfg.MinR = 2.5
+	want := AnalyzeBifurcation(LogisticMap, 0.5, cfg)
+
+	cfg.Workers = 8
+	got := AnalyzeBifurcation(f, 0.5, cfg)
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Adapted map analysis differs from logistic map:\nwant: δ=%v bifurcations=%d\ngot:  δ=%v bifurcations=%d",
+			want.Delta, len(want.Bifurcations), got.Delta, len(got.Bifurcations))
+	}
+	t.Logf("✓ Parallel sweep over measured map: δ=%.4f, %d bifurcations", got.Delta, len(got.Bifurcations))
+}
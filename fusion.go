@@ -0,0 +1,161 @@
+package lawbench
+
+import (
+	"fmt"
+	"math"
+)
+
+// RSignal is one independent estimate of the coupling parameter r with a
+// confidence in [0, 1], e.g. from USLCoefficients.RSignal,
+// TailDivergenceTracker.RSignal or CalculateSystemDNA.
+type RSignal struct {
+	Source     string  // Label for diagnostics ("usl", "tail", "dna", ...)
+	R          float64 // Estimated r
+	Confidence float64 // 0 = ignore, 1 = fully trusted
+}
+
+// RDisagreementSpread is the spread between credible signals beyond which
+// AnalyzeRSignals reports disagreement. Half a unit of r separates the
+// stable zone from saturation (2.6 vs 3.1), so two signals this far apart
+// would put the governor in different zones.
+const RDisagreementSpread = 0.5
+
+// RFusion is the combined r estimate with agreement diagnostics.
+type RFusion struct {
+	R         float64 // Best estimate: WeightedR, or MaxR on disagreement
+	WeightedR float64 // Confidence-weighted mean of the used signals
+	MaxR      float64 // Highest credible r (or highest used r if none is credible)
+	Spread    float64 // Max − min r among credible signals
+	Used      int     // Signals with positive confidence and finite r
+	Credible  int     // Used signals with confidence ≥ DefaultMinRConfidence
+
+	// Disagree is true when credible signals are more than
+	// RDisagreementSpread apart: at least one of them is wrong, and R falls
+	// back to MaxR.
+	Disagree bool
+}
+
+// FuseR combines independent r estimates into one. See AnalyzeRSignals.
+func FuseR(estimates ...RSignal) float64 {
+	return AnalyzeRSignals(estimates...).R
+}
+
+// AnalyzeRSignals fuses r estimates by confidence-weighted mean and checks
+// whether they agree.
+//
+// Confidence is clamped to [0, 1]; signals with zero confidence or a
+// non-finite r are ignored. Only credible signals (confidence ≥
+// DefaultMinRConfidence) can flag disagreement: a noisy low-confidence
+// signal is already down-weighted and should not force the conservative
+// fallback. When credible signals disagree, R is the highest of them:
+// over-estimating r costs some shed load, under-estimating it misses
+// saturation.
+//
+// With no usable signal R is NaN.
+func AnalyzeRSignals(estimates ...RSignal) RFusion {
+	fusion := RFusion{
+		R:         math.NaN(),
+		WeightedR: math.NaN(),
+		MaxR:      math.NaN(),
+	}
+
+	var sum, weight float64
+	usedMax, credibleMin, credibleMax := math.Inf(-1), math.Inf(1), math.Inf(-1)
+	for _, s := range estimates {
+		c := clampFraction(s.Confidence)
+		if c == 0 || math.IsNaN(c) || math.IsNaN(s.R) || math.IsInf(s.R, 0) {
+			continue
+		}
+
+		fusion.Used++
+		sum += c * s.R
+		weight += c
+		usedMax = math.Max(usedMax, s.R)
+
+		if c >= DefaultMinRConfidence {
+			fusion.Credible++
+			credibleMin = math.Min(credibleMin, s.R)
+			credibleMax = math.Max(credibleMax, s.R)
+		}
+	}
+	if fusion.Used == 0 {
+		return fusion
+	}
+
+	fusion.WeightedR = sum / weight
+	fusion.R = fusion.WeightedR
+	fusion.MaxR = usedMax
+
+	if fusion.Credible > 0 {
+		fusion.MaxR = credibleMax
+		fusion.Spread = credibleMax - credibleMin
+	}
+	if fusion.Spread > RDisagreementSpread {
+		fusion.Disagree = true
+		fusion.R = fusion.MaxR
+	}
+
+	return fusion
+}
+
+// RSignal returns CouplingR(n) as a fusion signal, trusted as much as the
+// fit explains the data (R², clamped to [0, 1]).
+func (c USLCoefficients) RSignal(n int) RSignal {
+	return RSignal{Source: "usl", R: c.CouplingR(n), Confidence: clampFraction(c.RSquared)}
+}
+
+// RSignal returns EstimateRWithConfidence as a fusion signal.
+func (t *TailDivergenceTracker) RSignal() RSignal {
+	r, confidence := t.EstimateRWithConfidence()
+	return RSignal{Source: "tail", R: r, Confidence: confidence}
+}
+
+// CouplingReport ties a benchmark's USL fit to the tail behavior observed
+// for the same workload (see AnalyzeCouplingSources).
+type CouplingReport struct {
+	USL  USLCoefficients
+	Tail TailStats
+	MaxN int // Highest measured concurrency
+
+	USLR  float64 // USL.CouplingR(MaxN): r from coordination overhead
+	TailR float64 // Tail-derived EstimateR: r from latency divergence
+
+	// Fusion combines both signals, weighted by R² and tail confidence.
+	Fusion RFusion
+
+	// Disagree is set when USLR and TailR are more than
+	// RDisagreementSpread apart, whatever their confidence: either the
+	// tail is driven by something the USL fit doesn't see (GC pauses, a
+	// slow dependency) or β is fitted from too few levels.
+	Disagree bool
+}
+
+// AnalyzeCouplingSources reports the USL-derived and tail-derived r side by
+// side, to check empirically whether coordination overhead (β) and
+// power-law tail onset move together for a workload. The tracker should
+// hold latencies from the same runs, e.g. recorded from Result.Latencies at
+// the highest level.
+//
+// It returns an error if results cannot be fitted (fewer than three
+// levels) or tracker is nil.
+func AnalyzeCouplingSources(results []Result, tracker *TailDivergenceTracker) (CouplingReport, error) {
+	if tracker == nil {
+		return CouplingReport{}, fmt.Errorf("nil tail tracker")
+	}
+	coeffs, err := FitUSL(results)
+	if err != nil {
+		return CouplingReport{}, err
+	}
+
+	report := CouplingReport{USL: coeffs, Tail: tracker.GetStats()}
+	for _, r := range results {
+		report.MaxN = max(report.MaxN, r.N)
+	}
+
+	uslSignal, tailSignal := coeffs.RSignal(report.MaxN), tracker.RSignal()
+	report.USLR, report.TailR = uslSignal.R, tailSignal.R
+	report.Fusion = AnalyzeRSignals(uslSignal, tailSignal)
+	report.Disagree = math.Abs(report.USLR-report.TailR) > RDisagreementSpread
+
+	return report, nil
+}
@@ -0,0 +1,170 @@
+package lawbench
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestFuseR_ConfidentSignalDominates verifies a confident USL signal
+// outweighs a low-confidence tail signal, even a wild one, without flagging
+// disagreement.
+func TestFuseR_ConfidentSignalDominates(t *testing.T) {
+	usl := RSignal{Source: "usl", R: 2.2, Confidence: 0.95}
+
+	tests := []struct {
+		name string
+		tail RSignal
+	}{
+		{"Nearby tail", RSignal{Source: "tail", R: 2.6, Confidence: 0.1}},
+		{"Wild tail", RSignal{Source: "tail", R: 3.8, Confidence: 0.1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fusion := AnalyzeRSignals(usl, tt.tail)
+
+			want := (0.95*usl.R + 0.1*tt.tail.R) / 1.05
+			if math.Abs(fusion.R-want) > 1e-12 {
+				t.Errorf("R = %.4f, want weighted mean %.4f", fusion.R, want)
+			}
+			if math.Abs(fusion.R-usl.R) > math.Abs(fusion.R-tt.tail.R) {
+				t.Errorf("R = %.4f is closer to the tail signal (%.2f) than USL (%.2f)", fusion.R, tt.tail.R, usl.R)
+			}
+			if fusion.Disagree || fusion.Credible != 1 || fusion.Used != 2 {
+				t.Errorf("Expected agreement with 1 credible of 2 used, got %+v", fusion)
+			}
+			if got := FuseR(usl, tt.tail); got != fusion.R {
+				t.Errorf("FuseR = %.4f, want %.4f", got, fusion.R)
+			}
+
+			t.Logf("✓ USL %.2f (0.95) + tail %.2f (0.1) → r=%.4f", usl.R, tt.tail.R, fusion.R)
+		})
+	}
+}
+
+// TestFuseR_DisagreementFallsBackToMax verifies credible signals far apart
+// are flagged and fused to the highest r.
+func TestFuseR_DisagreementFallsBackToMax(t *testing.T) {
+	fusion := AnalyzeRSignals(
+		RSignal{Source: "usl", R: 1.8, Confidence: 0.9},
+		RSignal{Source: "tail", R: 2.0, Confidence: 0.7},
+		RSignal{Source: "dna", R: 3.4, Confidence: 0.6},
+	)
+
+	if !fusion.Disagree {
+		t.Fatalf("Expected disagreement, got %+v", fusion)
+	}
+	if fusion.R != 3.4 || fusion.MaxR != 3.4 {
+		t.Errorf("Expected conservative r=3.4, got R=%.4f MaxR=%.4f", fusion.R, fusion.MaxR)
+	}
+	if math.Abs(fusion.Spread-1.6) > 1e-12 {
+		t.Errorf("Spread = %.4f, want 1.6", fusion.Spread)
+	}
+	if fusion.WeightedR >= 3.0 {
+		t.Errorf("Weighted mean %.4f should still be reported below the max", fusion.WeightedR)
+	}
+
+	t.Logf("✓ Spread %.2f flagged: r=%.2f (weighted %.4f)", fusion.Spread, fusion.R, fusion.WeightedR)
+}
+
+// TestFuseR_IgnoresUnusableSignals verifies zero-confidence and non-finite
+// signals are skipped, and NaN is returned when nothing is left.
+func TestFuseR_IgnoresUnusableSignals(t *testing.T) {
+	got := FuseR(
+		RSignal{R: 2.5, Confidence: 0.8},
+		RSignal{R: 4.0, Confidence: 0},
+		RSignal{R: math.NaN(), Confidence: 1},
+		RSignal{R: math.Inf(1), Confidence: 1},
+		RSignal{R: 3.9, Confidence: math.NaN()},
+	)
+	if got != 2.5 {
+		t.Errorf("Expected only the usable signal (2.5), got %.4f", got)
+	}
+
+	if got := FuseR(); !math.IsNaN(got) {
+		t.Errorf("Expected NaN with no signals, got %.4f", got)
+	}
+	if got := FuseR(RSignal{R: 2.0}); !math.IsNaN(got) {
+		t.Errorf("Expected NaN with only zero-confidence signals, got %.4f", got)
+	}
+}
+
+// TestRSignal_Sources verifies the USL and tail tracker adapters.
+func TestRSignal_Sources(t *testing.T) {
+	coeffs := USLCoefficients{Alpha: 0.05, Beta: 0.001, RSquared: 0.98}
+	s := coeffs.RSignal(16)
+	if s.R != coeffs.CouplingR(16) || s.Confidence != 0.98 {
+		t.Errorf("USL signal = %+v, want r=%.4f confidence=0.98", s, coeffs.CouplingR(16))
+	}
+
+	tracker := NewTailDivergenceTracker(1000)
+	if s := tracker.RSignal(); s.Confidence != 0 {
+		t.Errorf("Empty tracker should have zero confidence, got %+v", s)
+	}
+	if got := FuseR(coeffs.RSignal(16), tracker.RSignal()); got != coeffs.CouplingR(16) {
+		t.Errorf("Empty tracker should not move the fused r, got %.4f", got)
+	}
+}
+
+// TestAnalyzeCouplingSources_HighBetaPowerLaw verifies high-β results paired
+// with a power-law tail give consistent high-r signals that agree, and that
+// the same results with a Gaussian tail are flagged.
+func TestAnalyzeCouplingSources_HighBetaPowerLaw(t *testing.T) {
+	var results []Result
+	for _, n := range []int{1, 2, 4, 8, 16} {
+		results = append(results, Result{N: n, Throughput: uslModel(float64(n), 1000, 0.02, 0.03)})
+	}
+
+	powerLaw := NewTailDivergenceTracker(10000)
+	for i := 0; i < 5000; i++ {
+		latency := 10 * time.Millisecond
+		if i%50 == 0 {
+			latency = 300 * time.Millisecond // 2% slow tail: P99/P50 = 30
+		}
+		powerLaw.Record(latency)
+	}
+
+	report, err := AnalyzeCouplingSources(results, powerLaw)
+	if err != nil {
+		t.Fatalf("AnalyzeCouplingSources failed: %v", err)
+	}
+
+	if report.MaxN != 16 || report.USLR != report.USL.CouplingR(16) {
+		t.Errorf("Expected USL r at N=16, got MaxN=%d USLR=%.4f", report.MaxN, report.USLR)
+	}
+	if report.USLR < StableDNAConstraint.MaxR || report.TailR < StableDNAConstraint.MaxR {
+		t.Errorf("Expected both signals saturated, got USL r=%.4f, tail r=%.4f", report.USLR, report.TailR)
+	}
+	if report.Disagree || report.Fusion.Disagree {
+		t.Errorf("Expected agreement: USL r=%.4f, tail r=%.4f", report.USLR, report.TailR)
+	}
+	if !report.Tail.IsPowerLaw {
+		t.Errorf("Tail stats should report a power law (ratio %.1f)", report.Tail.TailDivergenceRatio)
+	}
+	if r := report.Fusion.R; r < math.Min(report.USLR, report.TailR) || r > math.Max(report.USLR, report.TailR) {
+		t.Errorf("Fused r %.4f outside [%.4f, %.4f]", r, report.USLR, report.TailR)
+	}
+
+	gaussian := NewTailDivergenceTracker(10000)
+	for i := 0; i < 5000; i++ {
+		gaussian.Record(time.Duration(10+i%3) * time.Millisecond)
+	}
+	mixed, err := AnalyzeCouplingSources(results, gaussian)
+	if err != nil {
+		t.Fatalf("AnalyzeCouplingSources failed: %v", err)
+	}
+	if !mixed.Disagree || !mixed.Fusion.Disagree || mixed.Fusion.R != mixed.USLR {
+		t.Errorf("Expected flagged disagreement falling back to USL r=%.4f, got %+v", mixed.USLR, mixed.Fusion)
+	}
+
+	if _, err := AnalyzeCouplingSources(results[:2], powerLaw); err == nil {
+		t.Error("Expected a fit error with two levels")
+	}
+	if _, err := AnalyzeCouplingSources(results, nil); err == nil {
+		t.Error("Expected an error with a nil tracker")
+	}
+
+	t.Logf("✓ Agree: USL r=%.4f, tail r=%.4f → %.4f; Gaussian tail r=%.4f flagged",
+		report.USLR, report.TailR, report.Fusion.R, mixed.TailR)
+}
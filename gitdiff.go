@@ -0,0 +1,128 @@
+package lawbench
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// FileChange is one line of `git diff --numstat`: lines added and removed
+// in a single file. Binary files have no line counts.
+type FileChange struct {
+	Path    string
+	Added   int
+	Removed int
+	Binary  bool
+}
+
+// DiffStats is the per-file summary of a diff.
+type DiffStats struct {
+	Files []FileChange
+}
+
+// ParseGitNumstat reads the output of `git diff --numstat`.
+//
+// Each line is "added<TAB>removed<TAB>path"; binary files report "-" for
+// both counts. Renames are recorded under their new path, in both the
+// "old => new" and "dir/{old => new}/file" forms git prints.
+func ParseGitNumstat(r io.Reader) (DiffStats, error) {
+	var stats DiffStats
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			return DiffStats{}, fmt.Errorf("numstat line %d: expected 3 tab-separated fields, got %q", lineNo, line)
+		}
+
+		change := FileChange{Path: renamedPath(fields[2])}
+		if fields[0] == "-" && fields[1] == "-" {
+			change.Binary = true
+		} else {
+			var err error
+			if change.Added, err = strconv.Atoi(fields[0]); err != nil {
+				return DiffStats{}, fmt.Errorf("numstat line %d: bad added count: %w", lineNo, err)
+			}
+			if change.Removed, err = strconv.Atoi(fields[1]); err != nil {
+				return DiffStats{}, fmt.Errorf("numstat line %d: bad removed count: %w", lineNo, err)
+			}
+		}
+
+		stats.Files = append(stats.Files, change)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return DiffStats{}, err
+	}
+	return stats, nil
+}
+
+// renamedPath resolves git's rename notation to the destination path.
+func renamedPath(p string) string {
+	open, arrow, closing := strings.Index(p, "{"), strings.Index(p, " => "), strings.Index(p, "}")
+	switch {
+	case open >= 0 && arrow > open && closing > arrow:
+		// dir/{old => new}/file; either side of the arrow may be empty
+		joined := p[:open] + p[arrow+len(" => "):closing] + p[closing+1:]
+		return strings.ReplaceAll(joined, "//", "/")
+	case arrow >= 0:
+		return p[arrow+len(" => "):]
+	}
+	return p
+}
+
+// ComputeDeltasFromDiff classifies each changed file as Tier 1 (critical
+// core) or Tier 2/3 (extensible) and sums its churn (added + removed lines)
+// into DeltaCriticalCore and DeltaComplexity.
+//
+// Patterns use path.Match syntax against the slash-separated repository
+// path, plus the Go convention "dir/..." for everything under dir. Tier 1
+// takes precedence when a file matches both. A nil tier2Paths means every
+// file not in Tier 1; otherwise files matching neither list (docs, tests)
+// are ignored, as are binary files. Malformed patterns match nothing.
+//
+// The result carries only the deltas; feed it to NewCriticalityConstraint
+// or merge it into the metrics given to CheckStructuralIntegrity.
+func ComputeDeltasFromDiff(diff DiffStats, tier1Paths, tier2Paths []string) SystemIntegrityMetrics {
+	var metrics SystemIntegrityMetrics
+
+	for _, f := range diff.Files {
+		if f.Binary {
+			continue
+		}
+
+		churn := float64(f.Added + f.Removed)
+		switch {
+		case matchesAny(f.Path, tier1Paths):
+			metrics.DeltaCriticalCore += churn
+		case tier2Paths == nil || matchesAny(f.Path, tier2Paths):
+			metrics.DeltaComplexity += churn
+		}
+	}
+
+	return metrics
+}
+
+// matchesAny reports whether file matches one of the patterns.
+func matchesAny(file string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if dir, ok := strings.CutSuffix(pattern, "/..."); ok {
+			if dir == "." || file == dir || strings.HasPrefix(file, dir+"/") {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, file); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package lawbench
+
+import (
+	"strings"
+	"testing"
+)
+
+// sampleNumstat is `git diff --numstat` output for a change touching the
+// core, a plugin, docs, a binary asset and a renamed file.
+const sampleNumstat = "120\t30\tcore/state.go\n" +
+	"10\t5\tcore/merge/merge.go\n" +
+	"20\t4\tplugins/http/handler.go\n" +
+	"6\t0\tplugins/{old => grpc}/server.go\n" +
+	"40\t2\tREADME.md\n" +
+	"-\t-\tassets/logo.png\n" +
+	"3\t1\tcmd/tool.go => tools/tool.go\n"
+
+// TestParseGitNumstat verifies counts, binary files and rename resolution.
+func TestParseGitNumstat(t *testing.T) {
+	stats, err := ParseGitNumstat(strings.NewReader(sampleNumstat))
+	if err != nil {
+		t.Fatalf("ParseGitNumstat failed: %v", err)
+	}
+
+	want := []FileChange{
+		{Path: "core/state.go", Added: 120, Removed: 30},
+		{Path: "core/merge/merge.go", Added: 10, Removed: 5},
+		{Path: "plugins/http/handler.go", Added: 20, Removed: 4},
+		{Path: "plugins/grpc/server.go", Added: 6},
+		{Path: "README.md", Added: 40, Removed: 2},
+		{Path: "assets/logo.png", Binary: true},
+		{Path: "tools/tool.go", Added: 3, Removed: 1},
+	}
+
+	if len(stats.Files) != len(want) {
+		t.Fatalf("Expected %d files, got %d", len(want), len(stats.Files))
+	}
+	for i, w := range want {
+		if stats.Files[i] != w {
+			t.Errorf("File %d: got %+v, want %+v", i, stats.Files[i], w)
+		}
+	}
+
+	if _, err := ParseGitNumstat(strings.NewReader("12\tcore/state.go\n")); err == nil {
+		t.Error("Expected an error for a line with two fields")
+	}
+	if _, err := ParseGitNumstat(strings.NewReader("x\t1\tcore/state.go\n")); err == nil {
+		t.Error("Expected an error for a non-numeric count")
+	}
+}
+
+// TestComputeDeltasFromDiff verifies path classification into Tier 1 and
+// Tier 2/3 churn, and that the result feeds the 21% rule.
+func TestComputeDeltasFromDiff(t *testing.T) {
+	stats, err := ParseGitNumstat(strings.NewReader(sampleNumstat))
+	if err != nil {
+		t.Fatalf("ParseGitNumstat failed: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		tier1, tier2 []string
+		wantCore     float64
+		wantComplex  float64
+	}{
+		{
+			name:        "Recursive core, plugin glob",
+			tier1:       []string{"core/..."},
+			tier2:       []string{"plugins/*/*.go"},
+			wantCore:    150 + 15,
+			wantComplex: 24 + 6,
+		},
+		{
+			name:        "Top-level core only",
+			tier1:       []string{"core/*.go"},
+			tier2:       []string{"plugins/...", "core/..."},
+			wantCore:    150,
+			wantComplex: 15 + 24 + 6,
+		},
+		{
+			name:        "Nil Tier 2 takes everything else",
+			tier1:       []string{"core/..."},
+			tier2:       nil,
+			wantCore:    165,
+			wantComplex: 24 + 6 + 42 + 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := ComputeDeltasFromDiff(stats, tt.tier1, tt.tier2)
+			if m.DeltaCriticalCore != tt.wantCore || m.DeltaComplexity != tt.wantComplex {
+				t.Errorf("Got core=%.0f complexity=%.0f, want core=%.0f complexity=%.0f",
+					m.DeltaCriticalCore, m.DeltaComplexity, tt.wantCore, tt.wantComplex)
+			}
+			t.Logf("✓ %s: ΔCore=%.0f ΔComplexity=%.0f", tt.name, m.DeltaCriticalCore, m.DeltaComplexity)
+		})
+	}
+
+	// 30/165 ≈ 0.18 respects the 21% rule
+	m := ComputeDeltasFromDiff(stats, []string{"core/..."}, []string{"plugins/..."})
+	if err := NewCriticalityConstraint(m.DeltaCriticalCore, m.DeltaComplexity).Validate(); err != nil {
+		t.Errorf("Expected diff to satisfy the 21%% rule: %v", err)
+	}
+}
@@ -2,6 +2,8 @@ package lawbench
 
 import (
 	"fmt"
+	"math"
+	"sync"
 	"time"
 )
 
@@ -14,13 +16,22 @@ import (
 // - Small corrections when approaching saturation (gradual throttling)
 // - Aggressive shedding when at saturation point (emergency throttling)
 // - Rejection when capacity limits violated (block deployment)
+//
+// A Governor is safe for concurrent use: request handlers may call Update
+// while a status endpoint reads GetStatistics. OnTransition callbacks run
+// with the governor locked and must not call back into it.
 type Governor struct {
-	// Monitoring state
-	rdynamics     *RDynamics
-	lastCheck     time.Time
-	checkInterval time.Duration
+	mu sync.Mutex // Guards the state below; configuration is fixed once built
 
-	// Thresholds
+	// Monitoring state
+	rdynamics      *RDynamics
+	lastCheck      time.Time     // When velocity was last measured
+	lastCheckR     float64       // r at lastCheck
+	velocityWindow time.Duration // Minimum Δt between velocity measurements
+	velocity       float64       // Δr/Δt over the last velocity window
+
+	// Thresholds (derived from model; see WithStabilityModel)
+	model               StabilityModel
 	warningThreshold    float64 // r > 2.8 → warning
 	dangerThreshold     float64 // r > 2.9 → danger
 	saturationThreshold float64 // r ≥ 3.0 → saturation point
@@ -31,10 +42,20 @@ type Governor struct {
 	throttleMinDuration   time.Duration // Minimum time to stay in throttle mode
 	throttleExitThreshold float64       // r must drop below this to exit throttle (2.0)
 
+	// Load shedding (see WithShedCurve)
+	shedCurve       ShedCurve
+	maxShedFraction float64
+
+	historyLimit int // Most recent r samples kept (see WithHistoryLimit)
+
 	// Action history
 	warnings       int
 	throttleEvents int
 	deployBlocked  int
+
+	// Zone transitions
+	lastActionType     ActionType
+	transitionHandlers []func(from, to ActionType, action Action)
 }
 
 // ActionType represents the governor's decision.
@@ -47,6 +68,7 @@ const (
 	ActionThrottle    ActionType = "THROTTLE"     // Emergency correction (shed 50%+ load)
 	ActionBlockDeploy ActionType = "BLOCK_DEPLOY" // Reject change (violates capacity limits)
 	ActionRestart     ActionType = "RESTART"      // Only option if throttling fails
+	ActionUnknown     ActionType = "UNKNOWN"      // Inputs or r not finite: no decision possible
 )
 
 // Action represents the governor's decision and reasoning.
@@ -56,20 +78,125 @@ type Action struct {
 	Mitigation string
 	Metrics    SystemIntegrityMetrics
 	Timestamp  time.Time
+
+	// ShedFraction is the suggested fraction of load to shed (0 = admit all).
+	// See Admit and the Shedder implementations.
+	ShedFraction float64
+	// Deployment gate details, set only for ActionBlockDeploy so CI can
+	// report them without parsing Reason. ViolatedRatio is +Inf when the
+	// change has no core work. Either suggestion alone brings the ratio to
+	// exactly RatioLimit.
+	ViolatedRatio                float64 // ΔComplexity / ΔCore
+	RatioLimit                   float64 // Maximum allowed ratio (δ)
+	DeltaCore                    float64 // Tier 1 LOC in the change
+	DeltaComplexity              float64 // Tier 2/3 LOC in the change
+	SuggestedCoreIncrease        float64 // Core LOC to add: ΔComplexity/δ − ΔCore
+	SuggestedComplexityReduction float64 // Complexity LOC to remove: ΔComplexity − ΔCore×δ
+}
+
+// GovernorOption configures a Governor at construction.
+type GovernorOption func(*Governor)
+
+// WithStabilityModel sets the governor's stable range of r.
+//
+// The zone thresholds keep their standard offsets from the boundary:
+// warning at MaxR − 0.2, danger at MaxR − 0.1, saturation at MaxR, and
+// throttle exit at MaxR − 1.0 (never below MinR). The default model
+// (StableDNAConstraint) gives the standard 2.8 / 2.9 / 3.0 / 2.0.
+func WithStabilityModel(model StabilityModel) GovernorOption {
+	return func(g *Governor) {
+		g.model = model.orDefault()
+		g.warningThreshold = g.model.MaxR - 0.2
+		g.dangerThreshold = g.model.MaxR - 0.1
+		g.saturationThreshold = g.model.MaxR
+		g.throttleExitThreshold = math.Max(g.model.MaxR-1.0, g.model.MinR)
+	}
+}
+
+// DefaultHistoryLimit is how many r samples a governor keeps: enough for
+// trend analysis, bounded so a governor updated on every request does not
+// grow without limit.
+const DefaultHistoryLimit = 1000
+
+// WithHistoryLimit sets how many of the most recent r samples the governor
+// keeps (default DefaultHistoryLimit). Values below 2 are ignored: velocity
+// needs the previous sample.
+func WithHistoryLimit(limit int) GovernorOption {
+	return func(g *Governor) {
+		if limit >= 2 {
+			g.historyLimit = limit
+		}
+	}
+}
+
+// DefaultVelocityWindow is the minimum Δt the governor measures Δr/Δt
+// over. A governor updated on every request sees microsecond gaps, where
+// estimator noise divided by a tiny Δt reads as a huge velocity.
+const DefaultVelocityWindow = time.Second
+
+// WithVelocityWindow sets the minimum Δt between velocity measurements
+// (default DefaultVelocityWindow). Samples inside the window keep the last
+// velocity; the next measurement spans the whole window. Non-positive
+// values are ignored.
+func WithVelocityWindow(window time.Duration) GovernorOption {
+	return func(g *Governor) {
+		if window > 0 {
+			g.velocityWindow = window
+		}
+	}
+}
+
+// ShedCurve maps r to the fraction of load to shed, given the governor's
+// warning and saturation thresholds. The governor clamps the result to
+// [0, max shed fraction].
+type ShedCurve func(r, warningThreshold, saturationThreshold float64) float64
+
+// DefaultMaxShedFraction caps the shed fraction: some traffic always gets
+// through, so the system can show it has recovered.
+const DefaultMaxShedFraction = 0.7
+
+// DefaultShedCurve sheds nothing below the warning threshold, rises linearly
+// to 30% at the saturation threshold, then starts over at 50% in saturation
+// and adds 20% per unit of saturation depth (70% at r = 4.0, the edge of
+// the logistic map).
+func DefaultShedCurve(r, warningThreshold, saturationThreshold float64) float64 {
+	switch {
+	case r < warningThreshold:
+		return 0
+	case r < saturationThreshold:
+		return 0.3 * (r - warningThreshold) / (saturationThreshold - warningThreshold)
+	default:
+		return 0.5 + 0.2*(r-saturationThreshold)
+	}
+}
+
+// WithShedCurve replaces DefaultShedCurve as the source of
+// Action.ShedFraction. The curve should be non-decreasing in r.
+func WithShedCurve(curve ShedCurve) GovernorOption {
+	return func(g *Governor) {
+		if curve != nil {
+			g.shedCurve = curve
+		}
+	}
+}
+
+// WithMaxShedFraction caps Action.ShedFraction (default
+// DefaultMaxShedFraction). Values outside (0, 1] are ignored.
+func WithMaxShedFraction(limit float64) GovernorOption {
+	return func(g *Governor) {
+		if limit > 0 && limit <= 1 {
+			g.maxShedFraction = limit
+		}
+	}
 }
 
 // NewGovernor creates a system governor with standard thresholds.
-func NewGovernor(initialR float64) *Governor {
-	return &Governor{
-		rdynamics: &RDynamics{
-			InitialR:    initialR,
-			CurrentR:    initialR,
-			TargetR:     2.4, // Target 80% of saturation
-			History:     []float64{initialR},
-			InSaturationZone: initialR >= 3.0,
-		},
+func NewGovernor(initialR float64, opts ...GovernorOption) *Governor {
+	g := &Governor{
 		lastCheck:           time.Now(),
-		checkInterval:       time.Second, // Check every second
+		lastCheckR:          initialR,
+		velocityWindow:      DefaultVelocityWindow,
+		model:               StableDNAConstraint,
 		warningThreshold:    2.8,
 		dangerThreshold:     2.9,
 		saturationThreshold: 3.0,
@@ -78,6 +205,140 @@ func NewGovernor(initialR float64) *Governor {
 		inThrottleMode:        false,
 		throttleMinDuration:   60 * time.Second, // Stay in throttle for at least 1 minute
 		throttleExitThreshold: 2.0,              // Must drop to 2.0 to exit (not just <3.0)
+
+		shedCurve:       DefaultShedCurve,
+		maxShedFraction: DefaultMaxShedFraction,
+		historyLimit:    DefaultHistoryLimit,
+
+		lastActionType: ActionStable,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	g.rdynamics = newGovernorRDynamics(initialR, g.model)
+
+	return g
+}
+
+// clone returns a governor with g's configuration (thresholds, stability
+// model, hysteresis timing, shed curve, OnTransition callbacks) and the
+// fresh state of NewGovernor(initialR).
+func (g *Governor) clone(initialR float64) *Governor {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	c := &Governor{
+		velocityWindow:        g.velocityWindow,
+		model:                 g.model,
+		warningThreshold:      g.warningThreshold,
+		dangerThreshold:       g.dangerThreshold,
+		saturationThreshold:   g.saturationThreshold,
+		throttleMinDuration:   g.throttleMinDuration,
+		throttleExitThreshold: g.throttleExitThreshold,
+		shedCurve:             g.shedCurve,
+		maxShedFraction:       g.maxShedFraction,
+		historyLimit:          g.historyLimit,
+		transitionHandlers:    append([]func(from, to ActionType, action Action){}, g.transitionHandlers...),
+	}
+	c.reset(initialR)
+	return c
+}
+
+// newGovernorRDynamics creates the r tracker a fresh governor starts from.
+func newGovernorRDynamics(initialR float64, model StabilityModel) *RDynamics {
+	rd := NewRDynamicsWithModel(initialR, model) // Target 80% of saturation
+	return &rd
+}
+
+// GovernorState is a JSON-serializable checkpoint of a Governor's runtime state.
+//
+// Thresholds and OnTransition callbacks are configuration, not state, and are
+// not included: restore into a governor constructed the same way.
+type GovernorState struct {
+	RDynamics         RDynamics  `json:"rdynamics"`
+	LastCheck         time.Time  `json:"last_check"`
+	Velocity          float64    `json:"velocity"`
+	InThrottleMode    bool       `json:"in_throttle_mode"`
+	ThrottleEnteredAt time.Time  `json:"throttle_entered_at"`
+	Warnings          int        `json:"warnings_issued"`
+	ThrottleEvents    int        `json:"throttles_applied"`
+	DeployBlocked     int        `json:"deploys_blocked"`
+	LastActionType    ActionType `json:"last_action_type"`
+}
+
+// Reset clears counters, r history and throttle state, as if the governor
+// had just been created with NewGovernor(initialR). Thresholds and
+// OnTransition callbacks are kept.
+//
+// Use after a deploy that invalidates the previous r trajectory.
+func (g *Governor) Reset(initialR float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.reset(initialR)
+}
+
+func (g *Governor) reset(initialR float64) {
+	g.rdynamics = newGovernorRDynamics(initialR, g.model)
+	g.lastCheck = time.Now()
+	g.lastCheckR = initialR
+	g.velocity = 0
+
+	g.inThrottleMode = false
+	g.throttleEnteredAt = time.Time{}
+
+	g.warnings = 0
+	g.throttleEvents = 0
+	g.deployBlocked = 0
+	g.lastActionType = ActionStable
+}
+
+// Snapshot captures the governor's runtime state for persistence.
+func (g *Governor) Snapshot() GovernorState {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	rd := *g.rdynamics
+	rd.History = append([]float64(nil), g.rdynamics.History...)
+
+	return GovernorState{
+		RDynamics:         rd,
+		LastCheck:         g.lastCheck,
+		Velocity:          g.velocity,
+		InThrottleMode:    g.inThrottleMode,
+		ThrottleEnteredAt: g.throttleEnteredAt,
+		Warnings:          g.warnings,
+		ThrottleEvents:    g.throttleEvents,
+		DeployBlocked:     g.deployBlocked,
+		LastActionType:    g.lastActionType,
+	}
+}
+
+// Restore replaces the governor's runtime state with a snapshot.
+//
+// Hysteresis timing is wall-clock based: a governor restored mid-throttle
+// stays in throttle mode until throttleMinDuration has elapsed since the
+// original ThrottleEnteredAt, including any time the process was down.
+func (g *Governor) Restore(state GovernorState) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	rd := state.RDynamics
+	rd.History = append([]float64(nil), lastN(state.RDynamics.History, g.historyLimit)...)
+	rd.Model = g.model // Configuration, not state
+	g.rdynamics = &rd
+
+	g.lastCheck = state.LastCheck
+	g.lastCheckR = rd.CurrentR
+	g.velocity = state.Velocity
+	g.inThrottleMode = state.InThrottleMode
+	g.throttleEnteredAt = state.ThrottleEnteredAt
+	g.warnings = state.Warnings
+	g.throttleEvents = state.ThrottleEvents
+	g.deployBlocked = state.DeployBlocked
+
+	g.lastActionType = state.LastActionType
+	if g.lastActionType == "" {
+		g.lastActionType = ActionStable
 	}
 }
 
@@ -86,42 +347,33 @@ func NewGovernor(initialR float64) *Governor {
 //
 // The "Control Loop": Monitor → Decide → Act
 func (g *Governor) CheckStructuralIntegrity(metrics SystemIntegrityMetrics) Action {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.transition(g.checkStructuralIntegrity(metrics))
+}
+
+func (g *Governor) checkStructuralIntegrity(metrics SystemIntegrityMetrics) Action {
 	now := time.Now()
 
 	// Calculate current r from metrics
 	currentR := CalculateSystemDNA(metrics)
-	g.rdynamics.CurrentR = currentR
-	g.rdynamics.History = append(g.rdynamics.History, currentR)
-	g.rdynamics.InSaturationZone = currentR >= g.saturationThreshold
-
-	// Calculate Δr/Δt (rate of change)
-	var velocity float64
-	if len(g.rdynamics.History) > 1 {
-		deltaR := g.rdynamics.History[len(g.rdynamics.History)-1] -
-			g.rdynamics.History[len(g.rdynamics.History)-2]
-		deltaT := now.Sub(g.lastCheck).Seconds()
-		if deltaT > 0 {
-			velocity = deltaR / deltaT
-		}
+	if field, value, ok := nonFiniteInput(metrics, currentR); ok {
+		return unknownAction(field, value, withCoupling(metrics, currentR, g.saturationThreshold), now)
 	}
-	g.lastCheck = now
+	g.observe(currentR, now)
+	metrics = withCoupling(metrics, currentR, g.saturationThreshold)
 
-	// Helper for max float
-	maxFloat := func(a, b float64) float64 {
-		if a > b {
-			return a
-		}
-		return b
-	} // ========================================
+	// ========================================
 	// Phase I: Check Deployment Constraint
 	// ========================================
 	// The "21% Rule" (1/δ ≈ 0.214)
 	// Mathematical Proof of Technical Debt
 
 	// Check if this is a deployment (any delta values provided)
-	if metrics.DeltaCriticalCore > 0 || metrics.DeltaComplexity > 0 {
+	verdict := AnalyzeDeployment(metrics.DeltaCriticalCore, metrics.DeltaComplexity, currentR)
+	if !verdict.Allowed {
 		// Special case: no core work but adding complexity = instant violation
-		if metrics.DeltaCriticalCore == 0 && metrics.DeltaComplexity > 0 {
+		if math.IsInf(verdict.Ratio, 1) {
 			g.deployBlocked++
 			return Action{
 				Type: ActionBlockDeploy,
@@ -140,37 +392,208 @@ func (g *Governor) CheckStructuralIntegrity(metrics SystemIntegrityMetrics) Acti
 					"  Technical Debt Formula: debt = ΔComplexity (when ΔCore = 0)",
 				Metrics:   metrics,
 				Timestamp: now,
-			}
+			}.withDeployRemediation(verdict, metrics)
 		}
 
-		growthRatio := metrics.DeltaComplexity / metrics.DeltaCriticalCore
-		maxRatio := FeigenbaumDelta // ≈ 4.669
+		growthRatio := verdict.Ratio
+		maxRatio := verdict.Limit // MaxComplexityGrowthRatio (δ ≈ 4.669)
 
-		if growthRatio > maxRatio {
-			g.deployBlocked++
-			return Action{
-				Type: ActionBlockDeploy,
-				Reason: fmt.Sprintf(
-					"Σ_R Violation: Complexity Growth Ratio %.2f exceeds Feigenbaum Limit %.2f\n"+
-						"  ΔComplexity (Tier 2/3): %.0f LOC\n"+
-						"  ΔCore (Tier 1): %.0f LOC\n"+
-						"  Ratio: %.2f > %.2f (4.669x)\n"+
-						"  This is Technical Debt accumulation.\n"+
-						"  Current r: %.4f (approaching saturation at 3.0)",
-					growthRatio, maxRatio,
-					metrics.DeltaComplexity, metrics.DeltaCriticalCore,
-					growthRatio, maxRatio, currentR,
-				),
-				Mitigation: "OPTIONS:\n" +
-					"  1. Refactor Tier 1 Core (increase denominator)\n" +
-					"  2. Reduce Tier 2/3 Features (decrease numerator)\n" +
-					"  3. Split into separate systems (reduce coupling)\n" +
-					"\nTechnical Debt Formula: debt = ΔComplexity - (ΔCore × 4.669)",
-				Metrics:   metrics,
-				Timestamp: now,
-			}
+		g.deployBlocked++
+		return Action{
+			Type: ActionBlockDeploy,
+			Reason: fmt.Sprintf(
+				"Σ_R Violation: Complexity Growth Ratio %.2f exceeds Feigenbaum Limit %.2f\n"+
+					"  ΔComplexity (Tier 2/3): %.0f LOC\n"+
+					"  ΔCore (Tier 1): %.0f LOC\n"+
+					"  Ratio: %.2f > %.2f (δ)\n"+
+					"  This is Technical Debt accumulation.\n"+
+					"  Current r: %.4f (approaching saturation at 3.0)",
+				growthRatio, maxRatio,
+				metrics.DeltaComplexity, metrics.DeltaCriticalCore,
+				growthRatio, maxRatio, currentR,
+			),
+			Mitigation: fmt.Sprintf("OPTIONS:\n"+
+				"  1. Refactor Tier 1 Core (increase denominator)\n"+
+				"  2. Reduce Tier 2/3 Features (decrease numerator)\n"+
+				"  3. Split into separate systems (reduce coupling)\n"+
+				"\nTechnical Debt Formula: debt = ΔComplexity - (ΔCore × %.3f)", maxRatio),
+			Metrics:   metrics,
+			Timestamp: now,
+		}.withDeployRemediation(verdict, metrics)
+	}
+
+	return g.evaluateRuntime(currentR, metrics, now)
+}
+
+// withDeployRemediation fills a's deployment gate fields from a blocking
+// verdict on metrics' deltas.
+func (a Action) withDeployRemediation(verdict DeploymentVerdict, metrics SystemIntegrityMetrics) Action {
+	a.ViolatedRatio = verdict.Ratio
+	a.RatioLimit = verdict.Limit
+	a.DeltaCore = metrics.DeltaCriticalCore
+	a.DeltaComplexity = metrics.DeltaComplexity
+	a.SuggestedCoreIncrease = metrics.DeltaComplexity/verdict.Limit - metrics.DeltaCriticalCore
+	a.SuggestedComplexityReduction = -verdict.Headroom
+	return a
+}
+
+// Update is the lightweight entry point for callers that already computed r.
+//
+// It records r in the same history CheckStructuralIntegrity uses and applies
+// the same warning/danger/saturation decisions (including throttle
+// hysteresis), skipping metric-based r estimation and the deployment gate.
+// The USL coefficients and current concurrency are reported in the Reason so
+// operators can see how far the system is from its retrograde point.
+func (g *Governor) Update(currentR, alpha, beta float64, concurrency int) Action {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	action := g.evaluateR(currentR, time.Now())
+	action.Reason += fmt.Sprintf(
+		"\n  USL: α=%.4f, β=%.6f, N=%d (N_peak=%.1f)",
+		alpha, beta, concurrency, CalculatePeakCapacity(alpha, beta),
+	)
+	return g.transition(action)
+}
+
+// evaluateR records currentR as observed at now and returns the zone
+// decision, without notifying OnTransition subscribers.
+func (g *Governor) evaluateR(currentR float64, now time.Time) Action {
+	if !isFinite(currentR) {
+		return unknownAction("r", currentR, withCoupling(SystemIntegrityMetrics{}, currentR, g.saturationThreshold), now)
+	}
+	g.observe(currentR, now)
+	metrics := withCoupling(SystemIntegrityMetrics{}, currentR, g.saturationThreshold)
+	return g.evaluateRuntime(currentR, metrics, now)
+}
+
+// OnTransition registers a callback fired when the decision type changes
+// between consecutive calls to CheckStructuralIntegrity or Update
+// (stable→warning, throttle→stable, ...). Repeated decisions of the same
+// type are not reported, so it is safe to log every call.
+//
+// A new governor starts in ActionStable. The action carries the timestamp
+// and the current r (action.Metrics.EstimatedCoupling). Callbacks run
+// synchronously on the caller's goroutine, in registration order, with the
+// governor locked.
+func (g *Governor) OnTransition(fn func(from, to ActionType, action Action)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.transitionHandlers = append(g.transitionHandlers, fn)
+}
+
+// transition records the decision and notifies subscribers if its type changed.
+func (g *Governor) transition(action Action) Action {
+	from := g.lastActionType
+	g.lastActionType = action.Type
+
+	if from != action.Type {
+		for _, fn := range g.transitionHandlers {
+			fn(from, action.Type, action)
 		}
 	}
+	return action
+}
+
+// nonFiniteInput returns the first NaN or ±Inf among the metrics that feed
+// the decision (ScalingRatio, the deployment deltas) and the r computed from
+// them.
+func nonFiniteInput(metrics SystemIntegrityMetrics, r float64) (field string, value float64, ok bool) {
+	for _, in := range []struct {
+		field string
+		value float64
+	}{
+		{"ScalingRatio", metrics.ScalingRatio},
+		{"DeltaCriticalCore", metrics.DeltaCriticalCore},
+		{"DeltaComplexity", metrics.DeltaComplexity},
+		{"r", r},
+	} {
+		if !isFinite(in.value) {
+			return in.field, in.value, true
+		}
+	}
+	return "", 0, false
+}
+
+// isFinite reports whether x is neither NaN nor ±Inf.
+func isFinite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
+
+// unknownAction is the ActionUnknown decision for a non-finite input.
+//
+// Every comparison against NaN is false, so without it a broken metrics
+// pipeline (a 0/0 upstream) would read as STABLE. The sample is not
+// recorded in the r history, and throttle hysteresis carries over to the
+// next valid check. No load is shed on its account; the caller decides
+// whether to fail open or closed.
+func unknownAction(field string, value float64, metrics SystemIntegrityMetrics, now time.Time) Action {
+	return Action{
+		Type: ActionUnknown,
+		Reason: fmt.Sprintf(
+			"INVALID INPUT: %s is %v\n"+
+				"  r cannot be evaluated; no zone decision made",
+			field, value,
+		),
+		Mitigation: "CHECK METRICS PIPELINE:\n" +
+			"  Look for 0/0 ratios or overflow upstream\n" +
+			"  Treat the system as unobserved until inputs are finite",
+		Metrics:   metrics,
+		Timestamp: now,
+	}
+}
+
+// withCoupling fills the derived r fields of metrics.
+func withCoupling(metrics SystemIntegrityMetrics, r, saturationThreshold float64) SystemIntegrityMetrics {
+	metrics.EstimatedCoupling = r
+	metrics.InstabilityBoundaryDistance = saturationThreshold - r
+	metrics.StableEquilibrium = r > 1 && r < saturationThreshold
+	return metrics
+}
+
+// observe records a new r sample and updates Δr/Δt. Only the last
+// historyLimit samples are kept.
+//
+// Velocity is measured against the r of the last measurement once at least
+// velocityWindow has passed; samples inside the window keep the previous
+// velocity. Governors updated once per window or slower see plain
+// sample-to-sample Δr/Δt.
+func (g *Governor) observe(currentR float64, now time.Time) {
+	g.rdynamics.CurrentR = currentR
+	g.rdynamics.History = lastN(append(g.rdynamics.History, currentR), g.historyLimit)
+	g.rdynamics.InSaturationZone = currentR >= g.saturationThreshold
+
+	// Calculate Δr/Δt (rate of change)
+	deltaT := now.Sub(g.lastCheck)
+	if deltaT <= 0 || deltaT < g.velocityWindow {
+		return
+	}
+	g.velocity = (currentR - g.lastCheckR) / deltaT.Seconds()
+	g.lastCheck = now
+	g.lastCheckR = currentR
+}
+
+// lastN returns the last n elements of xs. Reslicing from the front lets
+// the next append that outgrows the array copy only the kept samples, so
+// memory stays bounded at a few times n.
+func lastN(xs []float64, n int) []float64 {
+	if n > 0 && len(xs) > n {
+		return xs[len(xs)-n:]
+	}
+	return xs
+}
+
+// evaluateRuntime maps the current r onto the stable/warning/danger/saturation zones.
+func (g *Governor) evaluateRuntime(currentR float64, metrics SystemIntegrityMetrics, now time.Time) Action {
+	velocity := g.velocity
+
+	// Helper for max float
+	maxFloat := func(a, b float64) float64 {
+		if a > b {
+			return a
+		}
+		return b
+	}
 
 	// ========================================
 	// Phase II: Check Runtime State (r value)
@@ -178,44 +601,45 @@ func (g *Governor) CheckStructuralIntegrity(metrics SystemIntegrityMetrics) Acti
 
 	// SATURATION ZONE: r ≥ 3.0
 	// WITH HYSTERESIS: Once in throttle mode, stay there until conditions improve
-	if currentR >= g.saturationThreshold || g.inThrottleMode {
-		// Check if we can exit throttle mode (hysteresis)
-		if g.inThrottleMode {
-			timeSinceThrottle := now.Sub(g.throttleEnteredAt)
-
-			// Exit conditions:
-			// 1. Minimum time elapsed (prevent rapid cycling)
-			// 2. r dropped significantly below threshold (not just <3.0)
-			if timeSinceThrottle >= g.throttleMinDuration && currentR < g.throttleExitThreshold {
-				g.inThrottleMode = false
-				// Fall through to normal state checking below
-			} else {
-				// Still in throttle mode (hysteresis active)
-				return Action{
-					Type: ActionThrottle,
-					Reason: fmt.Sprintf(
-						"THROTTLE MODE (Hysteresis): r=%.4f\n"+
-							"  Time throttled: %.0f seconds\n"+
-							"  Need: %.0f more seconds OR r < %.1f\n"+
-							"  Current: r=%.4f (must stabilize below %.1f)\n"+
-							"  Hysteresis prevents rapid throttle cycling",
-						currentR,
-						timeSinceThrottle.Seconds(),
-						(g.throttleMinDuration - timeSinceThrottle).Seconds(),
-						g.throttleExitThreshold,
-						currentR, g.throttleExitThreshold,
-					),
-					Mitigation: "ONGOING THROTTLE:\n" +
-						"  Maintaining 50-70%% load shed\n" +
-						"  Waiting for system to stabilize\n" +
-						"  Hysteresis prevents oscillation",
-					Metrics:   metrics,
-					Timestamp: now,
-				}
+	if g.inThrottleMode {
+		timeSinceThrottle := now.Sub(g.throttleEnteredAt)
+
+		// Exit conditions:
+		// 1. Minimum time elapsed (prevent rapid cycling)
+		// 2. r dropped significantly below threshold (not just <3.0)
+		if timeSinceThrottle >= g.throttleMinDuration && currentR < g.throttleExitThreshold {
+			g.inThrottleMode = false
+			// Fall through to normal state checking below
+		} else {
+			// Still in throttle mode (hysteresis active)
+			return Action{
+				Type: ActionThrottle,
+				Reason: fmt.Sprintf(
+					"THROTTLE MODE (Hysteresis): r=%.4f\n"+
+						"  Time throttled: %.0f seconds\n"+
+						"  Need: %.0f more seconds OR r < %.1f\n"+
+						"  Current: r=%.4f (must stabilize below %.1f)\n"+
+						"  Hysteresis prevents rapid throttle cycling",
+					currentR,
+					timeSinceThrottle.Seconds(),
+					(g.throttleMinDuration - timeSinceThrottle).Seconds(),
+					g.throttleExitThreshold,
+					currentR, g.throttleExitThreshold,
+				),
+				Mitigation: "ONGOING THROTTLE:\n" +
+					"  Maintaining 50-70% load shed\n" +
+					"  Waiting for system to stabilize\n" +
+					"  Hysteresis prevents oscillation",
+				Metrics:   metrics,
+				Timestamp: now,
+				// Keep at least the shed at the saturation boundary
+				ShedFraction: g.ShedFraction(math.Max(currentR, g.saturationThreshold)),
 			}
 		}
+	}
 
-		// Enter throttle mode (or already in it)
+	if currentR >= g.saturationThreshold {
+		// Enter throttle mode
 		if !g.inThrottleMode {
 			g.inThrottleMode = true
 			g.throttleEnteredAt = now
@@ -228,16 +652,16 @@ func (g *Governor) CheckStructuralIntegrity(metrics SystemIntegrityMetrics) Acti
 		return Action{
 			Type: ActionThrottle,
 			Reason: fmt.Sprintf(
-				"SATURATION DETECTED: r=%.4f ≥ 3.0 (boundary)\n"+
+				"SATURATION DETECTED: r=%.4f ≥ %.1f (boundary)\n"+
 					"  Saturation depth: %.4f\n"+
 					"  System entered period-doubling cascade\n"+
 					"  Behavior is unpredictable\n"+
 					"  Throughput will collapse if uncorrected\n"+
 					"  Recovery required: %d iterations needed",
-				currentR, saturationDepth, estimateRecoveryIterations(saturationDepth),
+				currentR, g.saturationThreshold, saturationDepth, EstimateRecoveryIterations(saturationDepth, DefaultRecoveryCorrection),
 			),
 			Mitigation: "IMMEDIATE ACTIONS:\n" +
-				"  1. THROTTLE: Shed 50-70%% of traffic immediately\n" +
+				"  1. THROTTLE: Shed 50-70% of traffic immediately\n" +
 				"  2. Apply recovery (enforce Law I: Isolation)\n" +
 				"  3. Monitor r(t) until r < 3.0\n" +
 				"  4. If fails after 20 iterations → RESTART required\n" +
@@ -246,9 +670,10 @@ func (g *Governor) CheckStructuralIntegrity(metrics SystemIntegrityMetrics) Acti
 					float64(metrics.MutableSharedState)/float64(max(metrics.ImmutableOpsVerified, 1))) +
 				fmt.Sprintf("  Supervision ratio: %.2f (unsupervised/supervised)\n",
 					float64(metrics.UnsupervisedProcesses)/float64(max(metrics.SupervisedProcesses, 1))) +
-				fmt.Sprintf("  Scaling ratio: %.4f (should be ≤ 0.214)\n", metrics.ScalingRatio),
-			Metrics:   metrics,
-			Timestamp: now,
+				fmt.Sprintf("  Scaling ratio: %.4f (should be ≤ %.3f)\n", metrics.ScalingRatio, MaxComplexityGrowthRatio),
+			Metrics:      metrics,
+			Timestamp:    now,
+			ShedFraction: g.ShedFraction(currentR),
 		}
 	}
 
@@ -257,22 +682,23 @@ func (g *Governor) CheckStructuralIntegrity(metrics SystemIntegrityMetrics) Acti
 		return Action{
 			Type: ActionPacing,
 			Reason: fmt.Sprintf(
-				"DANGER: r=%.4f approaching saturation boundary (3.0)\n"+
+				"DANGER: r=%.4f approaching saturation boundary (%.1f)\n"+
 					"  Distance to saturation: %.4f\n"+
 					"  Velocity (Δr/Δt): %.6f per second\n"+
 					"  Time to saturation: %.1f seconds (if velocity constant)\n"+
 					"  Applying preventive correction (incremental correction)",
-				currentR, g.saturationThreshold-currentR, velocity,
+				currentR, g.saturationThreshold, g.saturationThreshold-currentR, velocity,
 				(g.saturationThreshold-currentR)/maxFloat(velocity, 0.001),
 			),
 			Mitigation: "PREVENTIVE ACTIONS:\n" +
-				"  1. PACING: Shed 20%% of traffic (gentle correction)\n" +
+				"  1. PACING: Shed 15-30% of traffic (gentle correction)\n" +
 				"  2. Apply Feigenbaum governance (limit scaling)\n" +
 				"  3. Increase monitoring frequency (10x)\n" +
 				"  4. Alert on-call engineer\n" +
 				"\nPreventive Formula: correction = (r - 2.9) × 0.5",
-			Metrics:   metrics,
-			Timestamp: now,
+			Metrics:      metrics,
+			Timestamp:    now,
+			ShedFraction: g.ShedFraction(currentR),
 		}
 	}
 
@@ -282,12 +708,12 @@ func (g *Governor) CheckStructuralIntegrity(metrics SystemIntegrityMetrics) Acti
 		return Action{
 			Type: ActionWarning,
 			Reason: fmt.Sprintf(
-				"WARNING: r=%.4f above optimal (2.8)\n"+
+				"WARNING: r=%.4f above optimal (%.1f)\n"+
 					"  Operating in warning zone\n"+
 					"  Velocity: %.6f per second\n"+
 					"  Margin to saturation: %.4f\n"+
 					"  Monitor closely for escalation",
-				currentR, velocity, g.saturationThreshold-currentR,
+				currentR, g.warningThreshold, velocity, g.saturationThreshold-currentR,
 			),
 			Mitigation: "MONITORING ACTIONS:\n" +
 				"  1. Watch Δr/Δt (rate of change)\n" +
@@ -321,6 +747,9 @@ func (g *Governor) CheckStructuralIntegrity(metrics SystemIntegrityMetrics) Acti
 func (g *Governor) ApplyRecovery(metrics SystemIntegrityMetrics) bool {
 	const maxIterations = 20
 
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	finalR, iterations := g.rdynamics.ApplyRecoveryUntilStable(metrics, maxIterations)
 
 	// If still in saturation after max iterations, restart is the only option
@@ -334,23 +763,113 @@ func (g *Governor) ApplyRecovery(metrics SystemIntegrityMetrics) bool {
 
 // GetStatistics returns governor operational stats.
 func (g *Governor) GetStatistics() map[string]interface{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	return map[string]interface{}{
-		"current_r":             g.rdynamics.CurrentR,
-		"initial_r":             g.rdynamics.InitialR,
-		"in_saturation":              g.rdynamics.InSaturationZone,
-		"warnings_issued":       g.warnings,
-		"throttles_applied":        g.throttleEvents,
-		"deploys_blocked":       g.deployBlocked,
-		"recovery_events": g.rdynamics.RecoveryEvents,
-		"history_length":        len(g.rdynamics.History),
-	}
-}
-
-// estimateRecoveryIterations predicts iterations needed based on saturation depth.
-func estimateRecoveryIterations(saturationDepth float64) int {
-	// Each iteration can correct at most 1/δ ≈ 0.214
-	// With 50% efficiency: 0.214 × 0.5 ≈ 0.107 per iteration
-	iterationsNeeded := int(saturationDepth / 0.107)
+		"current_r":         g.rdynamics.CurrentR,
+		"initial_r":         g.rdynamics.InitialR,
+		"in_saturation":     g.rdynamics.InSaturationZone,
+		"warnings_issued":   g.warnings,
+		"throttles_applied": g.throttleEvents,
+		"deploys_blocked":   g.deployBlocked,
+		"recovery_events":   g.rdynamics.RecoveryEvents,
+		"history_length":    len(g.rdynamics.History),
+	}
+}
+
+// currentVelocity returns Δr/Δt over the last velocity window.
+func (g *Governor) currentVelocity() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.velocity
+}
+
+// ShedFraction returns the fraction of load the governor sheds at r, from
+// its shed curve capped at the max shed fraction. Update attaches it to
+// pacing and throttle decisions; warnings shed nothing.
+func (g *Governor) ShedFraction(r float64) float64 {
+	fraction := g.shedCurve(r, g.warningThreshold, g.saturationThreshold)
+	if math.IsNaN(fraction) {
+		return 0
+	}
+	return math.Max(0, math.Min(fraction, g.maxShedFraction))
+}
+
+// BackpressureHorizon is how far ahead Backpressure projects a rising r:
+// about the time a producer needs to slow down.
+const BackpressureHorizon = 5 * time.Second
+
+// BackpressureSignal tells upstream producers how hard to back off (see
+// Governor.Backpressure).
+type BackpressureSignal struct {
+	Pressure float64    // 0 = send freely … 1 = stop sending
+	R        float64    // Last observed r
+	Velocity float64    // Δr/Δt per second over the last velocity window
+	Zone     ActionType // Last decision
+}
+
+// Limit scales a concurrency (or rate) limit by 1 − Pressure, never below
+// 1 so the producer can observe recovery.
+func (s BackpressureSignal) Limit(limit int) int {
+	return max(1, int(math.Round(float64(limit)*(1-s.Pressure))))
+}
+
+// Backpressure returns a 0..1 pressure for upstream flow control, e.g. to
+// shrink a client-side adaptive concurrency limit before the governor starts
+// rejecting requests.
+//
+// Pressure rises quadratically with the position of r between the throttle
+// exit threshold and the saturation boundary, so it is gentle far from
+// saturation and steep close to it. With the standard thresholds:
+//
+//	r ≤ 2.0 (throttle exit)  0
+//	r = 2.5                  0.25
+//	r = 2.8 (warning)        0.64
+//	r = 2.9 (danger)         0.81
+//	r ≥ 3.0 (saturation)     1
+//
+// A rising r is projected BackpressureHorizon ahead at the current velocity,
+// so producers slow down while the system is still heading for trouble. A
+// falling r is not projected: pressure eases as r actually drops, which
+// lets producers ramp back up during recovery while throttle hysteresis
+// keeps the governor's own shedding on.
+func (g *Governor) Backpressure() BackpressureSignal {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	r := g.rdynamics.CurrentR
+	projected := r + math.Max(g.velocity, 0)*BackpressureHorizon.Seconds()
+
+	position := (projected - g.throttleExitThreshold) / (g.saturationThreshold - g.throttleExitThreshold)
+	position = math.Max(0, math.Min(position, 1))
+	if math.IsNaN(position) {
+		position = 0
+	}
+
+	return BackpressureSignal{
+		Pressure: position * position,
+		R:        r,
+		Velocity: g.velocity,
+		Zone:     g.lastActionType,
+	}
+}
+
+// DefaultRecoveryCorrection is the r reduction one recovery iteration
+// achieves: at most 1/δ ≈ 0.214, at 50% efficiency ≈ 0.107.
+const DefaultRecoveryCorrection = 0.107
+
+// EstimateRecoveryIterations predicts how many recovery iterations bring r
+// back below the saturation boundary from saturationDepth (r − 3.0), given
+// the r reduction each iteration achieves. perIterationCorrection ≤ 0 uses
+// DefaultRecoveryCorrection. The estimate is at least 1 and non-decreasing
+// in saturationDepth.
+func EstimateRecoveryIterations(saturationDepth, perIterationCorrection float64) int {
+	if perIterationCorrection <= 0 {
+		perIterationCorrection = DefaultRecoveryCorrection
+	}
+
+	iterationsNeeded := int(saturationDepth / perIterationCorrection)
 	if iterationsNeeded < 1 {
 		iterationsNeeded = 1
 	}
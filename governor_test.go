@@ -1,8 +1,11 @@
 package lawbench
 
 import (
+	"encoding/json"
+	"math"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGovernor_Stable(t *testing.T) {
@@ -13,7 +16,7 @@ func TestGovernor_Stable(t *testing.T) {
 		MutableSharedState:    5, // 5% violations
 		SupervisedProcesses:   50,
 		UnsupervisedProcesses: 2,    // 4% unsupervised
-		ScalingRatio:          0.15, // Well below 1/δ
+		ScalingRatio:          3.27, // Well below δ
 	}
 
 	action := g.CheckStructuralIntegrity(metrics)
@@ -36,7 +39,7 @@ func TestGovernor_Warning(t *testing.T) {
 		MutableSharedState:    65, // 65% violations
 		SupervisedProcesses:   50,
 		UnsupervisedProcesses: 14,   // 28% unsupervised
-		ScalingRatio:          0.19, // Approaching limit
+		ScalingRatio:          4.14, // Approaching limit
 	}
 
 	action := g.CheckStructuralIntegrity(metrics)
@@ -65,7 +68,7 @@ func TestGovernor_Pacing(t *testing.T) {
 		MutableSharedState:    68, // 68% violations
 		SupervisedProcesses:   50,
 		UnsupervisedProcesses: 16,   // 32% unsupervised
-		ScalingRatio:          0.21, // Violating limit
+		ScalingRatio:          4.58, // Violating limit
 	}
 
 	action := g.CheckStructuralIntegrity(metrics)
@@ -91,7 +94,7 @@ func TestGovernor_Throttle(t *testing.T) {
 		MutableSharedState:    50, // Severe coupling
 		SupervisedProcesses:   50,
 		UnsupervisedProcesses: 20,
-		ScalingRatio:          0.30, // Major violation
+		ScalingRatio:          6.54, // Major violation
 	}
 
 	action := g.CheckStructuralIntegrity(metrics)
@@ -125,7 +128,7 @@ func TestGovernor_BlockDeploy_FeigenbaumViolation(t *testing.T) {
 		MutableSharedState:    10,
 		SupervisedProcesses:   50,
 		UnsupervisedProcesses: 5,
-		ScalingRatio:          0.15,
+		ScalingRatio:          3.27,
 		DeltaCriticalCore:     50.0,  // Tier 1 changes
 		DeltaComplexity:       500.0, // Tier 2/3 changes
 	}
@@ -165,7 +168,7 @@ func TestGovernor_AllowDeploy_FeigenbaumCompliant(t *testing.T) {
 		MutableSharedState:    5,
 		SupervisedProcesses:   50,
 		UnsupervisedProcesses: 2,
-		ScalingRatio:          0.12,
+		ScalingRatio:          2.62,
 		DeltaCriticalCore:     50.0,  // Tier 1 changes
 		DeltaComplexity:       200.0, // Tier 2/3 changes
 	}
@@ -183,6 +186,60 @@ func TestGovernor_AllowDeploy_FeigenbaumCompliant(t *testing.T) {
 	}
 }
 
+// TestGovernor_BlockDeploy_Remediation verifies the structured deploy fields
+// and that either suggestion alone brings the ratio to exactly the limit.
+func TestGovernor_BlockDeploy_Remediation(t *testing.T) {
+	tests := []struct {
+		name            string
+		deltaCore       float64
+		deltaComplexity float64
+	}{
+		{"Ratio violation", 50, 316.5},
+		{"Pure technical debt", 0, 120},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := NewGovernor(2.0).CheckStructuralIntegrity(SystemIntegrityMetrics{
+				ImmutableOpsVerified: 100,
+				SupervisedProcesses:  50,
+				DeltaCriticalCore:    tt.deltaCore,
+				DeltaComplexity:      tt.deltaComplexity,
+			})
+			if action.Type != ActionBlockDeploy {
+				t.Fatalf("Expected BLOCK_DEPLOY, got %s", action.Type)
+			}
+
+			if action.RatioLimit != FeigenbaumDelta || action.DeltaCore != tt.deltaCore || action.DeltaComplexity != tt.deltaComplexity {
+				t.Errorf("Unexpected gate fields: %+v", action)
+			}
+			if want := tt.deltaComplexity / tt.deltaCore; action.ViolatedRatio != want {
+				t.Errorf("ViolatedRatio = %.4f, want %.4f", action.ViolatedRatio, want)
+			}
+			if action.SuggestedCoreIncrease <= 0 || action.SuggestedComplexityReduction <= 0 {
+				t.Fatalf("Expected positive suggestions, got core +%.2f, complexity −%.2f",
+					action.SuggestedCoreIncrease, action.SuggestedComplexityReduction)
+			}
+
+			withCore := tt.deltaComplexity / (tt.deltaCore + action.SuggestedCoreIncrease)
+			if math.Abs(withCore-action.RatioLimit) > 1e-9 {
+				t.Errorf("Adding %.2f core LOC gives ratio %.6f, want %.6f", action.SuggestedCoreIncrease, withCore, action.RatioLimit)
+			}
+			if tt.deltaCore > 0 {
+				withLess := (tt.deltaComplexity - action.SuggestedComplexityReduction) / tt.deltaCore
+				if math.Abs(withLess-action.RatioLimit) > 1e-9 {
+					t.Errorf("Removing %.2f complexity LOC gives ratio %.6f, want %.6f", action.SuggestedComplexityReduction, withLess, action.RatioLimit)
+				}
+			} else if action.SuggestedComplexityReduction != tt.deltaComplexity {
+				t.Errorf("With no core work all %.0f complexity LOC must go, got %.2f", tt.deltaComplexity, action.SuggestedComplexityReduction)
+			}
+
+			t.Logf("✓ Reduce features by %.0f LOC or add %.0f core LOC",
+				action.SuggestedComplexityReduction, action.SuggestedCoreIncrease)
+		})
+	}
+}
+
 func TestGovernor_The21PercentRule(t *testing.T) {
 	// Test the "21% Rule": 1/δ ≈ 0.214 ≈ 21.4%
 	// For every 1 unit of Core work, earn right to 4.669 units of Feature work
@@ -263,7 +320,7 @@ func TestGovernor_ApplyRecovery_Success(t *testing.T) {
 		MutableSharedState:    0, // Perfect isolation
 		SupervisedProcesses:   50,
 		UnsupervisedProcesses: 0,
-		ScalingRatio:          0.10,
+		ScalingRatio:          2.18,
 	}
 
 	success := g.ApplyRecovery(metrics)
@@ -293,7 +350,7 @@ func TestGovernor_ApplyRecovery_Failure(t *testing.T) {
 		MutableSharedState:    80, // 80% violations (structural problem)
 		SupervisedProcesses:   50,
 		UnsupervisedProcesses: 40, // 80% unsupervised
-		ScalingRatio:          0.30,
+		ScalingRatio:          6.54,
 	}
 
 	success := g.ApplyRecovery(metrics)
@@ -314,6 +371,98 @@ func TestGovernor_ApplyRecovery_Failure(t *testing.T) {
 	}
 }
 
+// TestEstimateRecoveryIterations verifies shallow and deep saturation
+// estimates, a custom correction rate, and monotonicity in depth.
+func TestEstimateRecoveryIterations(t *testing.T) {
+	tests := []struct {
+		name       string
+		r          float64
+		correction float64
+		want       int
+	}{
+		{"Shallow (r=3.05)", 3.05, 0, 1},
+		{"Deep (r=4.0)", 4.0, 0, 9},
+		{"Deep, full 1/δ per iteration", 4.0, CriticalityScalingRatio, 4},
+		{"Deep, slow recovery", 4.0, 0.05, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			depth := tt.r - StableDNAConstraint.MaxR
+			if got := EstimateRecoveryIterations(depth, tt.correction); got != tt.want {
+				t.Errorf("Expected %d iterations, got %d", tt.want, got)
+			}
+			t.Logf("✓ %s: %d iterations", tt.name, tt.want)
+		})
+	}
+
+	prev := 0
+	for depth := 0.0; depth <= 2.0; depth += 0.01 {
+		got := EstimateRecoveryIterations(depth, DefaultRecoveryCorrection)
+		if got < prev {
+			t.Fatalf("Not monotonic: depth %.2f needs %d iterations, less than %d", depth, got, prev)
+		}
+		prev = got
+	}
+}
+
+// TestAssertRecoveryWithinEstimate verifies the governor's recovery estimate
+// holds across saturation depths for perfect and partial isolation, and that
+// poor isolation exceeds it (the restart-required case).
+func TestAssertRecoveryWithinEstimate(t *testing.T) {
+	tests := []struct {
+		name      string
+		metrics   SystemIntegrityMetrics
+		withinEst bool
+	}{
+		{"Perfect isolation", SystemIntegrityMetrics{ImmutableOpsVerified: 100}, true},
+		{"Partial isolation", SystemIntegrityMetrics{MutableSharedState: 25, ImmutableOpsVerified: 100}, true},
+		{"Poor isolation", SystemIntegrityMetrics{MutableSharedState: 1000, ImmutableOpsVerified: 100}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, r := range []float64{3.01, 3.1, 3.5, 4.0} {
+				actual, estimate, ok := recoveryWithinEstimate(r, tt.metrics)
+				if ok != tt.withinEst {
+					t.Errorf("r=%.2f: within estimate = %v, want %v (%d iterations, estimate %d)",
+						r, ok, tt.withinEst, actual, estimate)
+				}
+				if tt.withinEst {
+					AssertRecoveryWithinEstimate(t, r, tt.metrics)
+				}
+			}
+		})
+	}
+}
+
+// TestAssertRecoveryPulseBounded verifies ApplyRecovery never moves r by
+// more than 1/δ in one step, and that a recovery correcting the whole depth
+// at once is caught.
+func TestAssertRecoveryPulseBounded(t *testing.T) {
+	depths := []float64{0.00001, 0.05, 0.2, 0.5, 1.0}
+	metricsSet := []SystemIntegrityMetrics{
+		{ImmutableOpsVerified: 100},
+		{MutableSharedState: 25, ImmutableOpsVerified: 100},
+		{MutableSharedState: 1000, ImmutableOpsVerified: 100},
+		{ImmutableOpsVerified: 100, UnsupervisedProcesses: 5, SupervisedProcesses: 5},
+	}
+
+	AssertRecoveryPulseBounded(t, depths, metricsSet)
+
+	// Deliberately bad: jump straight back to the stable regime.
+	panicRecovery := func(rd *RDynamics, _ SystemIntegrityMetrics) float64 {
+		rd.CurrentR = StableDNAConstraint.MaxR * 0.9
+		rd.InSaturationZone = false
+		return rd.CurrentR
+	}
+	failures := recoveryPulseViolations(depths, metricsSet, panicRecovery)
+	if len(failures) == 0 {
+		t.Fatal("Uncapped recovery should violate the 1/δ pulse bound")
+	}
+	t.Logf("✓ Uncapped recovery caught (%d violations), e.g.\n%s", len(failures), failures[0])
+}
+
 func TestGovernor_Statistics(t *testing.T) {
 	g := NewGovernor(2.0)
 
@@ -379,6 +528,362 @@ func TestGovernor_VelocityTracking(t *testing.T) {
 	}
 }
 
+func TestGovernor_Update_Zones(t *testing.T) {
+	tests := []struct {
+		r    float64
+		want ActionType
+	}{
+		{2.5, ActionStable},
+		{2.85, ActionWarning},
+		{2.95, ActionPacing},
+		{3.2, ActionThrottle},
+	}
+
+	for _, tt := range tests {
+		g := NewGovernor(2.0)
+		action := g.Update(tt.r, 0.05, 0.001, 16)
+
+		if action.Type != tt.want {
+			t.Errorf("r=%.2f: expected %s, got %s", tt.r, tt.want, action.Type)
+		}
+		if action.Metrics.EstimatedCoupling != tt.r {
+			t.Errorf("r=%.2f: Metrics.EstimatedCoupling = %.2f", tt.r, action.Metrics.EstimatedCoupling)
+		}
+		if !strings.Contains(action.Reason, "N=16") {
+			t.Errorf("r=%.2f: expected concurrency in reason, got: %s", tt.r, action.Reason)
+		}
+	}
+}
+
+// TestGovernor_WithStabilityModel verifies a governor configured with a
+// tighter boundary throttles where the default governor only warns, and
+// that its r tracker and Reset keep the same boundary.
+func TestGovernor_WithStabilityModel(t *testing.T) {
+	model := StabilityModel{MinR: 1.0, MaxR: 2.5}
+	g := NewGovernor(2.0, WithStabilityModel(model))
+
+	action := g.Update(2.6, 0.05, 0.001, 16)
+	if action.Type != ActionThrottle {
+		t.Fatalf("MaxR=2.5 at r=2.6: expected %s, got %s", ActionThrottle, action.Type)
+	}
+	if !strings.Contains(action.Reason, "≥ 2.5") {
+		t.Errorf("Expected the configured boundary in reason, got: %s", action.Reason)
+	}
+	if !g.rdynamics.InSaturationZone {
+		t.Error("r tracker should be in the saturation zone at r=2.6 with MaxR=2.5")
+	}
+
+	if got := NewGovernor(2.0).Update(2.6, 0.05, 0.001, 16).Type; got != ActionStable {
+		t.Errorf("Default governor at r=2.6: expected %s, got %s", ActionStable, got)
+	}
+
+	g.Reset(2.6)
+	if g.rdynamics.Model != model || !g.rdynamics.InSaturationZone {
+		t.Errorf("Reset should keep the model: got %+v, saturated=%v", g.rdynamics.Model, g.rdynamics.InSaturationZone)
+	}
+
+	t.Logf("✓ MaxR=2.5 throttles at r=2.6; default governor stays %s", ActionStable)
+}
+
+// TestGovernor_HistoryLimit verifies a governor updated on every request
+// keeps only the most recent r samples.
+func TestGovernor_HistoryLimit(t *testing.T) {
+	g := NewGovernor(1.5, WithHistoryLimit(100))
+	for i := 0; i < 1000; i++ {
+		g.Update(1.5+float64(i)/1000, 0.05, 0.001, 8)
+	}
+
+	history := g.Snapshot().RDynamics.History
+	if len(history) != 100 {
+		t.Fatalf("Expected 100 samples, got %d", len(history))
+	}
+	if last := history[len(history)-1]; math.Abs(last-2.499) > 1e-9 {
+		t.Errorf("Expected the latest sample 2.499 last, got %.4f", last)
+	}
+	if cap(history) > 300 {
+		t.Errorf("History backing array grew to %d", cap(history))
+	}
+	if got := NewGovernor(1.5).historyLimit; got != DefaultHistoryLimit {
+		t.Errorf("Default limit %d, want %d", got, DefaultHistoryLimit)
+	}
+}
+
+func TestGovernor_Update_TracksHistoryAndHysteresis(t *testing.T) {
+	g := NewGovernor(2.0, WithVelocityWindow(time.Nanosecond))
+
+	g.Update(2.5, 0.05, 0.001, 8)
+	g.Update(3.1, 0.05, 0.001, 32)
+
+	// Dropping below saturation but not below the exit threshold stays throttled
+	action := g.Update(2.5, 0.05, 0.001, 8)
+	if action.Type != ActionThrottle {
+		t.Errorf("Expected hysteresis to keep THROTTLE, got %s", action.Type)
+	}
+
+	stats := g.GetStatistics()
+	if stats["history_length"].(int) != 4 {
+		t.Errorf("Expected 4 history entries, got %d", stats["history_length"].(int))
+	}
+	if stats["current_r"].(float64) != 2.5 {
+		t.Errorf("Expected current_r 2.5, got %.2f", stats["current_r"].(float64))
+	}
+	if stats["throttles_applied"].(int) != 1 {
+		t.Errorf("Expected 1 throttle, got %d", stats["throttles_applied"].(int))
+	}
+	if g.velocity == 0 {
+		t.Errorf("Expected non-zero velocity after r changed")
+	}
+}
+
+func TestGovernor_NonFiniteInputs(t *testing.T) {
+	healthy := SystemIntegrityMetrics{
+		ImmutableOpsVerified: 100,
+		SupervisedProcesses:  50,
+		ScalingRatio:         2.18,
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*SystemIntegrityMetrics)
+		field  string
+	}{
+		{"NaN scaling ratio", func(m *SystemIntegrityMetrics) { m.ScalingRatio = math.NaN() }, "ScalingRatio"},
+		{"Inf scaling ratio", func(m *SystemIntegrityMetrics) { m.ScalingRatio = math.Inf(1) }, "ScalingRatio"},
+		{"NaN deploy delta", func(m *SystemIntegrityMetrics) { m.DeltaComplexity = math.NaN() }, "DeltaComplexity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGovernor(2.0)
+			var transitions []ActionType
+			g.OnTransition(func(from, to ActionType, action Action) {
+				transitions = append(transitions, to)
+			})
+
+			metrics := healthy
+			tt.mutate(&metrics)
+			action := g.CheckStructuralIntegrity(metrics)
+
+			if action.Type != ActionUnknown {
+				t.Fatalf("Expected UNKNOWN, got %s", action.Type)
+			}
+			if !strings.Contains(action.Reason, tt.field) {
+				t.Errorf("Reason should name %s, got: %s", tt.field, action.Reason)
+			}
+			if got := g.GetStatistics()["history_length"].(int); got != 1 {
+				t.Errorf("Non-finite sample recorded in history (%d entries)", got)
+			}
+			if len(transitions) != 1 || transitions[0] != ActionUnknown {
+				t.Errorf("Expected one STABLE→UNKNOWN transition, got %v", transitions)
+			}
+			if _, err := json.Marshal(action); err != nil {
+				t.Errorf("Marshal failed: %v", err)
+			}
+
+			if next := g.CheckStructuralIntegrity(healthy); next.Type != ActionStable {
+				t.Errorf("Expected STABLE once inputs are finite, got %s", next.Type)
+			}
+		})
+	}
+
+	if action := NewGovernor(2.0).Update(math.NaN(), 0.01, 0.001, 8); action.Type != ActionUnknown {
+		t.Errorf("Update with NaN r: expected UNKNOWN, got %s", action.Type)
+	}
+}
+
+func TestGovernor_OnTransition(t *testing.T) {
+	g := NewGovernor(2.0)
+	g.throttleMinDuration = 0 // Exit hysteresis as soon as r drops below 2.0
+
+	type event struct {
+		from, to ActionType
+		r        float64
+	}
+	var events []event
+	g.OnTransition(func(from, to ActionType, action Action) {
+		if action.Timestamp.IsZero() {
+			t.Errorf("Transition %s→%s has no timestamp", from, to)
+		}
+		events = append(events, event{from, to, action.Metrics.EstimatedCoupling})
+	})
+
+	for _, r := range []float64{2.5, 2.85, 2.86, 3.2, 2.5, 1.8, 1.7} {
+		g.Update(r, 0.05, 0.001, 8)
+	}
+
+	want := []event{
+		{ActionStable, ActionWarning, 2.85},
+		{ActionWarning, ActionThrottle, 3.2},
+		{ActionThrottle, ActionStable, 1.8}, // Hysteresis exit
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d transitions, got %d: %+v", len(want), len(events), events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("Transition %d: expected %+v, got %+v", i, want[i], events[i])
+		}
+	}
+}
+
+// TestGovernor_Backpressure verifies pressure rises monotonically as r climbs
+// from stable through saturation, and drops as it recovers.
+func TestGovernor_Backpressure(t *testing.T) {
+	g := NewGovernor(1.5)
+	now := time.Now()
+
+	if p := g.Backpressure().Pressure; p != 0 {
+		t.Errorf("Fresh governor at r=1.5: pressure %.3f, want 0", p)
+	}
+
+	step := func(r float64) BackpressureSignal {
+		now = now.Add(time.Second)
+		g.transition(g.evaluateR(r, now))
+		return g.Backpressure()
+	}
+
+	prev := 0.0
+	for r := 1.5; r <= 3.3; r += 0.05 {
+		signal := step(r)
+		if signal.Pressure < prev-1e-12 {
+			t.Fatalf("Climbing r=%.2f (%s): pressure %.3f fell below %.3f", r, signal.Zone, signal.Pressure, prev)
+		}
+		prev = signal.Pressure
+	}
+	if prev != 1 {
+		t.Errorf("Pressure in saturation %.3f, want 1", prev)
+	}
+
+	for r := 3.3; r >= 1.5; r -= 0.05 {
+		signal := step(r)
+		if signal.Pressure > prev+1e-12 {
+			t.Fatalf("Recovering r=%.2f (%s): pressure %.3f rose above %.3f", r, signal.Zone, signal.Pressure, prev)
+		}
+		prev = signal.Pressure
+	}
+	if prev != 0 {
+		t.Errorf("Pressure after recovery %.3f, want 0", prev)
+	}
+
+	// A rising r is projected ahead: same r, more pressure
+	rising := NewGovernor(2.5)
+	rising.evaluateR(2.5, now)
+	rising.evaluateR(2.6, now.Add(time.Second))
+	steady := NewGovernor(2.6)
+	steady.evaluateR(2.6, now)
+	if rising.Backpressure().Pressure <= steady.Backpressure().Pressure {
+		t.Errorf("Rising r=2.6: pressure %.3f should exceed steady %.3f",
+			rising.Backpressure().Pressure, steady.Backpressure().Pressure)
+	}
+
+	if limit := (BackpressureSignal{Pressure: 0.75}).Limit(100); limit != 25 {
+		t.Errorf("Limit(100) at pressure 0.75 = %d, want 25", limit)
+	}
+	if limit := (BackpressureSignal{Pressure: 1}).Limit(100); limit != 1 {
+		t.Errorf("Limit(100) at pressure 1 = %d, want 1", limit)
+	}
+}
+
+// TestGovernor_Backpressure_PerRequestUpdates verifies that estimator
+// jitter across updates spaced 100µs apart does not read as velocity, while
+// a real trend still does.
+func TestGovernor_Backpressure_PerRequestUpdates(t *testing.T) {
+	g := NewGovernor(2.0)
+	now := time.Now()
+
+	// Noisy but flat r, one update every 100µs for two seconds
+	for i := 0; i < 20_000; i++ {
+		now = now.Add(100 * time.Microsecond)
+		g.evaluateR(2.0+0.05*float64(i%2), now)
+	}
+	signal := g.Backpressure()
+	if math.Abs(signal.Velocity) > 0.1 {
+		t.Errorf("Flat noisy r: velocity %.1f/s, want ≈ 0", signal.Velocity)
+	}
+	if signal.Pressure > 0.01 {
+		t.Errorf("Flat noisy r: pressure %.3f, want ≈ 0", signal.Pressure)
+	}
+
+	// r climbing 0.1 per second is still projected ahead
+	r := 2.0
+	for i := 0; i < 20_000; i++ {
+		now = now.Add(100 * time.Microsecond)
+		r += 0.1e-4
+		g.evaluateR(r, now)
+	}
+	signal = g.Backpressure()
+	if math.Abs(signal.Velocity-0.1) > 0.01 {
+		t.Errorf("Rising r: velocity %.4f/s, want ≈ 0.1", signal.Velocity)
+	}
+	if steady := math.Pow(r-2.0, 2); signal.Pressure <= steady {
+		t.Errorf("Rising r=%.2f: pressure %.3f should exceed unprojected %.3f", r, signal.Pressure, steady)
+	}
+
+	t.Logf("✓ Per-request updates: velocity %.4f/s, pressure %.3f at r=%.2f", signal.Velocity, signal.Pressure, r)
+}
+
+func TestGovernor_Reset(t *testing.T) {
+	g := NewGovernor(2.0)
+	g.Update(2.85, 0.05, 0.001, 8)
+	g.Update(3.3, 0.05, 0.001, 8)
+
+	g.Reset(1.5)
+
+	stats := g.GetStatistics()
+	if stats["current_r"].(float64) != 1.5 || stats["history_length"].(int) != 1 {
+		t.Errorf("Expected fresh r history, got r=%.2f len=%d",
+			stats["current_r"].(float64), stats["history_length"].(int))
+	}
+	if stats["warnings_issued"].(int) != 0 || stats["throttles_applied"].(int) != 0 {
+		t.Errorf("Expected counters cleared, got %v", stats)
+	}
+
+	// Throttle state cleared: a stable r is immediately stable again
+	if action := g.Update(1.5, 0.05, 0.001, 8); action.Type != ActionStable {
+		t.Errorf("Expected STABLE after reset, got %s", action.Type)
+	}
+}
+
+func TestGovernor_SnapshotRestore_MidThrottle(t *testing.T) {
+	g := NewGovernor(2.0)
+	g.Update(3.2, 0.05, 0.001, 32) // Enter throttle
+
+	data, err := json.Marshal(g.Snapshot())
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var state GovernorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !state.InThrottleMode || state.ThrottleEnteredAt.IsZero() {
+		t.Fatalf("Throttle state lost in JSON: %s", data)
+	}
+
+	// Simulated restart: r has recovered, but min duration has not elapsed
+	restored := NewGovernor(1.5)
+	restored.Restore(state)
+	if action := restored.Update(1.5, 0.05, 0.001, 8); action.Type != ActionThrottle {
+		t.Errorf("Expected restored governor to stay in THROTTLE, got %s", action.Type)
+	}
+
+	stats := restored.GetStatistics()
+	if stats["throttles_applied"].(int) != 1 {
+		t.Errorf("Expected throttle counter restored, got %d", stats["throttles_applied"].(int))
+	}
+	if stats["history_length"].(int) != 3 {
+		t.Errorf("Expected restored history plus one sample, got %d", stats["history_length"].(int))
+	}
+
+	// Once the min duration has elapsed (e.g. downtime), the governor exits throttle
+	state.ThrottleEnteredAt = state.ThrottleEnteredAt.Add(-61 * time.Second)
+	restored.Restore(state)
+	if action := restored.Update(1.5, 0.05, 0.001, 8); action.Type != ActionStable {
+		t.Errorf("Expected STABLE after min duration elapsed, got %s", action.Type)
+	}
+}
+
 func maxFloat(a, b float64) float64 {
 	if a > b {
 		return a
@@ -0,0 +1,331 @@
+package lawbench
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+	"time"
+)
+
+// LatencyRecorder accumulates operation latencies and answers percentile queries.
+//
+// Recorders are not safe for concurrent use. The benchmark runner gives each
+// worker its own recorder and merges them once the phase ends.
+type LatencyRecorder interface {
+	Record(latency time.Duration)
+	Merge(other LatencyRecorder) // other must be the same concrete type
+	Count() int64
+	Mean() time.Duration
+	Stddev() time.Duration
+	Percentile(p float64) time.Duration // p in [0, 1], nearest-rank
+}
+
+// SliceRecorder keeps every sample (exact percentiles, O(n) memory).
+// This is the behavior of Result.Latencies.
+type SliceRecorder struct {
+	samples []time.Duration
+	sorted  bool
+}
+
+// NewSliceRecorder creates an exact, unbounded recorder.
+func NewSliceRecorder() *SliceRecorder {
+	return &SliceRecorder{samples: make([]time.Duration, 0, 1000)}
+}
+
+// Record adds a sample.
+func (s *SliceRecorder) Record(latency time.Duration) {
+	s.samples = append(s.samples, latency)
+	s.sorted = false
+}
+
+// Merge appends all samples from another SliceRecorder.
+func (s *SliceRecorder) Merge(other LatencyRecorder) {
+	o := other.(*SliceRecorder)
+	s.samples = append(s.samples, o.samples...)
+	s.sorted = false
+}
+
+// Count returns the number of samples recorded.
+func (s *SliceRecorder) Count() int64 {
+	return int64(len(s.samples))
+}
+
+// Mean returns the average latency.
+func (s *SliceRecorder) Mean() time.Duration {
+	if len(s.samples) == 0 {
+		return 0
+	}
+	var sum time.Duration
+	for _, lat := range s.samples {
+		sum += lat
+	}
+	return sum / time.Duration(len(s.samples))
+}
+
+// Stddev returns the population standard deviation.
+func (s *SliceRecorder) Stddev() time.Duration {
+	if len(s.samples) == 0 {
+		return 0
+	}
+	mean := s.Mean()
+	var variance float64
+	for _, lat := range s.samples {
+		diff := float64(lat - mean)
+		variance += diff * diff
+	}
+	return time.Duration(math.Sqrt(variance / float64(len(s.samples))))
+}
+
+// Percentile returns the nearest-rank p-th percentile (sorted[n*p]).
+func (s *SliceRecorder) Percentile(p float64) time.Duration {
+	if len(s.samples) == 0 {
+		return 0
+	}
+	if !s.sorted {
+		sort.Slice(s.samples, func(i, j int) bool {
+			return s.samples[i] < s.samples[j]
+		})
+		s.sorted = true
+	}
+	return s.samples[nearestRankIndex(len(s.samples), p)]
+}
+
+// fresh returns an empty recorder with the same configuration.
+func (s *SliceRecorder) fresh() LatencyRecorder {
+	return NewSliceRecorder()
+}
+
+// sameConfig reports whether other is also a SliceRecorder.
+func (s *SliceRecorder) sameConfig(other LatencyRecorder) bool {
+	_, ok := other.(*SliceRecorder)
+	return ok
+}
+
+// Latencies returns the recorded samples.
+func (s *SliceRecorder) Latencies() []time.Duration {
+	return s.samples
+}
+
+// nearestRankIndex returns the 0-based index of the p-th percentile in a
+// sorted slice of length n, matching CalculateStatistics (sorted[n*p]).
+func nearestRankIndex(n int, p float64) int {
+	index := int(math.Floor(float64(n)*p + 1e-9)) // Guard 0.95*100 = 94.999...
+	if index < 0 {
+		index = 0
+	}
+	if index >= n {
+		index = n - 1
+	}
+	return index
+}
+
+// PercentileInterpolated returns the p-th percentile of sorted latencies,
+// interpolating linearly between adjacent order statistics (Hyndman & Fan
+// type 7, the default in R and NumPy):
+//
+//	h = (n−1)·p,  Q(p) = x₍⌊h⌋₎ + (h − ⌊h⌋)·(x₍⌊h⌋+1₎ − x₍⌊h⌋₎)
+//
+// Nearest-rank (sorted[n·p]) can only return a recorded sample, so on small
+// sample counts it is biased and jumps from one sample to the next as p
+// changes: P95 and P99 of 5 samples are both the maximum. Interpolation
+// moves smoothly between samples; on large samples the two converge.
+// sorted must be in ascending order. Returns 0 for an empty slice.
+func PercentileInterpolated(sorted []time.Duration, p float64) time.Duration {
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+
+	h := float64(n-1) * math.Min(math.Max(p, 0), 1)
+	lo := int(math.Floor(h + 1e-9)) // Guard 0.95*100 = 94.999...
+	if lo >= n-1 {
+		return sorted[n-1]
+	}
+	frac := math.Max(h-float64(lo), 0)
+	return sorted[lo] + time.Duration(math.Round(frac*float64(sorted[lo+1]-sorted[lo])))
+}
+
+// HDRRecorder is a High Dynamic Range histogram of latencies.
+//
+// Memory is fixed at construction and Record is O(1). Percentile walks the
+// bucket array, whose size depends only on the configured range and
+// precision, so queries cost the same at a million samples as at ten.
+//
+// Values are tracked from 1ns up to maxValue with significantDigits decimal
+// digits of precision: a reported percentile is within a relative error of
+// 10^-significantDigits of the exact sample. Samples above maxValue are
+// clamped to maxValue.
+//
+// Mean and Stddev are exact (tracked from running sums, not from buckets).
+type HDRRecorder struct {
+	maxValue int64
+
+	subBucketHalfCountMagnitude uint
+	subBucketHalfCount          int
+	subBucketMask               int64
+	subBucketCount              int
+
+	counts []int64
+	total  int64
+	sum    float64 // Σx (ns)
+	sumSq  float64 // Σx² (ns²)
+}
+
+// NewHDRRecorder creates a histogram tracking 1ns..maxValue with the given
+// number of significant decimal digits (clamped to [1, 5]).
+func NewHDRRecorder(maxValue time.Duration, significantDigits int) *HDRRecorder {
+	if significantDigits < 1 {
+		significantDigits = 1
+	}
+	if significantDigits > 5 {
+		significantDigits = 5
+	}
+	if maxValue < 2 {
+		maxValue = 2
+	}
+
+	largestSingleUnitResolution := int64(2 * math.Pow10(significantDigits))
+	subBucketCountMagnitude := uint(math.Ceil(math.Log2(float64(largestSingleUnitResolution))))
+	subBucketHalfCountMagnitude := subBucketCountMagnitude - 1
+	subBucketCount := 1 << subBucketCountMagnitude
+
+	// Number of power-of-two buckets needed to cover maxValue
+	smallestUntrackable := int64(subBucketCount)
+	bucketCount := 1
+	for smallestUntrackable <= int64(maxValue) {
+		if smallestUntrackable > math.MaxInt64/2 {
+			bucketCount++
+			break
+		}
+		smallestUntrackable <<= 1
+		bucketCount++
+	}
+
+	return &HDRRecorder{
+		maxValue:                    int64(maxValue),
+		subBucketHalfCountMagnitude: subBucketHalfCountMagnitude,
+		subBucketHalfCount:          subBucketCount / 2,
+		subBucketMask:               int64(subBucketCount - 1),
+		subBucketCount:              subBucketCount,
+		counts:                      make([]int64, (bucketCount+1)*(subBucketCount/2)),
+	}
+}
+
+// Record adds a sample.
+func (h *HDRRecorder) Record(latency time.Duration) {
+	v := int64(latency)
+	if v < 0 {
+		v = 0
+	}
+	if v > h.maxValue {
+		v = h.maxValue
+	}
+
+	h.counts[h.countsIndex(v)]++
+	h.total++
+	h.sum += float64(v)
+	h.sumSq += float64(v) * float64(v)
+}
+
+// Merge adds all counts from another HDRRecorder with the same configuration.
+func (h *HDRRecorder) Merge(other LatencyRecorder) {
+	o := other.(*HDRRecorder)
+	for i, c := range o.counts {
+		h.counts[i] += c
+	}
+	h.total += o.total
+	h.sum += o.sum
+	h.sumSq += o.sumSq
+}
+
+// Count returns the number of samples recorded.
+func (h *HDRRecorder) Count() int64 {
+	return h.total
+}
+
+// Mean returns the exact average latency.
+func (h *HDRRecorder) Mean() time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	return time.Duration(h.sum / float64(h.total))
+}
+
+// Stddev returns the exact population standard deviation.
+func (h *HDRRecorder) Stddev() time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	mean := h.sum / float64(h.total)
+	variance := h.sumSq/float64(h.total) - mean*mean
+	if variance < 0 {
+		variance = 0 // Rounding
+	}
+	return time.Duration(math.Sqrt(variance))
+}
+
+// Percentile returns the p-th percentile using the same nearest-rank
+// convention as SliceRecorder, reported as the highest value equivalent to
+// the bucket holding that rank.
+func (h *HDRRecorder) Percentile(p float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+
+	target := int64(nearestRankIndex(int(h.total), p)) + 1
+	var seen int64
+	for i, c := range h.counts {
+		seen += c
+		if seen >= target {
+			v := h.highestEquivalentValue(h.valueFromCountsIndex(i))
+			if v > h.maxValue {
+				v = h.maxValue
+			}
+			return time.Duration(v)
+		}
+	}
+
+	return time.Duration(h.maxValue)
+}
+
+// fresh returns an empty histogram with the same configuration.
+func (h *HDRRecorder) fresh() LatencyRecorder {
+	empty := *h
+	empty.counts = make([]int64, len(h.counts))
+	empty.total, empty.sum, empty.sumSq = 0, 0, 0
+	return &empty
+}
+
+// sameConfig reports whether other is an HDRRecorder with the same range
+// and significant digits, so Merge can add counts slot by slot.
+func (h *HDRRecorder) sameConfig(other LatencyRecorder) bool {
+	o, ok := other.(*HDRRecorder)
+	return ok && o.maxValue == h.maxValue && o.subBucketCount == h.subBucketCount
+}
+
+// countsIndex maps a value to its slot in counts.
+func (h *HDRRecorder) countsIndex(v int64) int {
+	pow2Ceiling := 64 - bits.LeadingZeros64(uint64(v|h.subBucketMask))
+	bucketIdx := pow2Ceiling - int(h.subBucketHalfCountMagnitude+1)
+	subBucketIdx := int(v >> uint(bucketIdx))
+	return ((bucketIdx + 1) << h.subBucketHalfCountMagnitude) + (subBucketIdx - h.subBucketHalfCount)
+}
+
+// valueFromCountsIndex returns the lowest value stored in slot i.
+func (h *HDRRecorder) valueFromCountsIndex(i int) int64 {
+	bucketIdx := (i >> h.subBucketHalfCountMagnitude) - 1
+	subBucketIdx := (i & (h.subBucketHalfCount - 1)) + h.subBucketHalfCount
+	if bucketIdx < 0 {
+		subBucketIdx -= h.subBucketHalfCount
+		bucketIdx = 0
+	}
+	return int64(subBucketIdx) << uint(bucketIdx)
+}
+
+// highestEquivalentValue returns the largest value that shares v's bucket.
+// v must be the lowest value of its bucket (as from valueFromCountsIndex).
+func (h *HDRRecorder) highestEquivalentValue(v int64) int64 {
+	pow2Ceiling := 64 - bits.LeadingZeros64(uint64(v|h.subBucketMask))
+	bucketIdx := pow2Ceiling - int(h.subBucketHalfCountMagnitude+1)
+	return v + (int64(1) << uint(bucketIdx)) - 1
+}
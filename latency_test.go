@@ -0,0 +1,183 @@
+package lawbench
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+// TestHDRRecorder_PercentilesWithinPrecision compares HDR percentiles to exact ones.
+func TestHDRRecorder_PercentilesWithinPrecision(t *testing.T) {
+	const digits = 3
+	precision := math.Pow10(-digits)
+
+	// Log-normal latencies spanning µs to tens of ms
+	rng := rand.New(rand.NewSource(42))
+	samples := make([]time.Duration, 100000)
+	for i := range samples {
+		samples[i] = time.Duration(math.Exp(rng.NormFloat64()*1.5 + 12))
+	}
+
+	hdr := NewHDRRecorder(time.Minute, digits)
+	exact := NewSliceRecorder()
+	for _, s := range samples {
+		hdr.Record(s)
+		exact.Record(s)
+	}
+
+	for _, p := range []float64{0.50, 0.95, 0.99} {
+		want := exact.Percentile(p)
+		got := hdr.Percentile(p)
+		relErr := math.Abs(float64(got-want)) / float64(want)
+		t.Logf("P%.0f: exact=%v hdr=%v (rel err %.5f)", p*100, want, got, relErr)
+		if relErr > precision {
+			t.Errorf("P%.0f: relative error %.5f exceeds %.5f", p*100, relErr, precision)
+		}
+	}
+
+	if hdr.Mean() != exact.Mean() {
+		t.Errorf("Mean should be exact: got %v, want %v", hdr.Mean(), exact.Mean())
+	}
+	if d := math.Abs(float64(hdr.Stddev() - exact.Stddev())); d > 1e-6*float64(exact.Stddev()) {
+		t.Errorf("Stddev off: got %v, want %v", hdr.Stddev(), exact.Stddev())
+	}
+}
+
+// TestHDRRecorder_MergeAndClamp verifies merging and out-of-range values.
+func TestHDRRecorder_MergeAndClamp(t *testing.T) {
+	a := NewHDRRecorder(time.Second, 2)
+	b := NewHDRRecorder(time.Second, 2)
+	for i := 1; i <= 50; i++ {
+		a.Record(time.Duration(i) * time.Millisecond)
+		b.Record(time.Duration(i+50) * time.Millisecond)
+	}
+	b.Record(time.Hour) // Clamped to max
+
+	a.Merge(b)
+	if a.Count() != 101 {
+		t.Fatalf("Expected 101 samples after merge, got %d", a.Count())
+	}
+	if got := a.Percentile(1.0); got != time.Second {
+		t.Errorf("Expected max percentile clamped to 1s, got %v", got)
+	}
+	if got := a.Percentile(0.5); math.Abs(float64(got-51*time.Millisecond)) > 0.01*float64(51*time.Millisecond) {
+		t.Errorf("Expected P50 ≈ 51ms, got %v", got)
+	}
+}
+
+// TestSliceRecorder_MatchesCalculateStatistics ensures the slice path is unchanged.
+func TestSliceRecorder_MatchesCalculateStatistics(t *testing.T) {
+	rec := NewSliceRecorder()
+	for i := 100; i >= 1; i-- {
+		rec.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	latencies := append([]time.Duration(nil), rec.Latencies()...)
+	stats := CalculateStatistics(Result{Latencies: latencies})
+
+	if rec.Percentile(0.50) != stats.P50 || rec.Percentile(0.95) != stats.P95 || rec.Percentile(0.99) != stats.P99 {
+		t.Errorf("SliceRecorder percentiles %v/%v/%v differ from CalculateStatistics %v/%v/%v",
+			rec.Percentile(0.50), rec.Percentile(0.95), rec.Percentile(0.99), stats.P50, stats.P95, stats.P99)
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	if stats.P95 != latencies[95] {
+		t.Errorf("Expected P95 = %v, got %v", latencies[95], stats.P95)
+	}
+}
+
+// TestRun_HDRRecorder verifies Config.Recorder replaces the latency slice.
+func TestRun_HDRRecorder(t *testing.T) {
+	cfg := Config{
+		Duration: 50 * time.Millisecond,
+		Levels:   []int{1, 2},
+		Recorder: func() LatencyRecorder { return NewHDRRecorder(time.Second, 3) },
+	}
+
+	op := func(ctx context.Context) error {
+		time.Sleep(100 * time.Microsecond)
+		return nil
+	}
+
+	results, err := Run(context.Background(), op, cfg)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	for _, r := range results {
+		if r.Latencies != nil {
+			t.Errorf("N=%d: Latencies should be nil with a Recorder", r.N)
+		}
+		if r.Recorder == nil || r.Recorder.Count() != r.Operations {
+			t.Fatalf("N=%d: Recorder should hold all %d operations", r.N, r.Operations)
+		}
+
+		stats := CalculateStatistics(r)
+		if stats.P50 < 100*time.Microsecond {
+			t.Errorf("N=%d: P50 %v below sleep time", r.N, stats.P50)
+		}
+		t.Logf("✓ N=%d: %d ops, P50=%v P99=%v", r.N, r.Operations, stats.P50, stats.P99)
+	}
+}
+
+// TestPercentileInterpolated verifies type-7 interpolation on a small known
+// dataset and its convergence with nearest-rank on large samples.
+func TestPercentileInterpolated(t *testing.T) {
+	ms := time.Millisecond
+	small := []time.Duration{10 * ms, 20 * ms, 30 * ms, 40 * ms, 50 * ms}
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 10 * ms},
+		{0.50, 30 * ms},
+		{0.95, 48 * ms}, // h = 3.8: 40ms + 0.8·(50ms − 40ms)
+		{0.99, 49600 * time.Microsecond},
+		{1, 50 * ms},
+	}
+	for _, tt := range tests {
+		if got := PercentileInterpolated(small, tt.p); got != tt.want {
+			t.Errorf("P%g = %v, want %v", tt.p*100, got, tt.want)
+		}
+	}
+
+	// Nearest-rank can only return the maximum; interpolation lands between samples
+	stats := CalculateStatistics(Result{Latencies: small})
+	interp := CalculateStatisticsInterpolated(Result{Latencies: small})
+	if stats.P95 != 50*ms || interp.P95 <= 40*ms || interp.P95 >= 50*ms {
+		t.Errorf("P95: nearest-rank %v (want 50ms), interpolated %v (want between 40ms and 50ms)", stats.P95, interp.P95)
+	}
+	if interp.Mean != stats.Mean || interp.Stddev != stats.Stddev {
+		t.Errorf("Mean/Stddev should not depend on the percentile method")
+	}
+
+	tracker := NewTailDivergenceTracker(5)
+	tracker.Interpolate = true
+	for _, lat := range small {
+		tracker.Record(lat)
+	}
+	if got := tracker.P99(); got != 49600*time.Microsecond {
+		t.Errorf("Interpolating tracker P99 = %v, want 49.6ms", got)
+	}
+
+	// On large samples the methods converge
+	rng := rand.New(rand.NewSource(5))
+	large := make([]time.Duration, 100000)
+	for i := range large {
+		large[i] = time.Duration(math.Exp(rng.NormFloat64()) * float64(ms))
+	}
+	stats = CalculateStatistics(Result{Latencies: large})
+	interp = CalculateStatisticsInterpolated(Result{Latencies: large})
+	for _, pair := range [][2]time.Duration{{stats.P50, interp.P50}, {stats.P95, interp.P95}, {stats.P99, interp.P99}} {
+		if diff := math.Abs(float64(pair[0]-pair[1])) / float64(pair[0]); diff > 0.01 {
+			t.Errorf("Methods should converge on 100k samples: nearest-rank %v, interpolated %v", pair[0], pair[1])
+		}
+	}
+
+	t.Logf("✓ 5 samples: P95 nearest-rank %v, interpolated %v", CalculateStatistics(Result{Latencies: small}).P95, PercentileInterpolated(small, 0.95))
+	t.Logf("  100k samples: P99 nearest-rank %v, interpolated %v", stats.P99, interp.P99)
+}
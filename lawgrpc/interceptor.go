@@ -0,0 +1,108 @@
+// Package lawgrpc adapts lawbench's governor to gRPC server interceptors.
+//
+// It is a separate module (github.com/alexshd/lawbench/lawgrpc), so
+// importing lawbench does not pull in grpc for users who don't need it.
+//
+//	g := lawbench.NewGovernor(1.5)
+//	est := lawbench.NewTailDivergenceTrackerWithWindow(1000, 30*time.Second)
+//	srv := grpc.NewServer(
+//	    grpc.UnaryInterceptor(lawgrpc.UnaryServerInterceptor(g, est)),
+//	    grpc.StreamInterceptor(lawgrpc.StreamServerInterceptor(g, est)),
+//	)
+package lawgrpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alexshd/lawbench"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor rejects calls with codes.ResourceExhausted while
+// the governor is in ActionThrottle, and records the latency of every
+// admitted call in est.
+//
+// Before a call, at most once per lawbench.DefaultMiddlewareUpdateInterval,
+// the estimated r goes through g.Update; calls in between share the last
+// decision. g may be shared with other interceptors and HTTP middleware.
+func UnaryServerInterceptor(g *lawbench.Governor, est lawbench.REstimator) grpc.UnaryServerInterceptor {
+	gate := newGate(g, est)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		if err := gate.admit(); err != nil {
+			return nil, err
+		}
+
+		resp, err := handler(ctx, req)
+		est.Record(time.Since(start))
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is the streaming variant of UnaryServerInterceptor.
+// Admission is decided when the stream opens; the recorded latency is the
+// lifetime of the handler, so prefer a separate estimator for long-lived
+// streams.
+func StreamServerInterceptor(g *lawbench.Governor, est lawbench.REstimator) grpc.StreamServerInterceptor {
+	gate := newGate(g, est)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		if err := gate.admit(); err != nil {
+			return err
+		}
+
+		err := handler(srv, ss)
+		est.Record(time.Since(start))
+		return err
+	}
+}
+
+// gate runs the governor for one interceptor.
+type gate struct {
+	g   *lawbench.Governor
+	est lawbench.REstimator
+
+	mu         sync.Mutex // Guards the fields below
+	lastAction lawbench.Action
+	lastUpdate time.Time
+}
+
+func newGate(g *lawbench.Governor, est lawbench.REstimator) *gate {
+	return &gate{g: g, est: est}
+}
+
+// admit returns a ResourceExhausted status error while throttling.
+func (gt *gate) admit() error {
+	action := gt.decide(time.Now())
+	if action.Type == lawbench.ActionThrottle {
+		return status.Error(codes.ResourceExhausted,
+			fmt.Sprintf("lawbench: load shed at r=%.2f (saturation)", action.Metrics.EstimatedCoupling))
+	}
+	return nil
+}
+
+// decide runs the governor on the current r estimate, or returns the last
+// decision if it is less than an update interval old.
+func (gt *gate) decide(now time.Time) lawbench.Action {
+	gt.mu.Lock()
+	if !gt.lastUpdate.IsZero() && now.Sub(gt.lastUpdate) < lawbench.DefaultMiddlewareUpdateInterval {
+		defer gt.mu.Unlock()
+		return gt.lastAction
+	}
+	gt.lastUpdate = now
+	gt.mu.Unlock()
+
+	action := gt.g.Update(gt.est.EstimateR(), 0, 0, 0)
+
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	gt.lastAction = action
+	return action
+}
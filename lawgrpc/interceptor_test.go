@@ -0,0 +1,122 @@
+package lawgrpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexshd/lawbench"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// scriptedEstimator returns a settable r and counts recorded latencies.
+type scriptedEstimator struct {
+	mu       sync.Mutex
+	r        float64
+	recorded int
+}
+
+func (e *scriptedEstimator) Record(time.Duration) {
+	e.mu.Lock()
+	e.recorded++
+	e.mu.Unlock()
+}
+
+func (e *scriptedEstimator) EstimateR() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.r
+}
+
+func (e *scriptedEstimator) set(r float64) {
+	e.mu.Lock()
+	e.r = r
+	e.mu.Unlock()
+}
+
+// newHealthClient serves the standard health service over bufconn with both
+// interceptors installed.
+func newHealthClient(t *testing.T, g *lawbench.Governor, est lawbench.REstimator) healthpb.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(g, est)),
+		grpc.StreamInterceptor(StreamServerInterceptor(g, est)),
+	)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+// TestUnaryServerInterceptor_ResourceExhausted verifies calls succeed while r
+// is stable and fail with ResourceExhausted once r crosses saturation.
+func TestUnaryServerInterceptor_ResourceExhausted(t *testing.T) {
+	est := &scriptedEstimator{r: 2.0}
+	client := newHealthClient(t, lawbench.NewGovernor(2.0), est)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+			t.Fatalf("Stable r should admit: %v", err)
+		}
+	}
+
+	est.set(3.4)
+	time.Sleep(lawbench.DefaultMiddlewareUpdateInterval) // Let the governor see it
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted at r=3.4, got %v", err)
+	}
+
+	// Governor hysteresis keeps shedding after r dips just below 3.0
+	est.set(2.5)
+	time.Sleep(lawbench.DefaultMiddlewareUpdateInterval)
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected throttle hysteresis to keep shedding at r=2.5, got %v", err)
+	}
+
+	if est.recorded != 5 {
+		t.Errorf("Only admitted calls should be recorded: got %d, want 5", est.recorded)
+	}
+	t.Logf("✓ Saturation rejected: %v", err)
+}
+
+// TestStreamServerInterceptor_ResourceExhausted verifies streams are refused
+// when they open during saturation.
+func TestStreamServerInterceptor_ResourceExhausted(t *testing.T) {
+	est := &scriptedEstimator{r: 3.5}
+	client := newHealthClient(t, lawbench.NewGovernor(2.0), est)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted on stream, got %v", err)
+	}
+}
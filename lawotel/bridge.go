@@ -0,0 +1,118 @@
+// Package lawotel exports lawbench state to OpenTelemetry: span attributes
+// on the request being decided, plus metrics for r and shed events.
+//
+// It is a separate module (github.com/alexshd/lawbench/lawotel), so the
+// core lawbench module stays free of OpenTelemetry dependencies. Hook it
+// into the HTTP middleware so every request's span carries the r and action
+// it was decided under, and shed requests are marked and counted:
+//
+//	bridge, _ := lawotel.NewBridge(nil)
+//	tracker := lawbench.NewTailDivergenceTrackerWithWindow(1000, 30*time.Second)
+//	m := &lawbench.Middleware{
+//	    Estimator:  tracker,
+//	    OnDecision: bridge.DecisionHook(tracker),
+//	    OnShed:     bridge.ShedHook(),
+//	}
+//	handler := otelhttp.NewHandler(m.Middleware(mux), "api")
+package lawotel
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/alexshd/lawbench"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute keys set on spans and metrics.
+const (
+	AttrR         = attribute.Key("lawbench.r")
+	AttrAction    = attribute.Key("lawbench.action")
+	AttrTailRatio = attribute.Key("lawbench.tail_ratio")
+	AttrShed      = attribute.Key("lawbench.shed")
+)
+
+// TailRatioSource provides the P99/P50 tail ratio; *TailDivergenceTracker
+// implements it.
+type TailRatioSource interface {
+	TailDivergenceRatio() float64
+}
+
+// Bridge records lawbench decisions as span attributes and metrics.
+type Bridge struct {
+	rHistogram  metric.Float64Histogram
+	shedCounter metric.Int64Counter
+}
+
+// NewBridge creates the "lawbench.r" histogram and "lawbench.sheds" counter
+// on mp (nil = the global MeterProvider).
+func NewBridge(mp metric.MeterProvider) (*Bridge, error) {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter("github.com/alexshd/lawbench")
+
+	rHistogram, err := meter.Float64Histogram("lawbench.r",
+		metric.WithDescription("Coupling parameter r at each governor decision"))
+	if err != nil {
+		return nil, err
+	}
+	shedCounter, err := meter.Int64Counter("lawbench.sheds",
+		metric.WithDescription("Requests shed by the governor"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bridge{rHistogram: rHistogram, shedCounter: shedCounter}, nil
+}
+
+// Annotate sets lawbench.r, lawbench.action and (when tracker is non-nil)
+// lawbench.tail_ratio on the span in ctx, and records r in the histogram.
+func (b *Bridge) Annotate(ctx context.Context, action lawbench.Action, tracker TailRatioSource) {
+	r := action.Metrics.EstimatedCoupling
+
+	attrs := []attribute.KeyValue{
+		AttrR.Float64(r),
+		AttrAction.String(string(action.Type)),
+	}
+	if tracker != nil {
+		attrs = append(attrs, AttrTailRatio.Float64(tracker.TailDivergenceRatio()))
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attrs...)
+
+	b.rHistogram.Record(ctx, r, metric.WithAttributes(AttrAction.String(string(action.Type))))
+}
+
+// RecordShed marks the span in ctx with lawbench.shed=true and a
+// "lawbench.shed" event, and counts the shed. r and the action are left to
+// Annotate, which sees every request.
+func (b *Bridge) RecordShed(ctx context.Context, action lawbench.Action) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(AttrShed.Bool(true))
+	span.AddEvent("lawbench.shed", trace.WithAttributes(
+		AttrR.Float64(action.Metrics.EstimatedCoupling),
+		AttrAction.String(string(action.Type)),
+	))
+
+	b.shedCounter.Add(ctx, 1, metric.WithAttributes(AttrAction.String(string(action.Type))))
+}
+
+// DecisionHook adapts Annotate to lawbench.Middleware.OnDecision, using the
+// request's context for the span, so the lawbench.r histogram sees every
+// decision rather than only shed requests.
+func (b *Bridge) DecisionHook(tracker TailRatioSource) func(*http.Request, lawbench.Action) {
+	return func(req *http.Request, action lawbench.Action) {
+		b.Annotate(req.Context(), action, tracker)
+	}
+}
+
+// ShedHook adapts RecordShed to lawbench.Middleware.OnShed, using the
+// request's context for the span.
+func (b *Bridge) ShedHook() func(*http.Request, lawbench.Action) {
+	return func(req *http.Request, action lawbench.Action) {
+		b.RecordShed(req.Context(), action)
+	}
+}
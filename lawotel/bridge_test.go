@@ -0,0 +1,186 @@
+package lawotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alexshd/lawbench"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestBridge_ShedAttachesStateToSpan verifies that a request shed by the
+// middleware carries r, action and tail ratio on its span, and that the
+// shed is counted.
+func TestBridge_ShedAttachesStateToSpan(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)).Tracer("test")
+	reader := sdkmetric.NewManualReader()
+	bridge, err := NewBridge(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+
+	// Power-law tail: 2% of requests take 1s
+	tracker := lawbench.NewTailDivergenceTracker(100)
+	for i := 0; i < 100; i++ {
+		latency := time.Millisecond
+		if i%50 == 0 {
+			latency = time.Second
+		}
+		tracker.Record(latency)
+	}
+
+	m := &lawbench.Middleware{
+		Estimator:  tracker,
+		Shedder:    lawbench.NewTokenBucketShedder(0, 0), // Reject whenever shedding
+		OnDecision: bridge.DecisionHook(tracker),
+		OnShed:     bridge.ShedHook(),
+	}
+	inner := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Request should have been shed")
+	}))
+
+	// Stand-in for otelhttp: one span per request
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tracer.Start(r.Context(), "GET /api/order")
+		defer span.End()
+		inner.ServeHTTP(w, r.WithContext(ctx))
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/order", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503, got %d", rec.Code)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(ended))
+	}
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range ended[0].Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+
+	if got, want := attrs[AttrR].AsFloat64(), tracker.EstimateR(); got != want || got < 3.0 {
+		t.Errorf("lawbench.r = %.2f, want %.2f (≥ 3.0)", got, want)
+	}
+	if got := attrs[AttrAction].AsString(); got != string(lawbench.ActionThrottle) {
+		t.Errorf("lawbench.action = %q, want THROTTLE", got)
+	}
+	if got, want := attrs[AttrTailRatio].AsFloat64(), tracker.TailDivergenceRatio(); got != want {
+		t.Errorf("lawbench.tail_ratio = %.2f, want %.2f", got, want)
+	}
+	if !attrs[AttrShed].AsBool() {
+		t.Error("lawbench.shed should be true")
+	}
+	if events := ended[0].Events(); len(events) != 1 || events[0].Name != "lawbench.shed" {
+		t.Errorf("Expected a single lawbench.shed event, got %v", events)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	var sheds int64
+	var rSamples uint64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					sheds += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					rSamples += dp.Count
+				}
+			}
+		}
+	}
+	if sheds != 1 || rSamples != 1 {
+		t.Errorf("Expected 1 shed and 1 r sample, got %d and %d", sheds, rSamples)
+	}
+
+	t.Logf("✓ Shed span: r=%.2f action=%s tail_ratio=%.0f",
+		attrs[AttrR].AsFloat64(), attrs[AttrAction].AsString(), attrs[AttrTailRatio].AsFloat64())
+}
+
+// TestBridge_AdmittedRequestsRecordR verifies admitted requests get r on
+// their span and in the histogram too, without being marked shed.
+func TestBridge_AdmittedRequestsRecordR(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)).Tracer("test")
+	reader := sdkmetric.NewManualReader()
+	bridge, err := NewBridge(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+
+	tracker := lawbench.NewTailDivergenceTracker(100)
+	m := &lawbench.Middleware{
+		Estimator:      tracker,
+		OnDecision:     bridge.DecisionHook(tracker),
+		OnShed:         bridge.ShedHook(),
+		UpdateInterval: time.Nanosecond,
+	}
+	inner := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tracer.Start(r.Context(), "GET /health")
+		defer span.End()
+		inner.ServeHTTP(w, r.WithContext(ctx))
+	})
+
+	for i := 0; i < 3; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	}
+
+	for _, span := range spans.Ended() {
+		attrs := map[attribute.Key]attribute.Value{}
+		for _, kv := range span.Attributes() {
+			attrs[kv.Key] = kv.Value
+		}
+		if _, ok := attrs[AttrR]; !ok {
+			t.Error("Admitted span lacks lawbench.r")
+		}
+		if _, ok := attrs[AttrShed]; ok {
+			t.Error("Admitted span marked shed")
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	var rSamples uint64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if data, ok := m.Data.(metricdata.Histogram[float64]); ok {
+				for _, dp := range data.DataPoints {
+					rSamples += dp.Count
+				}
+			}
+		}
+	}
+	if rSamples != 3 {
+		t.Errorf("Expected an r sample per request, got %d", rSamples)
+	}
+}
+
+// TestBridge_AnnotateWithoutSpan verifies Annotate is a no-op on spans when
+// the context carries none, and tolerates a nil tracker.
+func TestBridge_AnnotateWithoutSpan(t *testing.T) {
+	bridge, err := NewBridge(nil)
+	if err != nil {
+		t.Fatalf("NewBridge failed: %v", err)
+	}
+	bridge.Annotate(context.Background(), lawbench.Action{Type: lawbench.ActionStable}, nil)
+	bridge.RecordShed(context.Background(), lawbench.Action{Type: lawbench.ActionThrottle})
+}
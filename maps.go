@@ -0,0 +1,78 @@
+package lawbench
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// SineMap is x_{n+1} = r·sin(πx), for x ∈ [0, 1] and r ∈ [0, 1].
+//
+// Like the logistic map it is unimodal with a quadratic maximum, so it is in
+// the same universality class: the period-doubling cascade (r ≈ 0.7197,
+// 0.8332, 0.8586, ...) converges at the same rate δ ≈ 4.669.
+func SineMap(x, r float64) float64 {
+	return r * math.Sin(math.Pi*x)
+}
+
+// TentMap is x_{n+1} = r·min(x, 1-x), for x ∈ [0, 1] and r ∈ [0, 2].
+//
+// The tent map's maximum is a corner, not quadratic, so it is NOT in the
+// Feigenbaum universality class: it goes from a fixed point straight to
+// chaos at r = 1 with no period-doubling cascade. Useful as a negative
+// control when checking that a δ measurement is meaningful.
+func TentMap(x, r float64) float64 {
+	return r * math.Min(x, 1-x)
+}
+
+// GaussMapAlpha is the fixed width parameter used by GaussMap.
+const GaussMapAlpha = 6.2
+
+// GaussMap is the Gaussian ("mouse") map x_{n+1} = exp(-α·x²) + r with
+// α = GaussMapAlpha and r ∈ [-1, 1].
+//
+// Sweeping r upward from -1 shows a period-doubling cascade (δ ≈ 4.669)
+// followed by period-halving back to a fixed point.
+func GaussMap(x, r float64) float64 {
+	return math.Exp(-GaussMapAlpha*x*x) + r
+}
+
+var (
+	mapRegistryMu sync.RWMutex
+	mapRegistry   = map[string]MapFunction{
+		"logistic": LogisticMap,
+		"sine":     SineMap,
+		"tent":     TentMap,
+		"gauss":    GaussMap,
+	}
+)
+
+// LookupMap returns the map function registered under name
+// ("logistic", "sine", "tent", "gauss" are built in).
+func LookupMap(name string) (MapFunction, bool) {
+	mapRegistryMu.RLock()
+	defer mapRegistryMu.RUnlock()
+	f, ok := mapRegistry[name]
+	return f, ok
+}
+
+// RegisterMap adds or replaces a named map function, e.g. one built with
+// AdaptPerformanceToMap, so harnesses can select it by name.
+func RegisterMap(name string, f MapFunction) {
+	mapRegistryMu.Lock()
+	defer mapRegistryMu.Unlock()
+	mapRegistry[name] = f
+}
+
+// MapNames returns the registered map names in sorted order.
+func MapNames() []string {
+	mapRegistryMu.RLock()
+	defer mapRegistryMu.RUnlock()
+
+	names := make([]string, 0, len(mapRegistry))
+	for name := range mapRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,95 @@
+package lawbench
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestSineMap_FeigenbaumUniversality verifies δ ≈ 4.669 for a different unimodal map.
+func TestSineMap_FeigenbaumUniversality(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.MinR = 0.71
+	cfg.MaxR = 0.87
+	cfg.StepR = 0.0001
+	cfg.Warmup = 5000
+	cfg.Tolerance = 1e-5
+	cfg.MaxPeriod = 64
+
+	analysis := AnalyzeBifurcation(SineMap, 0.5, cfg)
+
+	if len(analysis.Bifurcations) < 3 {
+		t.Fatalf("Expected at least 3 bifurcations, got %d", len(analysis.Bifurcations))
+	}
+	if math.Abs(analysis.Bifurcations[0].R-0.7197) > 0.001 {
+		t.Errorf("Expected first bifurcation near r=0.7197, got %.4f", analysis.Bifurcations[0].R)
+	}
+	if math.Abs(analysis.Delta-FeigenbaumDelta) > FeigenbaumDeltaTolerance {
+		t.Errorf("Sine map δ = %.3f (expected ≈ %.3f ± %.1f)",
+			analysis.Delta, FeigenbaumDelta, FeigenbaumDeltaTolerance)
+	}
+
+	t.Logf("✓ Sine map δ = %.3f (logistic map universal constant ≈ 4.669)", analysis.Delta)
+}
+
+// TestTentMap_NoCascade verifies the tent map is outside the universality class.
+func TestTentMap_NoCascade(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.MinR = 0.5
+	cfg.MaxR = 2.0
+
+	// Fixed point below r = 1, chaos immediately above it
+	if period := DetectPeriod(IterateMap(TentMap, 0.3, 0.9, cfg), cfg); period != 1 {
+		t.Errorf("r=0.9: expected fixed point, got period %d", period)
+	}
+	if period := DetectPeriod(IterateMap(TentMap, 0.3, 1.1, cfg), cfg); period != -1 {
+		t.Errorf("r=1.1: expected chaos, got period %d", period)
+	}
+
+	// No cascade means no δ to measure
+	analysis := AnalyzeBifurcation(TentMap, 0.3, cfg)
+	if analysis.Delta != 0 {
+		t.Errorf("Tent map should not yield a Feigenbaum δ, got %.3f", analysis.Delta)
+	}
+}
+
+// TestGaussMap_PeriodDoubling verifies the Gauss map undergoes period doubling.
+func TestGaussMap_PeriodDoubling(t *testing.T) {
+	cfg := DefaultFeigenbaumConfig()
+	cfg.MinR = -1.0
+	cfg.MaxR = 0.0
+	cfg.StepR = 0.001
+	cfg.Warmup = 2000
+
+	analysis := AnalyzeBifurcation(GaussMap, 0.0, cfg)
+	if len(analysis.Bifurcations) < 2 {
+		t.Fatalf("Expected period doubling in the Gauss map, got %d bifurcations", len(analysis.Bifurcations))
+	}
+	t.Logf("✓ Gauss map: first doubling at r=%.4f", analysis.Bifurcations[0].R)
+}
+
+// TestMapRegistry verifies lookup by name.
+func TestMapRegistry(t *testing.T) {
+	want := []string{"gauss", "logistic", "sine", "tent"}
+	if names := MapNames(); !reflect.DeepEqual(names, want) {
+		t.Errorf("MapNames() = %v, want %v", names, want)
+	}
+
+	f, ok := LookupMap("sine")
+	if !ok || f(0.5, 0.8) != SineMap(0.5, 0.8) {
+		t.Error("LookupMap(\"sine\") did not return SineMap")
+	}
+	if _, ok := LookupMap("henon"); ok {
+		t.Error("Expected unknown map lookup to fail")
+	}
+
+	RegisterMap("cubic", func(x, r float64) float64 { return r * x * (1 - x*x) })
+	defer func() {
+		mapRegistryMu.Lock()
+		delete(mapRegistry, "cubic")
+		mapRegistryMu.Unlock()
+	}()
+	if _, ok := LookupMap("cubic"); !ok {
+		t.Error("Registered map not found")
+	}
+}
@@ -0,0 +1,168 @@
+package lawbench
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// REstimator derives the coupling parameter r from per-request latencies.
+//
+// *TailDivergenceTracker is the default implementation (r from the
+// P99/P50 tail ratio). Implementations must be safe for concurrent use.
+type REstimator interface {
+	Record(latency time.Duration)
+	EstimateR() float64
+}
+
+// Middleware defaults, used when the corresponding field is zero.
+const (
+	DefaultMiddlewareSamples    = 1000
+	DefaultMiddlewareWindow     = 30 * time.Second
+	DefaultMiddlewareShedStatus = http.StatusServiceUnavailable
+	DefaultMiddlewareShedBody   = "Service temporarily overloaded"
+	DefaultMiddlewareRetryAfter = 5 * time.Second
+
+	// DefaultMiddlewareUpdateInterval spaces governor updates: r moves on
+	// the scale of the estimator's window, not per request, and each update
+	// costs a history sample and a formatted Reason.
+	DefaultMiddlewareUpdateInterval = 10 * time.Millisecond
+)
+
+// Middleware is net/http admission control driven by the governor.
+//
+// Every admitted request's latency is recorded in the Estimator. At most
+// once per UpdateInterval, before a request, the estimated r goes through
+// Governor.Update; Admit decides whether to serve each request given the
+// latest action's ShedFraction. Shed requests get
+// ShedStatus with ShedBody and a Retry-After header, and are not recorded
+// (their near-zero latency would distort the tail ratio).
+//
+// Tunables are exported fields; zero means the default. Set Alpha and Beta
+// from a FitUSL run to have the governor report distance to N_peak.
+// Middleware is safe for concurrent use.
+type Middleware struct {
+	Estimator REstimator // Default: TailDivergenceTracker over the last 30s
+	Governor  *Governor  // Default: NewGovernor(1.5)
+	Shedder   Shedder    // Default: RandomShedder following ShedFraction
+
+	ShedStatus int           // Response status for shed requests (503)
+	ShedBody   string        // Response body for shed requests
+	RetryAfter time.Duration // Retry-After header, rounded up to whole seconds
+
+	UpdateInterval time.Duration // Minimum time between governor updates
+
+	Alpha, Beta float64 // USL coefficients reported by the governor
+
+	// OnDecision, if set, is called for every request with the decision it
+	// is admitted or shed under, before either happens, e.g. to record r on
+	// the request's trace span (see package lawotel).
+	OnDecision func(req *http.Request, action Action)
+
+	// OnShed, if set, is called for each shed request before the response is
+	// written, e.g. to tag the request's trace span (see package lawotel).
+	OnShed func(req *http.Request, action Action)
+
+	once       sync.Once
+	mu         sync.Mutex // Guards lastAction and lastUpdate
+	lastAction Action
+	lastUpdate time.Time
+	inFlight   atomic.Int64
+}
+
+// NewMiddleware creates a middleware with default estimator, governor and
+// shedder.
+func NewMiddleware() *Middleware {
+	return &Middleware{}
+}
+
+// Middleware wraps next with admission control. The method name matches
+// gorilla/mux's MiddlewareFunc interface, so a *Middleware can be passed to
+// Router.Use directly.
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	m.once.Do(m.init)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		inFlight := m.inFlight.Add(1)
+		defer m.inFlight.Add(-1)
+
+		action := m.decide(int(inFlight), start)
+		if m.OnDecision != nil {
+			m.OnDecision(r, action)
+		}
+		if !Admit(action, m.Shedder, start) {
+			if m.OnShed != nil {
+				m.OnShed(r, action)
+			}
+			m.shed(w)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+		m.Estimator.Record(time.Since(start))
+	})
+}
+
+// LastAction returns the governor's most recent decision, for status
+// endpoints.
+func (m *Middleware) LastAction() Action {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastAction
+}
+
+// init fills in defaults for unset fields.
+func (m *Middleware) init() {
+	if m.Estimator == nil {
+		m.Estimator = NewTailDivergenceTrackerWithWindow(DefaultMiddlewareSamples, DefaultMiddlewareWindow)
+	}
+	if m.Governor == nil {
+		m.Governor = NewGovernor(1.5)
+	}
+	if m.Shedder == nil {
+		m.Shedder = NewRandomShedder(0)
+	}
+	if m.ShedStatus == 0 {
+		m.ShedStatus = DefaultMiddlewareShedStatus
+	}
+	if m.ShedBody == "" {
+		m.ShedBody = DefaultMiddlewareShedBody
+	}
+	if m.RetryAfter == 0 {
+		m.RetryAfter = DefaultMiddlewareRetryAfter
+	}
+	if m.UpdateInterval == 0 {
+		m.UpdateInterval = DefaultMiddlewareUpdateInterval
+	}
+}
+
+// decide runs the governor on the current r estimate, or returns the last
+// decision if it is less than UpdateInterval old.
+func (m *Middleware) decide(inFlight int, now time.Time) Action {
+	m.mu.Lock()
+	if !m.lastUpdate.IsZero() && now.Sub(m.lastUpdate) < m.UpdateInterval {
+		defer m.mu.Unlock()
+		return m.lastAction
+	}
+	m.lastUpdate = now
+	m.mu.Unlock()
+
+	r := m.Estimator.EstimateR()
+	action := m.Governor.Update(r, m.Alpha, m.Beta, inFlight)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastAction = action
+	return action
+}
+
+// shed writes the rejection response.
+func (m *Middleware) shed(w http.ResponseWriter) {
+	seconds := int(math.Ceil(m.RetryAfter.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	http.Error(w, m.ShedBody, m.ShedStatus)
+}
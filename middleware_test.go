@@ -0,0 +1,167 @@
+package lawbench
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestMiddleware_ThrottlesOnTailDivergence verifies the default tail-ratio
+// estimator drives the governor into throttling once a heavy latency tail
+// appears, and shed requests get 503 with Retry-After.
+func TestMiddleware_ThrottlesOnTailDivergence(t *testing.T) {
+	calls := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls%25 == 0 {
+			time.Sleep(20 * time.Millisecond) // 4% black swans
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	m := NewMiddleware()
+	h := m.Middleware(handler)
+
+	shed := 0
+	for i := 0; i < 300; i++ {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/order", nil))
+
+		if rec.Code == http.StatusServiceUnavailable {
+			shed++
+			if got := rec.Header().Get("Retry-After"); got != "5" {
+				t.Fatalf("Expected Retry-After: 5, got %q", got)
+			}
+			if !strings.Contains(rec.Body.String(), DefaultMiddlewareShedBody) {
+				t.Errorf("Unexpected shed body: %q", rec.Body.String())
+			}
+		}
+	}
+
+	if shed == 0 {
+		t.Fatalf("Expected 503s once the tail diverged (r=%.2f, last action %s)",
+			m.Estimator.EstimateR(), m.LastAction().Type)
+	}
+	if got := m.LastAction().Type; got != ActionThrottle {
+		t.Errorf("Expected governor in THROTTLE, got %s", got)
+	}
+
+	t.Logf("✓ %d/300 requests shed with 503 + Retry-After (r=%.2f)", shed, m.Estimator.EstimateR())
+}
+
+// scriptedEstimator returns a fixed r and counts recorded latencies.
+type scriptedEstimator struct {
+	mu       sync.Mutex
+	r        float64
+	recorded int
+}
+
+func (e *scriptedEstimator) Record(time.Duration) {
+	e.mu.Lock()
+	e.recorded++
+	e.mu.Unlock()
+}
+
+func (e *scriptedEstimator) EstimateR() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.r
+}
+
+// halfShedder admits everything below a 50% shed fraction and nothing above.
+type halfShedder struct {
+	mu       sync.Mutex
+	fraction float64
+}
+
+func (s *halfShedder) ShouldAdmit(time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fraction < 0.5
+}
+
+func (s *halfShedder) SetShedFraction(fraction float64) {
+	s.mu.Lock()
+	s.fraction = fraction
+	s.mu.Unlock()
+}
+
+// TestMiddleware_CustomEstimatorAndResponse verifies a pluggable estimator,
+// a custom shed response, and that shed requests are not recorded.
+func TestMiddleware_CustomEstimatorAndResponse(t *testing.T) {
+	estimator := &scriptedEstimator{r: 2.0}
+	m := &Middleware{
+		Estimator:  estimator,
+		Shedder:    &halfShedder{},
+		ShedStatus: http.StatusTooManyRequests,
+		ShedBody:   "slow down",
+		RetryAfter: 1500 * time.Millisecond,
+	}
+	var shedActions []Action
+	m.OnShed = func(req *http.Request, action Action) {
+		shedActions = append(shedActions, action)
+	}
+	h := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		return rec
+	}
+
+	for i := 0; i < 10; i++ {
+		if rec := serve(); rec.Code != http.StatusOK {
+			t.Fatalf("Stable r should admit, got %d", rec.Code)
+		}
+	}
+
+	estimator.mu.Lock()
+	estimator.r = 3.5
+	estimator.mu.Unlock()
+	time.Sleep(DefaultMiddlewareUpdateInterval) // Let the governor see it
+
+	rec := serve()
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429 while throttling, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Retry-After should round 1.5s up to 2, got %q", got)
+	}
+	if !strings.Contains(rec.Body.String(), "slow down") {
+		t.Errorf("Unexpected body: %q", rec.Body.String())
+	}
+	if estimator.recorded != 10 {
+		t.Errorf("Only admitted requests should be recorded: got %d, want 10", estimator.recorded)
+	}
+	if got := m.LastAction().Type; got != ActionThrottle {
+		t.Errorf("Expected THROTTLE, got %s", got)
+	}
+	if len(shedActions) != 1 || shedActions[0].Metrics.EstimatedCoupling != 3.5 {
+		t.Errorf("OnShed should see the one shed request at r=3.5, got %+v", shedActions)
+	}
+}
+
+// TestMiddleware_UpdateInterval verifies requests within UpdateInterval
+// share one governor decision instead of each adding an r sample.
+func TestMiddleware_UpdateInterval(t *testing.T) {
+	g := NewGovernor(2.0)
+	m := &Middleware{Estimator: &scriptedEstimator{r: 2.0}, Governor: g, UpdateInterval: time.Hour}
+	h := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for i := 0; i < 100; i++ {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	// Initial r plus the first request's update
+	if got := g.GetStatistics()["history_length"].(int); got != 2 {
+		t.Errorf("Expected one governor update for 100 requests, got %d samples", got)
+	}
+	if got := m.LastAction().Type; got != ActionStable {
+		t.Errorf("Expected STABLE, got %s", got)
+	}
+}
@@ -0,0 +1,385 @@
+package lawbench
+
+import (
+	"fmt"
+	"math"
+)
+
+// ScalabilityModel identifies a throughput model fitted to benchmark results.
+type ScalabilityModel string
+
+const (
+	ModelUSL       ScalabilityModel = "USL"       // λN / (1 + α(N-1) + βN(N-1))
+	ModelAmdahl    ScalabilityModel = "AMDAHL"    // λN / (1 + σ(N-1))
+	ModelGustafson ScalabilityModel = "GUSTAFSON" // λ(N - σ(N-1))
+)
+
+// AmdahlCoefficients contains the Amdahl's Law parameters.
+//
+// Amdahl's Law is USL with β = 0: contention only, no coordination.
+// Throughput saturates at λ/σ but never goes retrograde.
+type AmdahlCoefficients struct {
+	Lambda         float64 // λ: Serial throughput (ops/sec at N=1)
+	SerialFraction float64 // σ: Fraction of work that cannot be parallelized
+	RSquared       float64 // R²: Goodness of fit (1.0 = perfect)
+}
+
+// PredictThroughput estimates throughput at a given concurrency level.
+func (c AmdahlCoefficients) PredictThroughput(n int) float64 {
+	return uslModel(float64(n), c.Lambda, c.SerialFraction, 0)
+}
+
+// GustafsonCoefficients contains the Gustafson's Law (scaled speedup) parameters.
+//
+// Gustafson's Law assumes the problem grows with N, so the serial part is a
+// fixed cost per step rather than a fixed fraction of a fixed problem:
+//
+//	C(N) = λ(N - σ(N-1))
+//
+// Throughput grows linearly without bound (slope λ(1-σ)).
+type GustafsonCoefficients struct {
+	Lambda         float64 // λ: Serial throughput (ops/sec at N=1)
+	SerialFraction float64 // σ: Serial fraction of the scaled workload
+	RSquared       float64 // R²: Goodness of fit (1.0 = perfect)
+}
+
+// PredictThroughput estimates throughput at a given concurrency level.
+func (c GustafsonCoefficients) PredictThroughput(n int) float64 {
+	N := float64(n)
+	return c.Lambda * (N - c.SerialFraction*(N-1))
+}
+
+// FitAmdahl fits Amdahl's Law using the same linearization as FitUSL with β = 0:
+//
+//	N/C(N) = 1/λ + (σ/λ)(N-1)
+func FitAmdahl(results []Result) (AmdahlCoefficients, error) {
+	if len(results) < 2 {
+		return AmdahlCoefficients{}, fmt.Errorf("need at least 2 data points, got %d", len(results))
+	}
+
+	lambda, sigma, ok := fitContentionOnly(results, func(int) float64 { return 1.0 })
+	if !ok {
+		return AmdahlCoefficients{}, fmt.Errorf("singular system: need at least 2 distinct concurrency levels")
+	}
+
+	return AmdahlCoefficients{
+		Lambda:         lambda,
+		SerialFraction: sigma,
+		RSquared: throughputRSquared(results, func(n float64) float64 {
+			return uslModel(n, lambda, sigma, 0)
+		}),
+	}, nil
+}
+
+// FitGustafson fits Gustafson's Law by ordinary least squares.
+//
+// C(N) = λσ + λ(1-σ)N is linear in N: fit C = a + bN, then
+// λ = a + b and σ = a / (a + b).
+func FitGustafson(results []Result) (GustafsonCoefficients, error) {
+	if len(results) < 2 {
+		return GustafsonCoefficients{}, fmt.Errorf("need at least 2 data points, got %d", len(results))
+	}
+
+	var sumN, sumC, sumNN, sumNC, count float64
+	for _, r := range results {
+		N := float64(r.N)
+		sumN += N
+		sumC += r.Throughput
+		sumNN += N * N
+		sumNC += N * r.Throughput
+		count++
+	}
+
+	det := count*sumNN - sumN*sumN
+	if math.Abs(det) < 1e-10 {
+		return GustafsonCoefficients{}, fmt.Errorf("singular system: need at least 2 distinct concurrency levels")
+	}
+
+	b := (count*sumNC - sumN*sumC) / det
+	a := (sumC - b*sumN) / count
+
+	lambda := a + b
+	var sigma float64
+	if lambda != 0 {
+		sigma = a / lambda
+	}
+
+	return GustafsonCoefficients{
+		Lambda:         lambda,
+		SerialFraction: sigma,
+		RSquared: throughputRSquared(results, func(n float64) float64 {
+			return a + b*n
+		}),
+	}, nil
+}
+
+// ModelComparison holds all three fits and the model the data best supports.
+type ModelComparison struct {
+	Best             ScalabilityModel
+	USL              USLCoefficients
+	Amdahl           AmdahlCoefficients
+	Gustafson        GustafsonCoefficients
+	AdjustedRSquared map[ScalabilityModel]float64
+}
+
+// ChooseBestModel fits USL, Amdahl, and Gustafson and picks the one with the
+// highest adjusted R².
+//
+// Adjusted R² penalizes extra parameters, so USL (λ, α, β) only wins when β
+// explains variance the two-parameter models cannot:
+//
+//	R²_adj = 1 - (1 - R²)(n - 1)/(n - p - 1)
+//
+// where n is the number of levels and p the number of non-intercept
+// parameters (USL: 2, Amdahl: 1, Gustafson: 1). A model with too few
+// points to adjust (n ≤ p + 1) scores -Inf. Ties go to the simpler model.
+func ChooseBestModel(results []Result) (ModelComparison, error) {
+	usl, err := FitUSL(results)
+	if err != nil {
+		return ModelComparison{}, err
+	}
+	amdahl, err := FitAmdahl(results)
+	if err != nil {
+		return ModelComparison{}, err
+	}
+	gustafson, err := FitGustafson(results)
+	if err != nil {
+		return ModelComparison{}, err
+	}
+
+	n := len(results)
+	cmp := ModelComparison{
+		USL:       usl,
+		Amdahl:    amdahl,
+		Gustafson: gustafson,
+		AdjustedRSquared: map[ScalabilityModel]float64{
+			ModelAmdahl:    adjustedRSquared(amdahl.RSquared, n, 1),
+			ModelGustafson: adjustedRSquared(gustafson.RSquared, n, 1),
+			ModelUSL:       adjustedRSquared(usl.RSquared, n, 2),
+		},
+	}
+
+	// Simplest first so ties keep the simpler model
+	cmp.Best = ModelAmdahl
+	for _, m := range []ScalabilityModel{ModelGustafson, ModelUSL} {
+		if cmp.AdjustedRSquared[m] > cmp.AdjustedRSquared[cmp.Best] {
+			cmp.Best = m
+		}
+	}
+
+	return cmp, nil
+}
+
+// adjustedRSquared penalizes R² for p predictors over n observations.
+func adjustedRSquared(rSquared float64, n, p int) float64 {
+	if n-p-1 <= 0 {
+		return math.Inf(-1)
+	}
+	return 1 - (1-rSquared)*float64(n-1)/float64(n-p-1)
+}
+
+// USL4Coefficients extends USL with a fixed concurrency overhead γ.
+//
+// The 3-parameter part is a named field rather than embedded so that its
+// methods, which ignore γ, are not promoted: USL.PeakConcurrency or
+// USL.CouplingR describe the λ, α, β curve alone and must be asked for
+// explicitly.
+type USL4Coefficients struct {
+	USL   USLCoefficients // λ, α, β, R² and MaxMeasuredN of the 4-parameter fit
+	Gamma float64         // γ: Fixed overhead paid by every request once N > 1
+}
+
+// usl4Model calculates predicted throughput using the 4-parameter USL:
+//
+//	C(N) = λN / (1 + α(N-1) + βN(N-1) + γ(1 - 1/N))
+func usl4Model(n, lambda, alpha, beta, gamma float64) float64 {
+	return (lambda * n) / (1 + alpha*(n-1) + beta*n*(n-1) + gamma*(1-1/n))
+}
+
+// PredictThroughput estimates throughput at a given concurrency level,
+// including the γ overhead term.
+func (c USL4Coefficients) PredictThroughput(n int) float64 {
+	return usl4Model(float64(n), c.USL.Lambda, c.USL.Alpha, c.USL.Beta, c.Gamma)
+}
+
+// PredictThroughputSafe is PredictThroughput with the extrapolation
+// confidence of USLCoefficients.PredictThroughputSafe.
+func (c USL4Coefficients) PredictThroughputSafe(n int) (throughput float64, confidence float64) {
+	_, confidence = c.USL.PredictThroughputSafe(n)
+	return c.PredictThroughput(n), confidence
+}
+
+// Efficiency returns the ratio of predicted to ideal throughput,
+// including the γ overhead term.
+func (c USL4Coefficients) Efficiency(n int) float64 {
+	ideal := c.USL.Lambda * float64(n)
+	if ideal == 0 {
+		return 0
+	}
+	return c.PredictThroughput(n) / ideal
+}
+
+// PeakConcurrency returns the concurrency at which the 4-parameter curve
+// peaks. Setting dC/dN = 0 gives the cubic
+//
+//	βN³ - (1 - α + γ)N + 2γ = 0
+//
+// whose largest root is N_peak; with γ = 0 it reduces to sqrt((1-α)/β).
+// As for USLCoefficients.PeakConcurrency the result is +Inf when β ≤ 0
+// and 0 when throughput never rises.
+func (c USL4Coefficients) PeakConcurrency() float64 {
+	alpha, beta, gamma := c.USL.Alpha, c.USL.Beta, c.Gamma
+	if beta <= 0 {
+		return math.Inf(1)
+	}
+
+	k := 1 - alpha + gamma
+	g := func(n float64) float64 { return beta*n*n*n - k*n + 2*gamma }
+
+	// g is smallest at sqrt(k/3β); throughput rises only while g < 0
+	lo := 0.0
+	if k > 0 {
+		lo = math.Sqrt(k / (3 * beta))
+	}
+	if g(lo) >= 0 {
+		return 0
+	}
+	hi := 2*lo + 1
+	for g(hi) < 0 {
+		hi *= 2
+	}
+	for i := 0; i < 100; i++ {
+		mid := (lo + hi) / 2
+		if g(mid) < 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// PeakThroughput returns the predicted throughput at the nearest integer
+// N_peak, including the γ overhead term. Without a peak (β ≤ 0) it is the
+// asymptote λ/α for α > 0 and +Inf otherwise.
+func (c USL4Coefficients) PeakThroughput() float64 {
+	peak := c.PeakConcurrency()
+	if math.IsInf(peak, 1) {
+		if c.USL.Alpha > 0 {
+			return c.USL.Lambda / c.USL.Alpha
+		}
+		return math.Inf(1)
+	}
+	n := int(math.Round(peak))
+	if n < 1 {
+		return 0
+	}
+	return c.PredictThroughput(n)
+}
+
+// FitUSL4 fits the 4-parameter USL variant for systems with a fixed
+// concurrency overhead:
+//
+//	C(N) = λN / (1 + α(N-1) + βN(N-1) + γ(1 - 1/N))
+//
+// γ models a per-request cost that switches on as soon as requests run
+// concurrently (lock acquisition paths, connection checkout, atomics that
+// are free when uncontended) but does not grow with N. The 3-parameter
+// model can only express such a step through α(N-1), so it inflates α and
+// under-reports λ.
+//
+// The (1 - 1/N) factor is what makes γ identifiable: a bare constant
+// "+ γ" in the denominator can be factored out and absorbed into λ, α and
+// β, so no amount of throughput data could tell them apart.
+//
+// Linearized, this is four-term least squares:
+//
+//	N/C(N) = 1/λ + (α/λ)(N-1) + (β/λ)N(N-1) + (γ/λ)(1 - 1/N)
+//
+// Requires at least 4 distinct concurrency levels.
+func FitUSL4(results []Result) (USL4Coefficients, error) {
+	if len(results) < 4 {
+		return USL4Coefficients{}, fmt.Errorf("need at least 4 data points, got %d", len(results))
+	}
+
+	// Normal equations: (XᵀX) b = XᵀY
+	var xtx [4][4]float64
+	var xty [4]float64
+	maxN := 0
+	for _, r := range results {
+		if r.Throughput == 0 {
+			continue
+		}
+		maxN = max(maxN, r.N)
+		N := float64(r.N)
+		Y := N / r.Throughput
+		x := [4]float64{1, N - 1, N * (N - 1), 1 - 1/N}
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				xtx[i][j] += x[i] * x[j]
+			}
+			xty[i] += x[i] * Y
+		}
+	}
+
+	b, ok := solve4(xtx, xty)
+	if !ok {
+		return USL4Coefficients{}, fmt.Errorf("singular system: need at least 4 distinct concurrency levels")
+	}
+
+	lambda := 1.0 / b[0]
+	alpha := b[1] / b[0]
+	beta := b[2] / b[0]
+	gamma := b[3] / b[0]
+
+	rSquared := throughputRSquared(results, func(n float64) float64 {
+		return usl4Model(n, lambda, alpha, beta, gamma)
+	})
+
+	return USL4Coefficients{
+		USL: USLCoefficients{
+			Lambda:       lambda,
+			Alpha:        alpha,
+			Beta:         beta,
+			RSquared:     rSquared,
+			MaxMeasuredN: maxN,
+		},
+		Gamma: gamma,
+	}, nil
+}
+
+// solve4 solves a 4x4 linear system by Gaussian elimination with partial pivoting.
+func solve4(a [4][4]float64, b [4]float64) ([4]float64, bool) {
+	const n = 4
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return [4]float64{}, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for row := col + 1; row < n; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			b[row] -= factor * b[col]
+		}
+	}
+
+	var x [4]float64
+	for row := n - 1; row >= 0; row-- {
+		sum := b[row]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, true
+}
@@ -0,0 +1,202 @@
+package lawbench
+
+import (
+	"math"
+	"testing"
+)
+
+// TestFitAmdahl_RecoversSerialFraction tests Amdahl fit on exact contention-only data.
+func TestFitAmdahl_RecoversSerialFraction(t *testing.T) {
+	results := make([]Result, 0)
+	for _, n := range []int{1, 2, 4, 8, 16} {
+		results = append(results, Result{N: n, Throughput: uslModel(float64(n), 1000, 0.1, 0)})
+	}
+
+	coeffs, err := FitAmdahl(results)
+	if err != nil {
+		t.Fatalf("FitAmdahl failed: %v", err)
+	}
+
+	if math.Abs(coeffs.SerialFraction-0.1) > 1e-6 {
+		t.Errorf("Expected σ ≈ 0.1, got %.6f", coeffs.SerialFraction)
+	}
+	if math.Abs(coeffs.Lambda-1000) > 1e-3 {
+		t.Errorf("Expected λ ≈ 1000, got %.3f", coeffs.Lambda)
+	}
+	if coeffs.RSquared < 0.9999 {
+		t.Errorf("Expected R² ≈ 1, got %.6f", coeffs.RSquared)
+	}
+}
+
+// TestFitGustafson_RecoversSerialFraction tests Gustafson fit on exact scaled-speedup data.
+func TestFitGustafson_RecoversSerialFraction(t *testing.T) {
+	results := make([]Result, 0)
+	for _, n := range []int{1, 2, 4, 8, 16} {
+		N := float64(n)
+		results = append(results, Result{N: n, Throughput: 1000 * (N - 0.2*(N-1))})
+	}
+
+	coeffs, err := FitGustafson(results)
+	if err != nil {
+		t.Fatalf("FitGustafson failed: %v", err)
+	}
+
+	if math.Abs(coeffs.SerialFraction-0.2) > 1e-6 {
+		t.Errorf("Expected σ ≈ 0.2, got %.6f", coeffs.SerialFraction)
+	}
+	if math.Abs(coeffs.PredictThroughput(32)-1000*(32-0.2*31)) > 1e-3 {
+		t.Errorf("Prediction at N=32 off: %.3f", coeffs.PredictThroughput(32))
+	}
+}
+
+// TestChooseBestModel_ContentionOnlyPrefersAmdahl verifies adjusted R² favors the simpler model.
+func TestChooseBestModel_ContentionOnlyPrefersAmdahl(t *testing.T) {
+	// Contention-only data (α = 0.1, β = 0) with ±1% deterministic noise
+	noise := []float64{0.996, 1.004, 0.997, 1.006, 0.995, 1.003}
+
+	results := make([]Result, 0)
+	for i, n := range []int{1, 2, 4, 8, 16, 32} {
+		results = append(results, Result{N: n, Throughput: uslModel(float64(n), 1000, 0.1, 0) * noise[i]})
+	}
+
+	cmp, err := ChooseBestModel(results)
+	if err != nil {
+		t.Fatalf("ChooseBestModel failed: %v", err)
+	}
+
+	for _, m := range []ScalabilityModel{ModelUSL, ModelAmdahl, ModelGustafson} {
+		t.Logf("%-10s adjusted R² = %.6f", m, cmp.AdjustedRSquared[m])
+	}
+
+	if cmp.Best != ModelAmdahl {
+		t.Errorf("Expected contention-only data to prefer %s, got %s", ModelAmdahl, cmp.Best)
+	}
+}
+
+// TestChooseBestModel_RetrogradePrefersUSL verifies β is only kept when the data needs it.
+func TestChooseBestModel_RetrogradePrefersUSL(t *testing.T) {
+	results := make([]Result, 0)
+	for _, n := range []int{1, 2, 4, 8, 16, 32} {
+		results = append(results, Result{N: n, Throughput: uslModel(float64(n), 1000, 0.05, 0.01)})
+	}
+
+	cmp, err := ChooseBestModel(results)
+	if err != nil {
+		t.Fatalf("ChooseBestModel failed: %v", err)
+	}
+
+	if cmp.Best != ModelUSL {
+		t.Errorf("Expected retrograde data to prefer %s, got %s", ModelUSL, cmp.Best)
+	}
+}
+
+// TestFitUSL4_RecoversGamma verifies the 4-parameter fit separates γ from α.
+func TestFitUSL4_RecoversGamma(t *testing.T) {
+	lambda, alpha, beta, gamma := 1000.0, 0.05, 0.001, 0.5
+
+	results := make([]Result, 0)
+	for _, n := range []int{1, 2, 4, 8, 16, 32} {
+		results = append(results, Result{N: n, Throughput: usl4Model(float64(n), lambda, alpha, beta, gamma)})
+	}
+
+	coeffs3, err := FitUSL(results)
+	if err != nil {
+		t.Fatalf("FitUSL failed: %v", err)
+	}
+
+	coeffs4, err := FitUSL4(results)
+	if err != nil {
+		t.Fatalf("FitUSL4 failed: %v", err)
+	}
+
+	t.Logf("True:    λ=%.1f, α=%.4f, β=%.5f, γ=%.3f", lambda, alpha, beta, gamma)
+	t.Logf("3-param: λ=%.1f, α=%.4f, β=%.5f", coeffs3.Lambda, coeffs3.Alpha, coeffs3.Beta)
+	t.Logf("4-param: λ=%.1f, α=%.4f, β=%.5f, γ=%.3f",
+		coeffs4.USL.Lambda, coeffs4.USL.Alpha, coeffs4.USL.Beta, coeffs4.Gamma)
+
+	if math.Abs(coeffs4.Gamma-gamma) > 1e-6 {
+		t.Errorf("Expected γ ≈ %.3f, got %.6f", gamma, coeffs4.Gamma)
+	}
+	if math.Abs(coeffs4.USL.Alpha-alpha) > 1e-6 {
+		t.Errorf("Expected α ≈ %.3f, got %.6f", alpha, coeffs4.USL.Alpha)
+	}
+	if coeffs3.Alpha <= alpha*1.2 {
+		t.Errorf("Expected 3-param fit to inflate α above %.3f, got %.6f", alpha, coeffs3.Alpha)
+	}
+
+	// Predictions must include γ
+	for _, n := range []int{1, 8, 32} {
+		want := usl4Model(float64(n), lambda, alpha, beta, gamma)
+		if got := coeffs4.PredictThroughput(n); math.Abs(got-want) > 1e-6*want {
+			t.Errorf("N=%d: predicted %.3f, want %.3f", n, got, want)
+		}
+	}
+	if eff := coeffs4.Efficiency(1); math.Abs(eff-1) > 1e-9 {
+		t.Errorf("Efficiency at N=1 should be 1.0, got %.6f", eff)
+	}
+}
+
+// TestUSL4Coefficients_PeakIncludesGamma verifies the peak, peak throughput
+// and extrapolation confidence of a 4-parameter fit account for γ.
+func TestUSL4Coefficients_PeakIncludesGamma(t *testing.T) {
+	lambda, alpha, beta := 1000.0, 0.05, 0.001
+
+	tests := []struct {
+		name  string
+		gamma float64
+	}{
+		{"No overhead (γ = 0)", 0},
+		{"Fixed overhead (γ = 0.5)", 0.5},
+		{"Heavy overhead (γ = 2)", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := make([]Result, 0)
+			for _, n := range []int{1, 2, 4, 8, 16, 32} {
+				results = append(results, Result{N: n, Throughput: usl4Model(float64(n), lambda, alpha, beta, tt.gamma)})
+			}
+
+			coeffs, err := FitUSL4(results)
+			if err != nil {
+				t.Fatalf("FitUSL4 failed: %v", err)
+			}
+			if coeffs.USL.MaxMeasuredN != 32 {
+				t.Errorf("MaxMeasuredN = %d, want 32", coeffs.USL.MaxMeasuredN)
+			}
+			if _, conf := coeffs.PredictThroughputSafe(64); math.Abs(conf-0.5) > 1e-9 {
+				t.Errorf("Confidence at N=64 = %.3f, want 0.5", conf)
+			}
+
+			// The peak must be a maximum of the γ-aware curve
+			peak := coeffs.PeakConcurrency()
+			at := func(n float64) float64 { return usl4Model(n, lambda, alpha, beta, tt.gamma) }
+			if at(peak) < at(peak*0.99) || at(peak) < at(peak*1.01) {
+				t.Errorf("N_peak %.2f is not a maximum: C(%.2f)=%.2f", peak, peak, at(peak))
+			}
+			if tt.gamma == 0 {
+				if want := CalculatePeakCapacity(alpha, beta); math.Abs(peak-want) > 1e-6 {
+					t.Errorf("γ = 0: N_peak %.4f, want %.4f", peak, want)
+				}
+			} else if peak <= coeffs.USL.PeakConcurrency() {
+				t.Errorf("γ > 0 should push N_peak above the 3-parameter %.2f, got %.2f",
+					coeffs.USL.PeakConcurrency(), peak)
+			}
+
+			want := coeffs.PredictThroughput(int(math.Round(peak)))
+			if got := coeffs.PeakThroughput(); got != want {
+				t.Errorf("PeakThroughput %.2f, want %.2f", got, want)
+			}
+			t.Logf("✓ γ=%.1f: N_peak=%.2f (3-param %.2f), peak %.0f ops/sec",
+				tt.gamma, peak, coeffs.USL.PeakConcurrency(), coeffs.PeakThroughput())
+		})
+	}
+
+	noPeak := USL4Coefficients{USL: USLCoefficients{Lambda: 1000, Alpha: 0.1}, Gamma: 0.5}
+	if !math.IsInf(noPeak.PeakConcurrency(), 1) {
+		t.Errorf("β = 0: N_peak = %.2f, want +Inf", noPeak.PeakConcurrency())
+	}
+	if got := noPeak.PeakThroughput(); math.Abs(got-10000) > 1e-9 {
+		t.Errorf("β = 0: peak throughput %.2f, want λ/α = 10000", got)
+	}
+}
@@ -0,0 +1,138 @@
+package lawbench
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultMonitorErrorDecay is the EWMA weight of one request in the
+// Monitor's error rate: the rate reflects roughly the last 100 requests.
+const DefaultMonitorErrorDecay = 0.01
+
+// Monitor is the one-call production facade over an REstimator and a
+// Governor: middleware reports each request's outcome to Observe and gets
+// the governor's decision back, instead of wiring the tracker, the r
+// formula and the governor by hand.
+//
+// r is the tail-derived estimate plus ErrorRWeight × the recent error rate
+// (the CouplingRWithErrors term), so a service failing fast is not read as
+// healthy because its latencies look good.
+//
+// Tunables are exported fields; zero means the default. Monitor is safe for
+// concurrent use.
+type Monitor struct {
+	Estimator REstimator // Default: TailDivergenceTracker over the last 30s
+	Governor  *Governor  // Default: NewGovernor(1.5)
+
+	// ClassifyError sorts request errors (nil = ClassifyError). Requests
+	// classed ErrorCancelled (by default context.Canceled: the client went
+	// away) are ignored entirely; timeouts count as failures.
+	ClassifyError func(error) ErrorClass
+
+	ErrorDecay     float64       // EWMA weight per request (default: DefaultMonitorErrorDecay)
+	UpdateInterval time.Duration // Minimum time between governor updates (default: DefaultMiddlewareUpdateInterval)
+	Alpha, Beta    float64       // USL coefficients reported by the governor
+
+	once       sync.Once
+	mu         sync.Mutex // Guards the fields below
+	errorRate  float64
+	r          float64
+	lastAction Action
+	lastUpdate time.Time
+}
+
+// NewMonitor creates a monitor with default estimator and governor.
+func NewMonitor() *Monitor {
+	return &Monitor{}
+}
+
+// Observe records one request's latency and error and returns the
+// governor's decision, ready for Admit. The governor is updated with the
+// current r at most once per UpdateInterval; in between Observe returns the
+// last decision.
+//
+// A failed request's latency is recorded too: a timeout is exactly the
+// tail the estimator should see.
+func (m *Monitor) Observe(latency time.Duration, err error) Action {
+	m.once.Do(m.init)
+
+	failed := err != nil
+	if failed && m.ClassifyError(err) == ErrorCancelled {
+		return m.LastAction()
+	}
+
+	m.Estimator.Record(latency)
+
+	outcome := 0.0
+	if failed {
+		outcome = 1
+	}
+
+	now := time.Now()
+	m.mu.Lock()
+	m.errorRate += m.ErrorDecay * (outcome - m.errorRate)
+	errorRate := m.errorRate
+	if !m.lastUpdate.IsZero() && now.Sub(m.lastUpdate) < m.UpdateInterval {
+		defer m.mu.Unlock()
+		return m.lastAction
+	}
+	m.lastUpdate = now
+	m.mu.Unlock()
+
+	r := m.Estimator.EstimateR() + ErrorRWeight*errorRate
+
+	action := m.Governor.Update(r, m.Alpha, m.Beta, 0)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.r, m.lastAction = r, action
+	return action
+}
+
+// R returns the r behind the last decision.
+func (m *Monitor) R() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.r
+}
+
+// Velocity returns Δr/Δt (per second) at the last decision.
+func (m *Monitor) Velocity() float64 {
+	m.once.Do(m.init)
+
+	return m.Governor.currentVelocity()
+}
+
+// ErrorRate returns the recent fraction of failed requests.
+func (m *Monitor) ErrorRate() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.errorRate
+}
+
+// LastAction returns the governor's most recent decision, for status
+// endpoints.
+func (m *Monitor) LastAction() Action {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastAction
+}
+
+// init fills in defaults for unset fields.
+func (m *Monitor) init() {
+	if m.Estimator == nil {
+		m.Estimator = NewTailDivergenceTrackerWithWindow(DefaultMiddlewareSamples, DefaultMiddlewareWindow)
+	}
+	if m.Governor == nil {
+		m.Governor = NewGovernor(1.5)
+	}
+	if m.ClassifyError == nil {
+		m.ClassifyError = ClassifyError
+	}
+	if m.ErrorDecay <= 0 || m.ErrorDecay > 1 {
+		m.ErrorDecay = DefaultMonitorErrorDecay
+	}
+	if m.UpdateInterval == 0 {
+		m.UpdateInterval = DefaultMiddlewareUpdateInterval
+	}
+}
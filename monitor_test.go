@@ -0,0 +1,107 @@
+package lawbench
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestMonitor_GrowingLatencyEscalates verifies a stream whose latency tail
+// keeps growing drives the monitor from STABLE through the warning zones to
+// THROTTLE, never de-escalating on the way.
+func TestMonitor_GrowingLatencyEscalates(t *testing.T) {
+	severity := map[ActionType]int{ActionStable: 0, ActionWarning: 1, ActionPacing: 2, ActionThrottle: 3}
+
+	m := &Monitor{UpdateInterval: time.Nanosecond} // Update on every observation
+	var seen []ActionType
+	for i := 0; i < 3000; i++ {
+		latency := 10 * time.Millisecond
+		if i%50 == 0 {
+			latency += time.Duration(i/10) * time.Millisecond // 2% tail, growing
+		}
+
+		action := m.Observe(latency, nil)
+		if _, ok := severity[action.Type]; !ok {
+			t.Fatalf("Unexpected %s at sample %d", action.Type, i)
+		}
+		if len(seen) == 0 || seen[len(seen)-1] != action.Type {
+			if len(seen) > 0 && severity[action.Type] < severity[seen[len(seen)-1]] {
+				t.Errorf("De-escalated %s → %s at sample %d", seen[len(seen)-1], action.Type, i)
+			}
+			seen = append(seen, action.Type)
+		}
+	}
+
+	if seen[0] != ActionStable || seen[len(seen)-1] != ActionThrottle {
+		t.Fatalf("Expected STABLE … THROTTLE, got %v", seen)
+	}
+	if len(seen) < 3 {
+		t.Errorf("Expected an intermediate zone before THROTTLE, got %v", seen)
+	}
+	if m.R() < 3.0 || m.LastAction().Type != ActionThrottle {
+		t.Errorf("Expected r ≥ 3.0 at the end, got %.4f (%s)", m.R(), m.LastAction().Type)
+	}
+
+	t.Logf("✓ %v (final r=%.4f)", seen, m.R())
+}
+
+// TestMonitor_Errors verifies failures raise r through the error rate while
+// cancellations are ignored.
+func TestMonitor_Errors(t *testing.T) {
+	m := &Monitor{UpdateInterval: time.Nanosecond}
+	for i := 0; i < 200; i++ {
+		m.Observe(10*time.Millisecond, context.Canceled)
+	}
+	if m.ErrorRate() != 0 || m.LastAction().Type != "" {
+		t.Fatalf("Cancellations should be ignored, got error rate %.4f, action %q", m.ErrorRate(), m.LastAction().Type)
+	}
+
+	for i := 0; i < 200; i++ {
+		m.Observe(10*time.Millisecond, nil)
+	}
+	healthy := m.R()
+
+	for i := 0; i < 200; i++ {
+		m.Observe(10*time.Millisecond, errors.New("upstream unavailable"))
+	}
+	if m.ErrorRate() < 0.8 {
+		t.Errorf("Error rate %.4f should approach 1 after 200 failures", m.ErrorRate())
+	}
+	if want := healthy + ErrorRWeight*m.ErrorRate(); m.R() < want-1e-9 || m.R() < 3.0 {
+		t.Errorf("r = %.4f, want ≈ %.4f (≥ 3.0) with failing requests", m.R(), want)
+	}
+	if m.Velocity() != m.Governor.velocity {
+		t.Errorf("Velocity %.4f doesn't match the governor", m.Velocity())
+	}
+
+	t.Logf("✓ r %.4f → %.4f at error rate %.2f", healthy, m.R(), m.ErrorRate())
+}
+
+// TestMonitor_TimeoutsRaiseR verifies requests failing with their own
+// deadline are recorded, latency and error both, and push r up.
+func TestMonitor_TimeoutsRaiseR(t *testing.T) {
+	m := &Monitor{UpdateInterval: time.Nanosecond}
+	for i := 0; i < 500; i++ {
+		m.Observe(10*time.Millisecond, nil)
+	}
+	healthy := m.R()
+
+	for i := 0; i < 500; i++ {
+		if i%10 == 0 {
+			m.Observe(time.Second, context.DeadlineExceeded) // Request timeout
+		} else {
+			m.Observe(10*time.Millisecond, nil)
+		}
+	}
+
+	if m.ErrorRate() == 0 {
+		t.Fatal("Timeouts should count as failures")
+	}
+	if m.R() <= healthy+ErrorRWeight*m.ErrorRate() {
+		t.Errorf("r = %.4f should exceed %.4f + the error term: the timeouts' latency is the tail",
+			m.R(), healthy)
+	}
+
+	t.Logf("✓ r %.4f → %.4f with 10%% timeouts (error rate %.2f)", healthy, m.R(), m.ErrorRate())
+}
@@ -1,6 +1,7 @@
 package lawbench
 
 import (
+	"fmt"
 	"math"
 	"sort"
 	"sync"
@@ -40,19 +41,54 @@ import (
 //	    // r likely ≥ 3.0 (saturation)
 //	}
 type TailDivergenceTracker struct {
+	// Regime thresholds on the P99/P50 ratio (set before use). Zero values
+	// fall back to DefaultGaussianMaxRatio and DefaultPowerLawMinRatio.
+	GaussianMaxRatio float64 // Below this: Gaussian (IsGaussian)
+	PowerLawMinRatio float64 // Above this: Power Law (IsPowerLaw)
+
+	// Interpolate makes ring-buffer percentiles interpolate between adjacent
+	// samples (see PercentileInterpolated) instead of indexing sorted[(n−1)·p].
+	// Set before use; sketch recorders ignore it.
+	Interpolate bool
+
 	mu          sync.RWMutex
 	samples     []time.Duration // Ring buffer of recent latencies
 	maxSamples  int             // Buffer size
 	writeIndex  int             // Next write position
 	sampleCount int64           // Total samples recorded (monotonic)
 
+	// Optional sketch replacing the ring buffer (nil = exact ring buffer)
+	recorder LatencyRecorder
+
+	// Time-decay mode (window > 0): samples older than window are ignored
+	window     time.Duration
+	timestamps []time.Time      // Parallel to samples
+	now        func() time.Time // Clock (time.Now unless overridden in tests)
+
 	// Cached percentiles (invalidated on write)
-	cachedP50  time.Duration
-	cachedP99  time.Duration
-	cachedP999 time.Duration
-	cacheValid bool
+	sorted       []time.Duration // Sorted copy of the buffer, valid while cacheValid
+	cachedP50    time.Duration
+	cachedP99    time.Duration
+	cachedP999   time.Duration
+	cachedModes  int     // Number of latency modes in the cache
+	modeRatio    float64 // Largest within-mode P99/P50 (when cachedModes > 1)
+	cacheValid   bool
+	cacheExpires time.Time // Window mode: when the oldest cached sample ages out
+	sorts        int       // Number of cache rebuilds (for tests/benchmarks)
+
+	// Recorder mode: evenly spaced sketch quantiles standing in for the
+	// sorted buffer (see sketchSample)
+	sketch        []time.Duration
+	sketchCount   int64     // Recorder count when sketch was built
+	sketchBuiltAt time.Time // Zero = no sketch cached
 }
 
+// Default regime thresholds on the P99/P50 ratio.
+const (
+	DefaultGaussianMaxRatio = 3.0
+	DefaultPowerLawMinRatio = 10.0
+)
+
 // NewTailDivergenceTracker creates a tracker with a fixed-size ring buffer.
 //
 // The buffer size determines the time window for percentile calculation:
@@ -67,8 +103,44 @@ func NewTailDivergenceTracker(maxSamples int) *TailDivergenceTracker {
 	}
 
 	return &TailDivergenceTracker{
-		samples:    make([]time.Duration, maxSamples),
-		maxSamples: maxSamples,
+		GaussianMaxRatio: DefaultGaussianMaxRatio,
+		PowerLawMinRatio: DefaultPowerLawMinRatio,
+		samples:          make([]time.Duration, maxSamples),
+		maxSamples:       maxSamples,
+	}
+}
+
+// NewTailDivergenceTrackerWithWindow creates a ring-buffer tracker that also
+// ignores samples older than window.
+//
+// A count-based buffer keeps reporting black swans from a past saturation
+// spike until enough new samples push them out. With a window, they age out
+// by time instead, so EstimateR tracks recovery promptly even at low
+// traffic. maxSamples still bounds memory.
+func NewTailDivergenceTrackerWithWindow(maxSamples int, window time.Duration) *TailDivergenceTracker {
+	t := NewTailDivergenceTracker(maxSamples)
+	t.window = window
+	t.timestamps = make([]time.Time, t.maxSamples)
+	t.now = time.Now
+	return t
+}
+
+// NewTailDivergenceTrackerWithRecorder creates a tracker backed by a
+// LatencyRecorder instead of the exact ring buffer.
+//
+// With a TDigestRecorder, Record is amortized O(1) and percentile queries
+// are O(log n) without retaining raw samples, which suits per-request
+// queries at high traffic. Percentiles are approximate (within the
+// recorder's accuracy) and cover every sample recorded, not a fixed window.
+//
+// Example:
+//
+//	tracker := NewTailDivergenceTrackerWithRecorder(NewTDigestRecorder(100))
+func NewTailDivergenceTrackerWithRecorder(recorder LatencyRecorder) *TailDivergenceTracker {
+	return &TailDivergenceTracker{
+		GaussianMaxRatio: DefaultGaussianMaxRatio,
+		PowerLawMinRatio: DefaultPowerLawMinRatio,
+		recorder:         recorder,
 	}
 }
 
@@ -80,12 +152,45 @@ func (t *TailDivergenceTracker) Record(latency time.Duration) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	if t.recorder != nil {
+		t.recorder.Record(latency)
+		t.sampleCount++
+		return
+	}
+
 	t.samples[t.writeIndex] = latency
+	if t.window > 0 {
+		t.timestamps[t.writeIndex] = t.now()
+	}
 	t.writeIndex = (t.writeIndex + 1) % t.maxSamples
 	t.sampleCount++
 	t.cacheValid = false // Invalidate cache
 }
 
+// Reset discards all samples, e.g. after a deploy or once an incident is
+// resolved, so old black swans stop skewing the tail ratio.
+//
+// Built-in recorders (Slice, HDR, TDigest) are replaced by empty ones with
+// the same configuration; custom recorders are left untouched.
+func (t *TailDivergenceTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if r, ok := t.recorder.(interface{ fresh() LatencyRecorder }); ok {
+		t.recorder = r.fresh()
+	}
+	for i := range t.samples {
+		t.samples[i] = 0
+	}
+	for i := range t.timestamps {
+		t.timestamps[i] = time.Time{}
+	}
+	t.writeIndex = 0
+	t.sampleCount = 0
+	t.cacheValid = false
+	t.sketchBuiltAt = time.Time{}
+}
+
 // TailDivergenceRatio returns P99/P50 (tail divergence ratio).
 //
 // Interpretation:
@@ -133,15 +238,44 @@ func (t *TailDivergenceTracker) Mean() time.Duration {
 	if t.sampleCount == 0 {
 		return 0
 	}
+	if t.recorder != nil {
+		return t.recorder.Mean()
+	}
 
-	var sum int64
+	var sum, live int64
 	effectiveSamples := t.effectiveSampleCount()
+	cutoff := t.windowCutoff()
 
 	for i := 0; i < effectiveSamples; i++ {
+		if t.window > 0 && t.timestamps[i].Before(cutoff) {
+			continue // Aged out
+		}
 		sum += int64(t.samples[i])
+		live++
 	}
 
-	return time.Duration(sum / int64(effectiveSamples))
+	if live == 0 {
+		return 0
+	}
+	return time.Duration(sum / live)
+}
+
+// ReliableCentralMetric returns the one latency number a dashboard can
+// trust, and which statistic it is: the mean ("mean") while the distribution
+// is Gaussian, otherwise the median ("p50"), since outside the Gaussian
+// regime a few black swans dominate the average.
+//
+// warning is non-empty when the tail is so heavy (ParetoIndex ≤ 2, infinite
+// variance) that even the median understates the risk: read it next to P99.
+func (t *TailDivergenceTracker) ReliableCentralMetric() (value time.Duration, which string, warning string) {
+	if t.IsGaussian() {
+		return t.Mean(), "mean", ""
+	}
+
+	if alpha := t.ParetoIndex(); alpha > 0 && alpha <= 2 {
+		warning = fmt.Sprintf("Pareto index %.2f ≤ 2 (infinite variance): median understates risk, check P99 (%v)", alpha, t.P99())
+	}
+	return t.P50(), "p50", warning
 }
 
 // ParetoIndex estimates the Pareto α parameter (if distribution is Power Law).
@@ -154,6 +288,9 @@ func (t *TailDivergenceTracker) Mean() time.Duration {
 //   - α ≈ 1.16: The famous 80/20 rule (Pareto Index)
 //
 // If α ≤ 2, your system has INFINITE VARIANCE - saturation.
+//
+// This is the quick-and-dirty path: two quantiles are noisy. Use
+// ParetoIndexHill for a statistically sound estimate.
 func (t *TailDivergenceTracker) ParetoIndex() float64 {
 	p50 := t.P50()
 	p99 := t.P99()
@@ -175,79 +312,614 @@ func (t *TailDivergenceTracker) ParetoIndex() float64 {
 	return alpha
 }
 
+// ParetoIndexHill estimates the Pareto α with the Hill estimator over the
+// top-k order statistics of the buffer:
+//
+//	α̂ = k / Σᵢ₌₁ᵏ (ln x₍ᵢ₎ − ln x₍ₖ₊₁₎)
+//
+// where x₍₁₎ ≥ x₍₂₎ ≥ ... are the samples in descending order. The standard
+// error is roughly α/√k, so larger k trades bias for variance. k ≤ 0 uses
+// the top 10% of the buffer. Returns 0 if there are too few positive samples.
+//
+// A recorder-backed tracker runs the estimator over its cached sketch
+// sample (at most 1000 quantiles), with k scaled from recorded samples to
+// sketch points, so the cost does not grow with the recorder's count.
+//
+// Unlike ParetoIndex (which only looks at P99/P50), this is a consistent
+// estimator: on true Pareto data it converges to α as k grows.
+func (t *TailDivergenceTracker) ParetoIndexHill(k int) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var sorted []time.Duration
+	if t.recorder != nil {
+		// The sketch sample stands in for the order statistics; k counts
+		// recorded samples, so scale it to the sketch's resolution
+		count := int(t.recorder.Count())
+		sorted = t.sketchSample()
+		if k > 0 && count > len(sorted) {
+			k = max(1, int(math.Round(float64(k)*float64(len(sorted))/float64(count))))
+		}
+	} else {
+		if !t.cacheFresh() {
+			t.rebuildCache()
+		}
+		sorted = t.sorted
+	}
+	n := len(sorted)
+
+	if k <= 0 {
+		k = n / 10
+	}
+	if k >= n {
+		k = n - 1
+	}
+	if k < 1 {
+		return 0
+	}
+
+	threshold := sorted[n-1-k] // x₍ₖ₊₁₎
+	if threshold <= 0 {
+		return 0 // ln undefined
+	}
+	logThreshold := math.Log(float64(threshold))
+
+	var sum float64
+	for i := n - k; i < n; i++ {
+		sum += math.Log(float64(sorted[i])) - logThreshold
+	}
+	if sum <= 0 {
+		return 0 // Flat tail (all top-k equal)
+	}
+
+	return float64(k) / sum
+}
+
 // IsGaussian returns true if distribution looks Gaussian (stable system).
 //
-// Heuristic: P99/P50 < 3 suggests Gaussian behavior.
+// Heuristic: P99/P50 < GaussianMaxRatio (default 3) suggests Gaussian behavior.
+// For a multimodal distribution the ratio is taken within each mode (see IsBimodal).
 func (t *TailDivergenceTracker) IsGaussian() bool {
-	return t.TailDivergenceRatio() < 3.0
+	gaussianMax, _ := t.thresholds()
+	return t.regimeRatio() < gaussianMax
 }
 
 // IsPowerLaw returns true if distribution looks like a Power Law (saturation).
 //
-// Heuristic: P99/P50 > 10 suggests Power Law behavior.
+// Heuristic: P99/P50 > PowerLawMinRatio (default 10) suggests Power Law behavior.
+// Distinct latency modes (a cache with fast hits and slow misses) are judged
+// by the divergence within each mode, not between them (see IsBimodal).
+// Raise the threshold for workloads with inherently wide but healthy spreads.
 func (t *TailDivergenceTracker) IsPowerLaw() bool {
-	return t.TailDivergenceRatio() > 10.0
+	_, powerLawMin := t.thresholds()
+	return t.regimeRatio() > powerLawMin
+}
+
+// IsBimodal reports whether latencies form two or more distinct modes, such
+// as cache hits and misses.
+//
+// P99/P50 can't tell a heavy tail from a clean gap between modes: a healthy
+// 70/30 cache with 1ms hits and 50ms misses has a ratio of 50 and would read
+// as deep saturation. When IsBimodal is true, IsGaussian, IsPowerLaw and
+// EstimateR use the largest P99/P50 within a single mode instead, so a slow
+// mode that grows a heavy tail is still caught.
+func (t *TailDivergenceTracker) IsBimodal() bool {
+	return t.ModeCount() > 1
+}
+
+// ModeCount returns the number of latency modes: peaks of a log-scale
+// histogram that hold at least 10% of the samples, rise to at least a
+// quarter of the tallest peak and are separated by a valley below half the
+// smaller peak. Black swans spread over decades are too flat to count.
+// Returns 1 for unimodal (or too few) samples and 0 when empty.
+func (t *TailDivergenceTracker) ModeCount() int {
+	modes, _ := t.modeStats()
+	return modes
+}
+
+// regimeRatio is the tail ratio used for regime decisions: P99/P50, or the
+// largest within-mode ratio when the distribution is multimodal.
+func (t *TailDivergenceTracker) regimeRatio() float64 {
+	if modes, ratio := t.modeStats(); modes > 1 {
+		return ratio
+	}
+	return t.TailDivergenceRatio()
+}
+
+// modeStats returns the mode count and the largest within-mode P99/P50.
+func (t *TailDivergenceTracker) modeStats() (modes int, ratio float64) {
+	if t.recorder != nil {
+		t.mu.Lock() // Sketches may compact on read
+		defer t.mu.Unlock()
+
+		t.sketchSample()
+		return t.cachedModes, t.modeRatio
+	}
+
+	t.mu.RLock()
+	if t.cacheFresh() {
+		defer t.mu.RUnlock()
+		return t.cachedModes, t.modeRatio
+	}
+	t.mu.RUnlock()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.cacheFresh() {
+		t.rebuildCache()
+	}
+	return t.cachedModes, t.modeRatio
+}
+
+// Mode detection parameters.
+const (
+	modeHistogramBins = 20    // Log-scale bins between min and max
+	modeMinSamples    = 50    // Fewer samples are always one mode
+	modeMinFraction   = 0.10  // A mode must hold ≥ 10% of samples...
+	modeMinPeak       = 0.25  // ...and peak at ≥ 25% of the tallest (a spread-out tail is not a mode)
+	modeValleyRatio   = 0.5   // Valley must dip below half the smaller peak
+	modeSketchPoints  = 1000. // Quantiles sampled from a sketch recorder
+)
+
+// Refresh policy for the sketch sample of a recorder-backed tracker: the
+// modes of a large sketch barely move per sample, so it is rebuilt only
+// once the count has grown (or shrunk) by sketchRefreshGrowth or the cache
+// is sketchRefreshTTL old.
+const (
+	sketchRefreshGrowth = 0.01
+	sketchRefreshTTL    = 100 * time.Millisecond
+)
+
+// sketchSample returns up to modeSketchPoints evenly spaced quantiles of the
+// recorder, ascending, as a pseudo-sample, and keeps cachedModes and
+// modeRatio current for it. Caller must hold the write lock.
+func (t *TailDivergenceTracker) sketchSample() []time.Duration {
+	count := t.recorder.Count()
+	now := t.clock()
+	if !t.sketchBuiltAt.IsZero() && now.Sub(t.sketchBuiltAt) < sketchRefreshTTL &&
+		math.Abs(float64(count-t.sketchCount)) <= sketchRefreshGrowth*float64(t.sketchCount) {
+		return t.sketch
+	}
+
+	n := int(math.Min(float64(count), modeSketchPoints))
+	t.sketch = t.sketch[:0]
+	for i := 0; i < n; i++ {
+		t.sketch = append(t.sketch, t.recorder.Percentile((float64(i)+0.5)/float64(n)))
+	}
+	t.cachedModes, t.modeRatio = latencyModes(t.sketch)
+	t.sketchCount, t.sketchBuiltAt = count, now
+	t.sorts++
+	return t.sketch
+}
+
+// clock returns the tracker's current time.
+func (t *TailDivergenceTracker) clock() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
+
+// latencyModes finds the modes of sorted latencies and returns their count
+// and the largest P99/P50 within any one mode.
+func latencyModes(sorted []time.Duration) (modes int, ratio float64) {
+	n := len(sorted)
+	if n == 0 {
+		return 0, 1
+	}
+	lo, hi := math.Log(math.Max(float64(sorted[0]), 1)), math.Log(math.Max(float64(sorted[n-1]), 1))
+	if n < modeMinSamples || hi-lo < 1e-9 {
+		return 1, modeTailRatio(sorted)
+	}
+
+	// Histogram of log latency, smoothed over 3 bins to suppress noise peaks
+	width := (hi - lo) / modeHistogramBins
+	bin := func(d time.Duration) int {
+		b := int((math.Log(math.Max(float64(d), 1)) - lo) / width)
+		return min(b, modeHistogramBins-1)
+	}
+	var counts, smooth [modeHistogramBins]float64
+	for _, d := range sorted {
+		counts[bin(d)]++
+	}
+	for i := range smooth {
+		smooth[i] = counts[i]
+		weight := 1.0
+		if i > 0 {
+			smooth[i] += counts[i-1]
+			weight++
+		}
+		if i < modeHistogramBins-1 {
+			smooth[i] += counts[i+1]
+			weight++
+		}
+		smooth[i] /= weight
+	}
+
+	// One region per local maximum, split at the lowest bin between peaks
+	type region struct {
+		end        int // Exclusive bin bound
+		peak, mass float64
+	}
+	var regions []region
+	valley := 0
+	for i := 0; i < modeHistogramBins; i++ {
+		if smooth[i] < smooth[valley] {
+			valley = i
+		}
+		rising := i == 0 || smooth[i] > smooth[i-1]
+		falling := i == modeHistogramBins-1 || smooth[i] >= smooth[i+1]
+		if !rising || !falling || smooth[i] == 0 {
+			continue
+		}
+		if len(regions) > 0 {
+			regions[len(regions)-1].end = valley + 1
+		}
+		regions = append(regions, region{end: modeHistogramBins})
+		valley = i
+	}
+	for i, b := 0, 0; i < len(regions); i++ {
+		for ; b < regions[i].end; b++ {
+			regions[i].mass += counts[b]
+			regions[i].peak = math.Max(regions[i].peak, smooth[b])
+		}
+	}
+
+	// Merge neighbours that are too small, too flat or too shallowly
+	// separated, weakest separation first
+	var tallest float64
+	for _, r := range regions {
+		tallest = math.Max(tallest, r.peak)
+	}
+	weak := func(r region) bool {
+		return r.mass < modeMinFraction*float64(n) || r.peak < modeMinPeak*tallest
+	}
+	for len(regions) > 1 {
+		merge, weakest := -1, -1.0
+		for i := 0; i+1 < len(regions); i++ {
+			a, b := regions[i], regions[i+1]
+			depth := smooth[a.end-1] / math.Min(a.peak, b.peak)
+			if weak(a) || weak(b) {
+				depth += 1 // Always merge, but prefer true shallow valleys first
+			}
+			if depth > weakest {
+				merge, weakest = i, depth
+			}
+		}
+		if weakest <= modeValleyRatio {
+			break
+		}
+		a, b := regions[merge], regions[merge+1]
+		regions[merge] = region{end: b.end, peak: math.Max(a.peak, b.peak), mass: a.mass + b.mass}
+		regions = append(regions[:merge+1], regions[merge+2:]...)
+	}
+
+	// Largest tail ratio within a single mode
+	ratio, first := 1.0, 0
+	for _, r := range regions {
+		edge := time.Duration(math.Exp(lo + width*float64(r.end)))
+		last := first + sort.Search(n-first, func(i int) bool { return sorted[first+i] >= edge })
+		if r.end == modeHistogramBins {
+			last = n
+		}
+		if last > first {
+			ratio = math.Max(ratio, modeTailRatio(sorted[first:last]))
+		}
+		first = last
+	}
+
+	return len(regions), ratio
+}
+
+// modeTailRatio returns P99/P50 of sorted latencies, indexed like sortedPercentile.
+func modeTailRatio(sorted []time.Duration) float64 {
+	n := len(sorted)
+	p50, p99 := sorted[int(float64(n-1)*0.50)], sorted[int(float64(n-1)*0.99)]
+	if p50 <= 0 {
+		return 1
+	}
+	return float64(p99) / float64(p50)
+}
+
+// RetryPolicy is a client retry budget derived from the latency regime.
+type RetryPolicy struct {
+	MaxRetries  int           // Retries after the first attempt (0 = don't retry)
+	BaseBackoff time.Duration // Wait before the first retry; double it for each next one
+	Jitter      float64       // Randomize each wait by ±Jitter × wait (1.0 = full jitter)
+	EstimatedR  float64       // r the policy was derived from
+	TailRatio   float64       // P99/P50 the policy was derived from
+}
+
+// DefaultRetryBaseBackoff is the base backoff before any latency is recorded.
+const DefaultRetryBaseBackoff = 100 * time.Millisecond
+
+// RecommendedRetryPolicy returns how aggressively clients should retry.
+//
+// Retries are load: in a Gaussian regime they cheaply mask transient
+// failures, but once the tail turns power-law every retry of a black-swan
+// request adds to the saturation that produced it. The budget shrinks as r
+// rises and is zero from r ≥ 3.0:
+//
+//	r < 2.0        3 retries, backoff P99,   jitter 0.2
+//	2.0 ≤ r < 2.5  2 retries, backoff 2·P99, jitter 0.5
+//	2.5 ≤ r < 3.0  1 retry,   backoff 4·P99, jitter 1.0
+//	r ≥ 3.0        no retries
+//
+// Waiting at least P99 means a retry rarely overlaps a still-running slow
+// attempt; rising jitter spreads synchronized clients apart.
+func (t *TailDivergenceTracker) RecommendedRetryPolicy() RetryPolicy {
+	policy := RetryPolicy{
+		EstimatedR: t.EstimateR(),
+		TailRatio:  t.TailDivergenceRatio(),
+	}
+
+	base := t.P99()
+	if base == 0 {
+		base = DefaultRetryBaseBackoff
+	}
+
+	switch r := policy.EstimatedR; {
+	case r >= StableDNAConstraint.MaxR:
+		// Black-swan regime: stop retrying
+	case r >= 2.5:
+		policy.MaxRetries, policy.BaseBackoff, policy.Jitter = 1, 4*base, 1.0
+	case r >= 2.0:
+		policy.MaxRetries, policy.BaseBackoff, policy.Jitter = 2, 2*base, 0.5
+	default:
+		policy.MaxRetries, policy.BaseBackoff, policy.Jitter = 3, base, 0.2
+	}
+
+	return policy
+}
+
+// thresholds returns the regime thresholds, applying defaults for zero values.
+func (t *TailDivergenceTracker) thresholds() (gaussianMax, powerLawMin float64) {
+	gaussianMax, powerLawMin = t.GaussianMaxRatio, t.PowerLawMinRatio
+	if gaussianMax <= 0 {
+		gaussianMax = DefaultGaussianMaxRatio
+	}
+	if powerLawMin <= 0 {
+		powerLawMin = DefaultPowerLawMinRatio
+	}
+	return gaussianMax, powerLawMin
 }
 
 // EstimateR estimates the r-parameter from tail divergence.
 //
-// Mapping:
-//   - TailRatio < 3:    r ≈ 1.5-2.0 (Gaussian, stable)
-//   - TailRatio 3-10:   r ≈ 2.5-3.0 (Transitioning)
-//   - TailRatio > 10:   r ≥ 3.0 (Power Law, saturation)
-//   - TailRatio > 100:  r ≥ 4.0 (Extreme saturation)
+// Mapping (with default thresholds G=3, P=10):
+//   - TailRatio < G:    r ≈ 1.5-2.0 (Gaussian, stable)
+//   - TailRatio G-P:    r ≈ 2.0-3.0 (Transitioning)
+//   - TailRatio > P:    r ≥ 3.0 (Power Law, saturation)
+//   - TailRatio > 10P:  r ≥ 4.0 (Extreme saturation)
+//
+// The breakpoints follow GaussianMaxRatio and PowerLawMinRatio, so r crosses
+// 3.0 exactly where IsPowerLaw turns true.
 //
 // This is an empirical mapping. For precise r, use USL coefficients.
 func (t *TailDivergenceTracker) EstimateR() float64 {
-	ratio := t.TailDivergenceRatio()
+	gaussianMax, powerLawMin := t.thresholds()
+	return estimateRFromRatio(t.regimeRatio(), gaussianMax, powerLawMin)
+}
+
+// DefaultMinRConfidence is a suggested floor for EstimateRWithConfidence:
+// below it the estimate is closer to a guess than a measurement, and
+// governors or autoscalers should hold their previous decision instead.
+const DefaultMinRConfidence = 0.5
+
+// confidenceHalfSamples is the sample count at which the sample-size factor
+// of EstimateRWithConfidence reaches 0.5. P99 is a single order statistic
+// until there are ~100 samples.
+const confidenceHalfSamples = 100
+
+// EstimateRWithConfidence is EstimateR with a confidence in [0, 1].
+//
+// Confidence is the product of two factors:
+//   - Sample size: n/(n+100) over the samples the percentiles come from, so
+//     10 samples give ≈0.09, 100 give 0.5 and 1000 give ≈0.91.
+//   - Regime fit: how far the tail ratio sits from the Gaussian and power-law
+//     breakpoints on a log scale. A ratio right at a breakpoint could belong
+//     to either neighbouring zone (0.5); one at least half the transition
+//     zone's width away is unambiguous (1.0).
+//
+// With no samples confidence is 0. See DefaultMinRConfidence for a
+// threshold.
+func (t *TailDivergenceTracker) EstimateRWithConfidence() (r float64, confidence float64) {
+	gaussianMax, powerLawMin := t.thresholds()
+	ratio := t.regimeRatio()
+	r = estimateRFromRatio(ratio, gaussianMax, powerLawMin)
+
+	n := float64(t.liveSamples())
+	if n == 0 {
+		return r, 0
+	}
+	sampleFactor := n / (n + confidenceHalfSamples)
+
+	return r, sampleFactor * regimeFit(ratio, gaussianMax, powerLawMin)
+}
+
+// regimeFit scores how unambiguously ratio falls in one regime zone: 0.5 at
+// a breakpoint, rising linearly in log-ratio to 1.0 at half the width of the
+// transition zone away from the nearest one.
+func regimeFit(ratio, gaussianMax, powerLawMin float64) float64 {
+	if ratio <= 0 {
+		return 0.5
+	}
+
+	halfWidth := math.Log(powerLawMin/gaussianMax) / 2
+	if halfWidth <= 0 {
+		return 1
+	}
+	distance := math.Min(
+		math.Abs(math.Log(ratio/gaussianMax)),
+		math.Abs(math.Log(ratio/powerLawMin)),
+	)
+	return 0.5 + 0.5*math.Min(distance/halfWidth, 1)
+}
+
+// liveSamples returns how many samples the percentiles are computed from:
+// the recorder's count, the in-window samples, or the filled ring buffer.
+func (t *TailDivergenceTracker) liveSamples() int {
+	if t.window > 0 {
+		t.P50() // Rebuilds the cache, dropping aged-out samples
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 
 	switch {
-	case ratio < 3.0:
+	case t.recorder != nil:
+		return int(t.recorder.Count())
+	case t.window > 0:
+		return len(t.sorted)
+	default:
+		return t.effectiveSampleCount()
+	}
+}
+
+// estimateRFromRatio is the empirical tail ratio → r mapping (monotonic),
+// piecewise linear between the regime breakpoints.
+func estimateRFromRatio(ratio, gaussianMax, powerLawMin float64) float64 {
+	extreme := 10 * powerLawMin
+
+	switch {
+	case ratio < gaussianMax:
 		// Gaussian regime
-		return 1.5 + (ratio/3.0)*0.5 // 1.5 → 2.0
+		return 1.5 + (ratio/gaussianMax)*0.5 // 1.5 → 2.0
 
-	case ratio < 10.0:
+	case ratio < powerLawMin:
 		// Transition zone
-		return 2.0 + ((ratio-3.0)/7.0)*1.0 // 2.0 → 3.0
+		return 2.0 + ((ratio-gaussianMax)/(powerLawMin-gaussianMax))*1.0 // 2.0 → 3.0
 
-	case ratio < 100.0:
+	case ratio < extreme:
 		// Power Law regime
-		return 3.0 + ((ratio-10.0)/90.0)*1.0 // 3.0 → 4.0
+		return 3.0 + ((ratio-powerLawMin)/(extreme-powerLawMin))*1.0 // 3.0 → 4.0
 
 	default:
 		// Extreme saturation
-		return 4.0 + math.Min((ratio-100.0)/100.0, 1.0) // 4.0 → 5.0
+		return 4.0 + math.Min((ratio-extreme)/extreme, 1.0) // 4.0 → 5.0
 	}
 }
 
 // percentile calculates the p-th percentile (0 < p < 1).
+//
+// The buffer is sorted at most once per write: the first read after a Record
+// rebuilds the cache and every later read is served from it.
 func (t *TailDivergenceTracker) percentile(p float64) time.Duration {
+	if t.recorder != nil {
+		t.mu.Lock() // Sketches may compact on read
+		defer t.mu.Unlock()
+		return t.recorder.Percentile(p)
+	}
+
+	t.mu.RLock()
+	if t.cacheFresh() {
+		v := t.cachedPercentile(p)
+		t.mu.RUnlock()
+		return v
+	}
+	t.mu.RUnlock()
+
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	if !t.cacheFresh() { // Another reader may have rebuilt it while we waited
+		t.rebuildCache()
+	}
+	return t.cachedPercentile(p)
+}
+
+// cacheFresh reports whether the cache can be served: no write since it was
+// built and, in window mode, no cached sample has aged out.
+func (t *TailDivergenceTracker) cacheFresh() bool {
+	if !t.cacheValid {
+		return false
+	}
+	return t.window <= 0 || t.now().Before(t.cacheExpires)
+}
+
+// windowCutoff returns the timestamp before which samples are ignored
+// (zero time when there is no window).
+func (t *TailDivergenceTracker) windowCutoff() time.Time {
+	if t.window <= 0 {
+		return time.Time{}
+	}
+	return t.now().Add(-t.window)
+}
+
+// rebuildCache sorts a copy of the buffer and refreshes the cached
+// percentiles. Caller must hold the write lock.
+func (t *TailDivergenceTracker) rebuildCache() {
 	effectiveSamples := t.effectiveSampleCount()
-	if effectiveSamples == 0 {
+
+	// Copy and sort samples (reusing the previous buffer)
+	if t.window > 0 {
+		cutoff := t.windowCutoff()
+		oldest := time.Time{}
+		t.sorted = t.sorted[:0]
+		for i := 0; i < effectiveSamples; i++ {
+			ts := t.timestamps[i]
+			if ts.Before(cutoff) {
+				continue // Aged out
+			}
+			t.sorted = append(t.sorted, t.samples[i])
+			if oldest.IsZero() || ts.Before(oldest) {
+				oldest = ts
+			}
+		}
+		t.cacheExpires = oldest.Add(t.window)
+	} else {
+		t.sorted = append(t.sorted[:0], t.samples[:effectiveSamples]...)
+	}
+	sort.Slice(t.sorted, func(i, j int) bool {
+		return t.sorted[i] < t.sorted[j]
+	})
+	t.sorts++
+
+	t.cachedP50 = t.sortedPercentile(0.50)
+	t.cachedP99 = t.sortedPercentile(0.99)
+	t.cachedP999 = t.sortedPercentile(0.999)
+	t.cachedModes, t.modeRatio = latencyModes(t.sorted)
+	t.cacheValid = true
+}
+
+// cachedPercentile serves p from the cache. Caller must hold a lock and the
+// cache must be valid.
+func (t *TailDivergenceTracker) cachedPercentile(p float64) time.Duration {
+	switch p {
+	case 0.50:
+		return t.cachedP50
+	case 0.99:
+		return t.cachedP99
+	case 0.999:
+		return t.cachedP999
+	}
+	return t.sortedPercentile(p)
+}
+
+// sortedPercentile indexes the sorted cache.
+func (t *TailDivergenceTracker) sortedPercentile(p float64) time.Duration {
+	n := len(t.sorted)
+	if n == 0 {
 		return 0
 	}
 
-	// Copy and sort samples
-	sorted := make([]time.Duration, effectiveSamples)
-	copy(sorted, t.samples[:effectiveSamples])
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i] < sorted[j]
-	})
+	if t.Interpolate {
+		return PercentileInterpolated(t.sorted, p)
+	}
 
 	// Calculate index
-	index := int(float64(effectiveSamples-1) * p)
+	index := int(float64(n-1) * p)
 	if index < 0 {
 		index = 0
 	}
-	if index >= effectiveSamples {
-		index = effectiveSamples - 1
+	if index >= n {
+		index = n - 1
 	}
 
-	return sorted[index]
-} // effectiveSampleCount returns the number of valid samples in the buffer.
+	return t.sorted[index]
+}
+
+// effectiveSampleCount returns the number of valid samples in the buffer.
 func (t *TailDivergenceTracker) effectiveSampleCount() int {
 	if t.sampleCount < int64(t.maxSamples) {
 		return int(t.sampleCount)
@@ -263,14 +935,19 @@ type TailStats struct {
 	P99                 time.Duration
 	P999                time.Duration
 	TailDivergenceRatio float64
-	ParetoIndex         float64
+	ParetoIndex         float64 // Quick P99/P50 estimate
+	ParetoIndexHill     float64 // Hill estimate over the top 10% (see ParetoIndexHill)
 	EstimatedR          float64
+	Confidence          float64 // Reliability of EstimatedR (see EstimateRWithConfidence)
 	IsGaussian          bool
 	IsPowerLaw          bool
+	ModeCount           int  // Distinct latency modes (see ModeCount)
+	IsBimodal           bool // Regime judged within modes (see IsBimodal)
 }
 
 // GetStats returns comprehensive statistics about the distribution.
 func (t *TailDivergenceTracker) GetStats() TailStats {
+	_, confidence := t.EstimateRWithConfidence()
 	return TailStats{
 		SampleCount:         t.sampleCount,
 		Mean:                t.Mean(),
@@ -279,8 +956,12 @@ func (t *TailDivergenceTracker) GetStats() TailStats {
 		P999:                t.P999(),
 		TailDivergenceRatio: t.TailDivergenceRatio(),
 		ParetoIndex:         t.ParetoIndex(),
+		ParetoIndexHill:     t.ParetoIndexHill(0),
 		EstimatedR:          t.EstimateR(),
+		Confidence:          confidence,
 		IsGaussian:          t.IsGaussian(),
 		IsPowerLaw:          t.IsPowerLaw(),
+		ModeCount:           t.ModeCount(),
+		IsBimodal:           t.IsBimodal(),
 	}
 }
@@ -1,18 +1,20 @@
 package lawbench
 
 import (
+	"math"
 	"math/rand"
 	"testing"
 	"time"
 )
 
 func TestTailDivergenceTracker_GaussianRegime(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
 	tracker := NewTailDivergenceTracker(1000)
 
 	// Simulate Gaussian latencies (stable system, r < 2.5)
 	// Mean = 50ms, StdDev = 10ms
 	for i := 0; i < 1000; i++ {
-		latency := time.Duration(50+rand.NormFloat64()*10) * time.Millisecond
+		latency := time.Duration(50+rng.NormFloat64()*10) * time.Millisecond
 		if latency < 0 {
 			latency = 1 * time.Millisecond
 		}
@@ -48,19 +50,20 @@ func TestTailDivergenceTracker_GaussianRegime(t *testing.T) {
 }
 
 func TestTailDivergenceTracker_PowerLawRegime(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
 	tracker := NewTailDivergenceTracker(1000)
 
 	// Simulate Power Law latencies (saturation, r ≥ 3.0)
 	// 98% fast, 2% extreme outliers (ensures P99 captures them)
 	for i := 0; i < 980; i++ {
 		// 98% of requests: 1-10ms (fast)
-		latency := time.Duration(1+rand.Intn(10)) * time.Millisecond
+		latency := time.Duration(1+rng.Intn(10)) * time.Millisecond
 		tracker.Record(latency)
 	}
 
 	for i := 0; i < 20; i++ {
 		// 2% of requests: 100-10000ms (BLACK SWANS)
-		latency := time.Duration(100+rand.Intn(9900)) * time.Millisecond
+		latency := time.Duration(100+rng.Intn(9900)) * time.Millisecond
 		tracker.Record(latency)
 	}
 
@@ -152,7 +155,43 @@ func TestTailDivergenceTracker_DominatedAverage(t *testing.T) {
 	t.Logf("✓ The Outliers dominate the Average")
 }
 
+// TestTailDivergenceTracker_ReliableCentralMetric verifies the dominated
+// average scenario reports the median with a heavy-tail warning, and a tight
+// Gaussian reports the mean.
+func TestTailDivergenceTracker_ReliableCentralMetric(t *testing.T) {
+	tracker := NewTailDivergenceTracker(1000)
+	for i := 0; i < 980; i++ {
+		tracker.Record(1 * time.Millisecond)
+	}
+	for i := 0; i < 20; i++ {
+		tracker.Record(10000 * time.Millisecond)
+	}
+
+	value, which, warning := tracker.ReliableCentralMetric()
+	if which != "p50" || value != tracker.P50() {
+		t.Errorf("Got %v (%s), want the median %v", value, which, tracker.P50())
+	}
+	if value == tracker.Mean() {
+		t.Errorf("Reported the outlier-dominated mean %v", value)
+	}
+	if warning == "" {
+		t.Error("Expected an infinite-variance warning")
+	}
+
+	gaussian := NewTailDivergenceTracker(100)
+	for i := 0; i < 100; i++ {
+		gaussian.Record(time.Duration(50+i%5) * time.Millisecond)
+	}
+	value, which, warning = gaussian.ReliableCentralMetric()
+	if which != "mean" || value != gaussian.Mean() || warning != "" {
+		t.Errorf("Gaussian: got %v (%s, %q), want the mean %v without warning", value, which, warning, gaussian.Mean())
+	}
+
+	t.Logf("✓ Dominated average: %v (p50) instead of mean %v; %s", tracker.P50(), tracker.Mean(), warning)
+}
+
 func TestTailDivergenceTracker_GaussianToPowerLawTransition(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
 	t.Log("=== GAUSSIAN → POWER LAW TRANSITION (Saturation Onset) ===")
 	t.Log("")
 
@@ -161,7 +200,7 @@ func TestTailDivergenceTracker_GaussianToPowerLawTransition(t *testing.T) {
 	// Phase 1: Stable (Gaussian)
 	t.Log("Phase 1: Stable (r < 2.5)")
 	for i := 0; i < 100; i++ {
-		latency := time.Duration(50+rand.NormFloat64()*10) * time.Millisecond
+		latency := time.Duration(50+rng.NormFloat64()*10) * time.Millisecond
 		if latency < 0 {
 			latency = 1 * time.Millisecond
 		}
@@ -177,11 +216,11 @@ func TestTailDivergenceTracker_GaussianToPowerLawTransition(t *testing.T) {
 	t.Log("Phase 2: Degradation (2.5 ≤ r < 3.0)")
 	for i := 0; i < 100; i++ {
 		var latency time.Duration
-		if rand.Float64() < 0.95 {
-			latency = time.Duration(50+rand.NormFloat64()*10) * time.Millisecond
+		if rng.Float64() < 0.95 {
+			latency = time.Duration(50+rng.NormFloat64()*10) * time.Millisecond
 		} else {
 			// 5% spikes to 500ms
-			latency = time.Duration(500+rand.Intn(500)) * time.Millisecond
+			latency = time.Duration(500+rng.Intn(500)) * time.Millisecond
 		}
 		if latency < 0 {
 			latency = 1 * time.Millisecond
@@ -198,11 +237,11 @@ func TestTailDivergenceTracker_GaussianToPowerLawTransition(t *testing.T) {
 	t.Log("Phase 3: Saturation (r ≥ 3.0)")
 	for i := 0; i < 100; i++ {
 		var latency time.Duration
-		if rand.Float64() < 0.90 {
-			latency = time.Duration(50+rand.NormFloat64()*10) * time.Millisecond
+		if rng.Float64() < 0.90 {
+			latency = time.Duration(50+rng.NormFloat64()*10) * time.Millisecond
 		} else {
 			// 10% BLACK SWANS (1-10 seconds)
-			latency = time.Duration(1000+rand.Intn(9000)) * time.Millisecond
+			latency = time.Duration(1000+rng.Intn(9000)) * time.Millisecond
 		}
 		if latency < 0 {
 			latency = 1 * time.Millisecond
@@ -235,6 +274,7 @@ func TestTailDivergenceTracker_GaussianToPowerLawTransition(t *testing.T) {
 }
 
 func TestParetoIndex_8020Rule(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
 	t.Log("=== THE 80/20 RULE (Pareto Index α ≈ 1.16) ===")
 	t.Log("")
 
@@ -246,10 +286,10 @@ func TestParetoIndex_8020Rule(t *testing.T) {
 		var latency time.Duration
 		if i < 800 {
 			// 80% fast (1-10ms)
-			latency = time.Duration(1+rand.Intn(10)) * time.Millisecond
+			latency = time.Duration(1+rng.Intn(10)) * time.Millisecond
 		} else {
 			// 20% slow (100-1000ms)
-			latency = time.Duration(100+rand.Intn(900)) * time.Millisecond
+			latency = time.Duration(100+rng.Intn(900)) * time.Millisecond
 		}
 		tracker.Record(latency)
 	}
@@ -271,6 +311,7 @@ func TestParetoIndex_8020Rule(t *testing.T) {
 }
 
 func TestParetoIndex_InfiniteVariance(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
 	t.Log("=== INFINITE VARIANCE (α ≤ 2) ===")
 	t.Log("")
 
@@ -280,12 +321,12 @@ func TestParetoIndex_InfiniteVariance(t *testing.T) {
 	// This is "Black Swan" territory
 	for i := 0; i < 1000; i++ {
 		var latency time.Duration
-		if rand.Float64() < 0.99 {
+		if rng.Float64() < 0.99 {
 			// 99% normal
-			latency = time.Duration(1+rand.Intn(50)) * time.Millisecond
+			latency = time.Duration(1+rng.Intn(50)) * time.Millisecond
 		} else {
 			// 1% EXTREME (up to 1 minute)
-			latency = time.Duration(rand.Intn(60000)) * time.Millisecond
+			latency = time.Duration(rng.Intn(60000)) * time.Millisecond
 		}
 		tracker.Record(latency)
 	}
@@ -309,3 +350,510 @@ func TestParetoIndex_InfiniteVariance(t *testing.T) {
 	t.Logf("  Traditional statistics (mean, variance) are meaningless")
 	t.Logf("  Only percentiles (P50, P99) are valid metrics")
 }
+
+// TestTailDivergenceTracker_PercentileCache verifies that GetStats sorts the
+// buffer once per write and that the cache is invalidated by Record.
+func TestTailDivergenceTracker_PercentileCache(t *testing.T) {
+	tracker := NewTailDivergenceTracker(1000)
+	for i := 1; i <= 1000; i++ {
+		tracker.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	stats := tracker.GetStats()
+	if tracker.sorts != 1 {
+		t.Errorf("GetStats should sort once, sorted %d times", tracker.sorts)
+	}
+	if stats.P50 != 500*time.Millisecond || stats.P99 != 990*time.Millisecond || stats.P999 != 999*time.Millisecond {
+		t.Errorf("Unexpected percentiles: P50=%v P99=%v P999=%v", stats.P50, stats.P99, stats.P999)
+	}
+
+	tracker.GetStats()
+	if tracker.sorts != 1 {
+		t.Errorf("Repeated GetStats without writes should not re-sort, sorted %d times", tracker.sorts)
+	}
+
+	// Overwrite the oldest sample (1ms) with a new maximum: P999 shifts up one rank
+	tracker.Record(5 * time.Second)
+	if got := tracker.P999(); got != time.Second {
+		t.Errorf("Cache should be invalidated by Record: P999=%v, want 1s", got)
+	}
+	if tracker.sorts != 2 {
+		t.Errorf("Record should trigger exactly one re-sort, sorted %d times", tracker.sorts)
+	}
+
+	t.Logf("✓ GetStats: 1 sort per write (was 6 before caching)")
+}
+
+// TestTailDivergenceTracker_SketchCache verifies a recorder-backed tracker
+// rebuilds its sketch sample only after 1% growth or once it is stale, not
+// on every regime query.
+func TestTailDivergenceTracker_SketchCache(t *testing.T) {
+	clock := time.Unix(0, 0)
+	tracker := NewTailDivergenceTrackerWithRecorder(NewTDigestRecorder(0))
+	tracker.now = func() time.Time { return clock }
+	for i := 1; i <= 10000; i++ {
+		tracker.Record(time.Duration(i%100+1) * time.Millisecond)
+	}
+
+	for i := 0; i < 100; i++ {
+		tracker.EstimateR()
+		tracker.IsPowerLaw()
+	}
+	if tracker.sorts != 1 {
+		t.Fatalf("Repeated queries should build the sketch sample once, built %d times", tracker.sorts)
+	}
+
+	for i := 0; i < 50; i++ { // 0.5% growth
+		tracker.Record(time.Millisecond)
+	}
+	tracker.EstimateR()
+	if tracker.sorts != 1 {
+		t.Errorf("0.5%% growth should not rebuild, built %d times", tracker.sorts)
+	}
+
+	for i := 0; i < 100; i++ { // 1.5% growth
+		tracker.Record(time.Millisecond)
+	}
+	tracker.EstimateR()
+	if tracker.sorts != 2 {
+		t.Errorf("1.5%% growth should rebuild once, built %d times", tracker.sorts)
+	}
+
+	clock = clock.Add(sketchRefreshTTL)
+	tracker.EstimateR()
+	if tracker.sorts != 3 {
+		t.Errorf("A stale sketch sample should rebuild, built %d times", tracker.sorts)
+	}
+}
+
+// BenchmarkTailDivergenceTracker_GetStats measures GetStats after each write,
+// reporting sorts/op (1 with the cache, 6 without).
+func BenchmarkTailDivergenceTracker_GetStats(b *testing.B) {
+	rng := rand.New(rand.NewSource(6))
+	tracker := NewTailDivergenceTracker(1000)
+	for i := 0; i < 1000; i++ {
+		tracker.Record(time.Duration(1+rng.Intn(100)) * time.Millisecond)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tracker.Record(time.Duration(1+rng.Intn(100)) * time.Millisecond)
+		tracker.GetStats()
+	}
+	b.ReportMetric(float64(tracker.sorts)/float64(b.N), "sorts/op")
+}
+
+// TestParetoIndexHill_RecoversAlpha verifies the Hill estimator recovers α
+// from true Pareto(α=1.16) samples, where the P99/P50 ratio method does not.
+func TestParetoIndexHill_RecoversAlpha(t *testing.T) {
+	const alpha = 1.16
+	rng := rand.New(rand.NewSource(42))
+
+	tracker := NewTailDivergenceTracker(10000)
+	for i := 0; i < 10000; i++ {
+		// Inverse transform: x = x_min · U^(-1/α)
+		u := 1 - rng.Float64() // (0, 1]
+		tracker.Record(time.Duration(float64(time.Millisecond) * math.Pow(u, -1/alpha)))
+	}
+
+	stats := tracker.GetStats()
+	if math.Abs(stats.ParetoIndexHill-alpha) > 0.1 {
+		t.Errorf("Hill estimate %.3f not within ±0.1 of α=%.2f", stats.ParetoIndexHill, alpha)
+	}
+
+	for _, k := range []int{100, 500, 1000, 2000} {
+		t.Logf("  k=%4d: α̂ = %.3f", k, tracker.ParetoIndexHill(k))
+	}
+
+	t.Logf("✓ Hill α̂ = %.3f (ratio method: %.3f, true α = %.2f)",
+		stats.ParetoIndexHill, stats.ParetoIndex, alpha)
+}
+
+// TestParetoIndexHill_Recorder verifies a recorder-backed tracker estimates
+// α from its cached sketch sample, without rebuilding it per call.
+func TestParetoIndexHill_Recorder(t *testing.T) {
+	const alpha = 1.16
+	rng := rand.New(rand.NewSource(42))
+
+	tracker := NewTailDivergenceTrackerWithRecorder(NewTDigestRecorder(0))
+	for i := 0; i < 100000; i++ {
+		u := 1 - rng.Float64()
+		tracker.Record(time.Duration(float64(time.Millisecond) * math.Pow(u, -1/alpha)))
+	}
+
+	for _, k := range []int{0, 5000, 10000} {
+		if got := tracker.ParetoIndexHill(k); math.Abs(got-alpha) > 0.15 {
+			t.Errorf("k=%d: Hill estimate %.3f not within ±0.15 of α=%.2f", k, got, alpha)
+		}
+	}
+	if tracker.sorts != 1 {
+		t.Errorf("Repeated estimates should build the sketch sample once, built %d times", tracker.sorts)
+	}
+
+	t.Logf("✓ Recorder Hill α̂ = %.3f (true α = %.2f)", tracker.ParetoIndexHill(0), alpha)
+}
+
+// TestParetoIndexHill_Degenerate verifies edge cases return 0.
+func TestParetoIndexHill_Degenerate(t *testing.T) {
+	tracker := NewTailDivergenceTracker(100)
+	if got := tracker.ParetoIndexHill(10); got != 0 {
+		t.Errorf("Empty tracker: expected 0, got %.3f", got)
+	}
+
+	for i := 0; i < 100; i++ {
+		tracker.Record(5 * time.Millisecond)
+	}
+	if got := tracker.ParetoIndexHill(10); got != 0 {
+		t.Errorf("Constant samples: expected 0, got %.3f", got)
+	}
+}
+
+// TestTailDivergenceTracker_WindowRecovery verifies that in window mode old
+// black swans age out by time, so IsPowerLaw flips back once the system
+// recovers, while a count-based tracker still reports saturation.
+func TestTailDivergenceTracker_WindowRecovery(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	clock := time.Unix(0, 0)
+	windowed := NewTailDivergenceTrackerWithWindow(1000, time.Second)
+	windowed.now = func() time.Time { return clock }
+	counted := NewTailDivergenceTracker(1000)
+
+	record := func(lat time.Duration) {
+		windowed.Record(lat)
+		counted.Record(lat)
+	}
+
+	// Saturation spike: mostly fast, 5% black swans
+	for i := 0; i < 500; i++ {
+		lat := time.Duration(1+rng.Intn(10)) * time.Millisecond
+		if i%20 == 0 {
+			lat = time.Duration(1000+rng.Intn(9000)) * time.Millisecond
+		}
+		record(lat)
+	}
+	if !windowed.IsPowerLaw() {
+		t.Fatalf("Should detect Power Law during spike (ratio %.2f)", windowed.TailDivergenceRatio())
+	}
+
+	// Recovery: 2s of fast samples (one per 10ms), beyond the 1s window
+	for i := 0; i < 200; i++ {
+		clock = clock.Add(10 * time.Millisecond)
+		record(time.Duration(5+rng.Intn(5)) * time.Millisecond)
+	}
+
+	if windowed.IsPowerLaw() {
+		t.Errorf("Windowed tracker should recover (ratio %.2f, r=%.2f)",
+			windowed.TailDivergenceRatio(), windowed.EstimateR())
+	}
+	if windowed.Mean() > 10*time.Millisecond {
+		t.Errorf("Windowed mean should ignore aged-out samples, got %v", windowed.Mean())
+	}
+	if !counted.IsPowerLaw() {
+		t.Errorf("Count-based tracker should still hold the black swans")
+	}
+
+	// Traffic stops: everything ages out, even without new writes
+	clock = clock.Add(2 * time.Second)
+	if windowed.P99() != 0 {
+		t.Errorf("All samples should have aged out, P99=%v", windowed.P99())
+	}
+
+	t.Logf("✓ Windowed r=%.2f after recovery (count-based r=%.2f)",
+		windowed.EstimateR(), counted.EstimateR())
+}
+
+// TestTailDivergenceTracker_Reset verifies Reset clears both backends.
+func TestTailDivergenceTracker_Reset(t *testing.T) {
+	trackers := []struct {
+		name    string
+		tracker *TailDivergenceTracker
+	}{
+		{"RingBuffer", NewTailDivergenceTracker(100)},
+		{"TDigest", NewTailDivergenceTrackerWithRecorder(NewTDigestRecorder(0))},
+	}
+
+	for _, tc := range trackers {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				tc.tracker.Record(10 * time.Second)
+			}
+			tc.tracker.P99() // Populate cache
+
+			tc.tracker.Reset()
+			if stats := tc.tracker.GetStats(); stats.SampleCount != 0 || stats.P99 != 0 || stats.Mean != 0 {
+				t.Errorf("Reset should clear samples, got %+v", stats)
+			}
+
+			tc.tracker.Record(5 * time.Millisecond)
+			if got := tc.tracker.P99(); got != 5*time.Millisecond {
+				t.Errorf("After Reset, P99 should reflect new samples only, got %v", got)
+			}
+		})
+	}
+}
+
+// TestTailDivergenceTracker_ConfigurableThresholds verifies IsPowerLaw and
+// EstimateR follow PowerLawMinRatio instead of the hard-coded 10.
+func TestTailDivergenceTracker_ConfigurableThresholds(t *testing.T) {
+	// Ratio-12 distribution: P50 = 10ms, P99 = 120ms
+	fill := func(tracker *TailDivergenceTracker) {
+		for i := 0; i < 985; i++ {
+			tracker.Record(10 * time.Millisecond)
+		}
+		for i := 0; i < 15; i++ {
+			tracker.Record(120 * time.Millisecond)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		powerLawMin float64
+		wantPower   bool
+		wantRAbove3 bool
+	}{
+		{"default threshold (10)", 0, true, true},
+		{"raised threshold (20)", 20, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewTailDivergenceTracker(1000)
+			if tt.powerLawMin > 0 {
+				tracker.PowerLawMinRatio = tt.powerLawMin
+			}
+			fill(tracker)
+
+			if ratio := tracker.TailDivergenceRatio(); ratio != 12 {
+				t.Fatalf("Expected ratio 12, got %.2f", ratio)
+			}
+			if got := tracker.IsPowerLaw(); got != tt.wantPower {
+				t.Errorf("IsPowerLaw = %v, want %v", got, tt.wantPower)
+			}
+			if r := tracker.EstimateR(); (r >= 3.0) != tt.wantRAbove3 {
+				t.Errorf("EstimateR = %.2f, want r ≥ 3.0: %v", r, tt.wantRAbove3)
+			}
+			t.Logf("✓ PowerLawMinRatio=%.0f: power law=%v, r=%.2f",
+				tracker.PowerLawMinRatio, tracker.IsPowerLaw(), tracker.EstimateR())
+		})
+	}
+}
+
+// TestEstimateRFromRatio_DefaultBreakpoints verifies the generalized mapping
+// reproduces the original breakpoints with default thresholds.
+func TestEstimateRFromRatio_DefaultBreakpoints(t *testing.T) {
+	tests := []struct {
+		ratio float64
+		want  float64
+	}{
+		{0, 1.5},
+		{3, 2.0},
+		{6.5, 2.5},
+		{10, 3.0},
+		{55, 3.5},
+		{100, 4.0},
+		{150, 4.5},
+		{1000, 5.0},
+	}
+
+	for _, tt := range tests {
+		got := estimateRFromRatio(tt.ratio, DefaultGaussianMaxRatio, DefaultPowerLawMinRatio)
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("ratio %.1f: r = %.4f, want %.4f", tt.ratio, got, tt.want)
+		}
+	}
+}
+
+// TestTailDivergenceTracker_RecommendedRetryPolicy verifies a Gaussian regime
+// gets a generous retry budget and an extreme power law gets none.
+func TestTailDivergenceTracker_RecommendedRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		latency     func(i int) time.Duration
+		wantRetries int
+	}{
+		{
+			name:        "Gaussian",
+			latency:     func(i int) time.Duration { return time.Duration(45+i%10) * time.Millisecond },
+			wantRetries: 3,
+		},
+		{
+			name: "Transition",
+			latency: func(i int) time.Duration {
+				if i%50 == 0 {
+					return 400 * time.Millisecond // Ratio 8
+				}
+				return 50 * time.Millisecond
+			},
+			wantRetries: 1,
+		},
+		{
+			name: "Extreme power law",
+			latency: func(i int) time.Duration {
+				if i%20 == 0 {
+					return 10 * time.Second // Ratio 200
+				}
+				return 50 * time.Millisecond
+			},
+			wantRetries: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewTailDivergenceTracker(1000)
+			for i := 0; i < 1000; i++ {
+				tracker.Record(tt.latency(i))
+			}
+
+			policy := tracker.RecommendedRetryPolicy()
+			if policy.MaxRetries != tt.wantRetries {
+				t.Errorf("Expected %d retries at r=%.2f (ratio %.1f), got %d",
+					tt.wantRetries, policy.EstimatedR, policy.TailRatio, policy.MaxRetries)
+			}
+			if policy.MaxRetries > 0 && policy.BaseBackoff < tracker.P99() {
+				t.Errorf("Backoff %v should be at least P99 %v", policy.BaseBackoff, tracker.P99())
+			}
+			if policy.MaxRetries == 0 && (policy.BaseBackoff != 0 || policy.Jitter != 0) {
+				t.Errorf("No-retry policy should carry no backoff: %+v", policy)
+			}
+
+			t.Logf("✓ %s: r=%.2f → %d retries, backoff %v, jitter %.1f",
+				tt.name, policy.EstimatedR, policy.MaxRetries, policy.BaseBackoff, policy.Jitter)
+		})
+	}
+
+	// No samples yet: generous budget with the default backoff
+	if policy := NewTailDivergenceTracker(10).RecommendedRetryPolicy(); policy.MaxRetries != 3 || policy.BaseBackoff != DefaultRetryBaseBackoff {
+		t.Errorf("Empty tracker should allow 3 retries at %v, got %+v", DefaultRetryBaseBackoff, policy)
+	}
+}
+
+// TestTailDivergenceTracker_IsBimodal verifies a healthy 70/30 cache is
+// recognized as two modes and not as saturation, while a true power law
+// stays a single mode.
+func TestTailDivergenceTracker_IsBimodal(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+
+	tests := []struct {
+		name         string
+		latency      func() time.Duration
+		wantBimodal  bool
+		wantPowerLaw bool
+	}{
+		{
+			name: "70/30 cache",
+			latency: func() time.Duration {
+				if rng.Float64() < 0.70 {
+					return time.Duration((1 + 0.1*rng.NormFloat64()) * float64(time.Millisecond)) // Hit
+				}
+				return time.Duration((50 + 5*rng.NormFloat64()) * float64(time.Millisecond)) // Miss
+			},
+			wantBimodal:  true,
+			wantPowerLaw: false,
+		},
+		{
+			name: "Pareto α=1.16",
+			latency: func() time.Duration {
+				return time.Duration(float64(time.Millisecond) * math.Pow(1-rng.Float64(), -1/1.16))
+			},
+			wantBimodal:  false,
+			wantPowerLaw: true,
+		},
+		{
+			name: "Gaussian",
+			latency: func() time.Duration {
+				return time.Duration((50 + 10*rng.NormFloat64()) * float64(time.Millisecond))
+			},
+			wantBimodal:  false,
+			wantPowerLaw: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exact := NewTailDivergenceTracker(1000)
+			digest := NewTailDivergenceTrackerWithRecorder(NewTDigestRecorder(0))
+			for i := 0; i < 1000; i++ {
+				lat := tt.latency()
+				exact.Record(lat)
+				digest.Record(lat)
+			}
+
+			for _, tracker := range []*TailDivergenceTracker{exact, digest} {
+				stats := tracker.GetStats()
+				if stats.IsBimodal != tt.wantBimodal {
+					t.Errorf("IsBimodal = %v (%d modes), want %v", stats.IsBimodal, stats.ModeCount, tt.wantBimodal)
+				}
+				if stats.IsPowerLaw != tt.wantPowerLaw {
+					t.Errorf("IsPowerLaw = %v (ratio %.1f, r=%.2f), want %v",
+						stats.IsPowerLaw, stats.TailDivergenceRatio, stats.EstimatedR, tt.wantPowerLaw)
+				}
+			}
+
+			stats := exact.GetStats()
+			if tt.wantBimodal && stats.EstimatedR >= 2.5 {
+				t.Errorf("Healthy bimodal cache should not look saturated, got r=%.2f", stats.EstimatedR)
+			}
+			t.Logf("✓ %s: %d modes, ratio %.1f, r=%.2f", tt.name, stats.ModeCount, stats.TailDivergenceRatio, stats.EstimatedR)
+		})
+	}
+
+	if modes := NewTailDivergenceTracker(10).ModeCount(); modes != 0 {
+		t.Errorf("Empty tracker should have 0 modes, got %d", modes)
+	}
+}
+
+// TestEstimateRWithConfidence_SampleSize verifies confidence is low with a
+// handful of samples and high with many, for the same distribution.
+func TestEstimateRWithConfidence_SampleSize(t *testing.T) {
+	sample := func(n int) *TailDivergenceTracker {
+		rng := rand.New(rand.NewSource(1))
+		tracker := NewTailDivergenceTracker(10000)
+		for i := 0; i < n; i++ {
+			tracker.Record(time.Duration(8+rng.Intn(5)) * time.Millisecond) // Gaussian-like 8-12ms
+		}
+		return tracker
+	}
+
+	few := sample(10)
+	many := sample(1000)
+
+	rFew, confFew := few.EstimateRWithConfidence()
+	rMany, confMany := many.EstimateRWithConfidence()
+
+	if confFew >= 0.2 {
+		t.Errorf("10 samples: confidence %.2f should be low", confFew)
+	}
+	if confMany < 0.8 {
+		t.Errorf("1000 samples: confidence %.2f should be high", confMany)
+	}
+	if confMany < DefaultMinRConfidence || confFew >= DefaultMinRConfidence {
+		t.Errorf("DefaultMinRConfidence %.2f should separate %.2f and %.2f", DefaultMinRConfidence, confFew, confMany)
+	}
+	if rMany != many.EstimateR() {
+		t.Errorf("r %.4f should match EstimateR %.4f", rMany, many.EstimateR())
+	}
+	if stats := many.GetStats(); stats.Confidence != confMany {
+		t.Errorf("TailStats.Confidence = %.4f, want %.4f", stats.Confidence, confMany)
+	}
+
+	if _, conf := NewTailDivergenceTracker(100).EstimateRWithConfidence(); conf != 0 {
+		t.Errorf("Empty tracker: confidence %.2f, want 0", conf)
+	}
+
+	t.Logf("✓ 10 samples: r=%.2f (confidence %.2f); 1000 samples: r=%.2f (confidence %.2f)", rFew, confFew, rMany, confMany)
+}
+
+// TestEstimateRWithConfidence_RegimeFit verifies a tail ratio sitting on a
+// regime breakpoint is less trustworthy than one deep inside a regime.
+func TestEstimateRWithConfidence_RegimeFit(t *testing.T) {
+	if got := regimeFit(DefaultGaussianMaxRatio, DefaultGaussianMaxRatio, DefaultPowerLawMinRatio); got != 0.5 {
+		t.Errorf("At the Gaussian breakpoint: fit %.2f, want 0.5", got)
+	}
+	if got := regimeFit(1.1, DefaultGaussianMaxRatio, DefaultPowerLawMinRatio); got != 1 {
+		t.Errorf("Tight Gaussian (ratio 1.1): fit %.2f, want 1", got)
+	}
+	if got := regimeFit(200, DefaultGaussianMaxRatio, DefaultPowerLawMinRatio); got != 1 {
+		t.Errorf("Deep power law (ratio 200): fit %.2f, want 1", got)
+	}
+}
@@ -0,0 +1,74 @@
+package lawbench
+
+import (
+	"math"
+	"sync"
+)
+
+// PriorityGovernor is a Governor that sheds by request class: the shed
+// fraction of its latest decision comes out of the least important classes
+// first, so paying customers keep perfect service while background jobs
+// absorb the overload.
+//
+// Classes are numbered 0 (most important, shed last) to classes-1, and the
+// fraction is spread from the bottom up exactly as in PriorityShedder. Unlike
+// PriorityShedder, admission within a partially shed class is deterministic:
+// a class shed with probability 0.5 admits every other request.
+//
+// Like Governor, it is safe for concurrent use: request handlers can call
+// Admit while a control loop calls Update.
+type PriorityGovernor struct {
+	*Governor
+
+	mu      sync.Mutex // Guards credit, and orders Update's shed fraction
+	shedder *PriorityShedder
+	credit  []float64 // Per-class admission credit carried between requests
+}
+
+// NewPriorityGovernor creates a governor for the given number of request
+// classes (at least 1).
+func NewPriorityGovernor(initialR float64, classes int, opts ...GovernorOption) *PriorityGovernor {
+	shedder := NewPriorityShedder(classes, 0)
+	return &PriorityGovernor{
+		Governor: NewGovernor(initialR, opts...),
+		shedder:  shedder,
+		credit:   make([]float64, shedder.classes),
+	}
+}
+
+// Update is Governor.Update; the decision's ShedFraction drives Admit until
+// the next call.
+func (p *PriorityGovernor) Update(currentR, alpha, beta float64, concurrency int) Action {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	action := p.Governor.Update(currentR, alpha, beta, concurrency)
+	p.shedder.SetShedFraction(action.ShedFraction)
+	return action
+}
+
+// Admit reports whether to serve a request of the given class under the
+// latest decision. Classes outside [0, classes-1] are treated as the
+// nearest valid one.
+func (p *PriorityGovernor) Admit(class int) bool {
+	drop := p.shedder.DropProbability(class)
+	class = max(0, min(class, len(p.credit)-1))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Error diffusion: each request earns its class 1 − drop of an admission
+	// and is served once a whole one has accumulated
+	p.credit[class] = math.Min(p.credit[class]+1-drop, 1)
+	if p.credit[class] < 1-1e-9 {
+		return false
+	}
+	p.credit[class]--
+	return true
+}
+
+// DropFraction returns the fraction of the given class's requests Admit
+// currently rejects.
+func (p *PriorityGovernor) DropFraction(class int) float64 {
+	return p.shedder.DropProbability(class)
+}
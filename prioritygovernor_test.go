@@ -0,0 +1,58 @@
+package lawbench
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPriorityGovernor_ShedsLowClassesFirst verifies that at a 50% shed,
+// class 0 is fully admitted, class 1 half and class 2 dropped.
+func TestPriorityGovernor_ShedsLowClassesFirst(t *testing.T) {
+	g := NewPriorityGovernor(2.0, 3, WithShedCurve(func(r, warning, saturation float64) float64 {
+		return 0.5
+	}))
+	action := g.Update(3.2, 0.05, 0.001, 8)
+	if action.ShedFraction != 0.5 {
+		t.Fatalf("ShedFraction = %.2f, want 0.5", action.ShedFraction)
+	}
+
+	const requests = 1000
+	admitted := make([]int, 3)
+	for i := 0; i < requests; i++ {
+		for class := range admitted {
+			if g.Admit(class) {
+				admitted[class]++
+			}
+		}
+	}
+
+	if admitted[0] != requests {
+		t.Errorf("Class 0: admitted %d/%d, want all", admitted[0], requests)
+	}
+	if admitted[1] != requests/2 {
+		t.Errorf("Class 1: admitted %d/%d, want exactly half", admitted[1], requests)
+	}
+	if admitted[2] > requests/10 {
+		t.Errorf("Class 2: admitted %d/%d, want it mostly dropped", admitted[2], requests)
+	}
+
+	total := admitted[0] + admitted[1] + admitted[2]
+	if shed := 1 - float64(total)/(3*requests); math.Abs(shed-0.5) > 0.01 {
+		t.Errorf("Overall shed %.3f, want 0.5", shed)
+	}
+
+	t.Logf("✓ Admitted per class at 50%% shed: %v", admitted)
+}
+
+// TestPriorityGovernor_StableAdmitsAll verifies nothing is shed while stable.
+func TestPriorityGovernor_StableAdmitsAll(t *testing.T) {
+	g := NewPriorityGovernor(2.0, 3)
+	if action := g.Update(2.0, 0.05, 0.001, 8); action.Type != ActionStable {
+		t.Fatalf("Expected STABLE, got %s", action.Type)
+	}
+	for class := -1; class <= 3; class++ {
+		if !g.Admit(class) {
+			t.Errorf("Class %d rejected while stable", class)
+		}
+	}
+}
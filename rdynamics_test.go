@@ -1,14 +1,19 @@
 package lawbench
 
 import (
+	"bytes"
+	"math"
+	"math/rand"
+	"strconv"
 	"testing"
+	"time"
 )
 
 // TestRDynamics_Creation verifies initial state.
 func TestRDynamics_Creation(t *testing.T) {
 	tests := []struct {
-		name        string
-		initialR    float64
+		name              string
+		initialR          float64
 		expectInstability bool
 	}{
 		{"Stable low", 1.5, false},
@@ -127,12 +132,117 @@ func TestRDynamics_Recovery_NoIsolation(t *testing.T) {
 	t.Logf("  Action required: Enforce Law I (Abstract Algebra verification)")
 }
 
+// TestRDynamics_Recovery_Supervision verifies that with identical isolation,
+// a well-supervised system reaches r < 3.0 in fewer iterations (Law II).
+func TestRDynamics_Recovery_Supervision(t *testing.T) {
+	tests := []struct {
+		name         string
+		supervised   int
+		unsupervised int
+	}{
+		{"Fully supervised", 50, 0},
+		{"Half supervised", 25, 25},
+		{"Mostly unsupervised", 10, 40},
+	}
+
+	previous := 0
+	for _, tt := range tests {
+		rd := NewRDynamics(3.5)
+		metrics := SystemIntegrityMetrics{
+			ImmutableOpsVerified:  100,
+			MutableSharedState:    10, // Same isolation for every case
+			SupervisedProcesses:   tt.supervised,
+			UnsupervisedProcesses: tt.unsupervised,
+		}
+
+		finalR, iterations := rd.ApplyRecoveryUntilStable(metrics, 500)
+		if finalR >= StableDNAConstraint.MaxR {
+			t.Fatalf("%s: recovery failed, r=%.4f after %d iterations", tt.name, finalR, iterations)
+		}
+		if iterations <= previous {
+			t.Errorf("%s: %d iterations, expected more than the better-supervised case (%d)",
+				tt.name, iterations, previous)
+		}
+		previous = iterations
+
+		t.Logf("✓ %s (%d/%d): r=3.5 → %.4f in %d iterations",
+			tt.name, tt.unsupervised, tt.supervised, finalR, iterations)
+	}
+
+	// No supervision data: isolation alone, as before Law II was considered
+	plain, supervised := NewRDynamics(3.5), NewRDynamics(3.5)
+	isolation := SystemIntegrityMetrics{ImmutableOpsVerified: 100, MutableSharedState: 10}
+	withSupervision := isolation
+	withSupervision.SupervisedProcesses = 50
+	if a, b := plain.ApplyRecovery(isolation), supervised.ApplyRecovery(withSupervision); a != b {
+		t.Errorf("Full supervision should not change the isolation-only pulse: %.4f vs %.4f", a, b)
+	}
+}
+
+// TestRDynamics_PIDRecovery_FasterThanLinear compares PID and linear recovery from deep saturation.
+func TestRDynamics_PIDRecovery_FasterThanLinear(t *testing.T) {
+	metrics := SystemIntegrityMetrics{
+		ImmutableOpsVerified: 100,
+		MutableSharedState:   0, // Perfect isolation
+	}
+
+	linear := NewRDynamics(3.8)
+	_, linearIterations := linear.ApplyRecoveryUntilStable(metrics, 50)
+
+	pid := NewRDynamics(3.8)
+	gains := DefaultPIDGains()
+	pidIterations := 0
+	prevR := pid.CurrentR
+	for pid.InSaturationZone && pidIterations < 50 {
+		r := pid.ApplyPIDRecovery(pid.TargetR, time.Second, gains)
+		pidIterations++
+
+		if step := prevR - r; step > CriticalityScalingRatio+1e-9 {
+			t.Errorf("PID step %d: correction %.4f exceeds 1/δ", pidIterations, step)
+		}
+		prevR = r
+	}
+
+	if pid.InSaturationZone {
+		t.Fatalf("PID recovery failed: r=%.4f after %d iterations", pid.CurrentR, pidIterations)
+	}
+	if pidIterations >= linearIterations {
+		t.Errorf("Expected PID (%d iterations) to beat linear recovery (%d iterations)",
+			pidIterations, linearIterations)
+	}
+
+	t.Logf("✓ r=3.8 → stable: PID %d iterations (r=%.4f), linear %d iterations (r=%.4f)",
+		pidIterations, pid.CurrentR, linearIterations, linear.CurrentR)
+}
+
+// TestRDynamics_PIDRecovery_NoOvershoot verifies PID never adds coupling or overshoots the setpoint.
+func TestRDynamics_PIDRecovery_NoOvershoot(t *testing.T) {
+	rd := NewRDynamics(3.2)
+	gains := DefaultPIDGains()
+
+	for i := 0; i < 30; i++ {
+		prev := rd.CurrentR
+		r := rd.ApplyPIDRecovery(2.4, time.Second, gains)
+		if r > prev {
+			t.Fatalf("Step %d: r increased %.4f → %.4f", i, prev, r)
+		}
+		if r < 2.4-1e-9 {
+			t.Fatalf("Step %d: r=%.4f overshot setpoint 2.4", i, r)
+		}
+	}
+
+	if math.Abs(rd.CurrentR-2.4) > 0.05 {
+		t.Errorf("Expected r to settle near setpoint 2.4, got %.4f", rd.CurrentR)
+	}
+	t.Logf("✓ Settled at r=%.4f (setpoint 2.4)", rd.CurrentR)
+}
+
 // TestRDynamics_FeigenbaumGovernance_CompliantScaling verifies stable scaling.
 func TestRDynamics_FeigenbaumGovernance_CompliantScaling(t *testing.T) {
 	// Start stable: r = 2.0
 	rd := NewRDynamics(2.0)
 
-	// Compliant scaling: 0.20 < 0.214 (within 1/δ limit)
+	// Compliant scaling: 0.20 ≪ δ (well within MaxComplexityGrowthRatio)
 	scalingRatio := 0.20
 
 	newR := rd.ApplyFeigenbaumGovernance(scalingRatio)
@@ -150,7 +260,7 @@ func TestRDynamics_FeigenbaumGovernance_CompliantScaling(t *testing.T) {
 
 	t.Logf("✓ Compliant scaling: r=%.4f → r=%.4f (+%.6f)",
 		rd.InitialR, newR, increase)
-	t.Logf("  Scaling ratio %.4f < %.4f (1/δ) - stable", scalingRatio, CriticalityScalingRatio)
+	t.Logf("  Scaling ratio %.4f < %.4f (δ) - stable", scalingRatio, MaxComplexityGrowthRatio)
 }
 
 // TestRDynamics_FeigenbaumGovernance_ViolatingScaling verifies escalation.
@@ -158,7 +268,7 @@ func TestRDynamics_FeigenbaumGovernance_ViolatingScaling(t *testing.T) {
 	// Start stable but near boundary: r = 2.8
 	rd := NewRDynamics(2.8)
 
-	// Violating scaling: 5.0 >> 0.214 (23x over 1/δ limit!)
+	// Violating scaling: 5.0 > δ ≈ 4.669
 	scalingRatio := 5.0
 
 	newR := rd.ApplyFeigenbaumGovernance(scalingRatio)
@@ -173,13 +283,66 @@ func TestRDynamics_FeigenbaumGovernance_ViolatingScaling(t *testing.T) {
 	if newR >= StableDNAConstraint.MaxR {
 		t.Logf("❌ Excessive scaling pushed r into instability: r=%.4f → r=%.4f (+%.6f)",
 			rd.InitialR, newR, increase)
-		t.Logf("  Scaling ratio %.4f >> %.4f (1/δ) - VIOLATED", scalingRatio, CriticalityScalingRatio)
+		t.Logf("  Scaling ratio %.4f > %.4f (δ) - VIOLATED", scalingRatio, MaxComplexityGrowthRatio)
 	} else {
 		t.Logf("⚠ Heavy scaling: r=%.4f → r=%.4f (+%.6f, approaching instability)",
 			rd.InitialR, newR, increase)
 	}
 }
 
+// TestRDynamics_TrendSlope verifies a rising history has a positive slope,
+// a flat one has none, and IsEscalating looks only at the recent window.
+func TestRDynamics_TrendSlope(t *testing.T) {
+	tests := []struct {
+		name       string
+		history    []float64
+		wantSlope  float64
+		escalating bool // over the last 4 samples
+	}{
+		{"Rising", []float64{2.0, 2.1, 2.2, 2.3, 2.4, 2.5}, 0.1, true},
+		{"Flat", []float64{2.4, 2.4, 2.4, 2.4, 2.4, 2.4}, 0, false},
+		{"Falling", []float64{2.9, 2.7, 2.5, 2.3, 2.1, 1.9}, -0.2, false},
+		{"Recovered after spike", []float64{2.0, 2.5, 2.9, 2.8, 2.6, 2.4}, 1.1 / 17.5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rd := NewRDynamics(tt.history[0])
+			rd.History = tt.history
+
+			if slope := rd.TrendSlope(); math.Abs(slope-tt.wantSlope) > 1e-9 {
+				t.Errorf("Expected slope %.4f, got %.4f", tt.wantSlope, slope)
+			}
+			if got := rd.IsEscalating(4); got != tt.escalating {
+				t.Errorf("IsEscalating(4) = %v, want %v", got, tt.escalating)
+			}
+			t.Logf("✓ %s: slope=%+.4f/step, escalating=%v", tt.name, rd.TrendSlope(), tt.escalating)
+		})
+	}
+
+	// Not enough history to judge
+	rd := NewRDynamics(2.0)
+	if rd.TrendSlope() != 0 || rd.IsEscalating(2) {
+		t.Error("A single sample should have no trend")
+	}
+}
+
+// TestRDynamics_ExportHistory verifies the CSV layout.
+func TestRDynamics_ExportHistory(t *testing.T) {
+	rd := NewRDynamics(3.2)
+	rd.ApplyFeigenbaumGovernance(0.1)
+
+	var buf bytes.Buffer
+	if err := rd.ExportHistory(&buf); err != nil {
+		t.Fatalf("ExportHistory failed: %v", err)
+	}
+
+	want := "index,r\n0,3.2\n1," + strconv.FormatFloat(rd.History[1], 'g', -1, 64) + "\n"
+	if buf.String() != want {
+		t.Errorf("Got CSV:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
 // TestCorrectRAfterRecovery_CombinedStrategy verifies both phases.
 func TestCorrectRAfterRecovery_CombinedStrategy(t *testing.T) {
 	// Start in instability: r = 3.8
@@ -189,7 +352,7 @@ func TestCorrectRAfterRecovery_CombinedStrategy(t *testing.T) {
 	metrics := SystemIntegrityMetrics{
 		ImmutableOpsVerified: 100,
 		MutableSharedState:   5,
-		ScalingRatio:         0.15, // Below 1/δ limit
+		ScalingRatio:         0.15, // Well below δ
 	}
 
 	// Phase 1: Recovery + Phase 2: Feigenbaum governance
@@ -263,7 +426,7 @@ func TestPerpetualStructuralIntegrity_ScalingViolation(t *testing.T) {
 	metrics := SystemIntegrityMetrics{
 		ImmutableOpsVerified: 100,
 		MutableSharedState:   0,
-		ScalingRatio:         5.0, // 23x over limit!
+		ScalingRatio:         109.0, // 23x over δ!
 	}
 
 	err := PerpetualStructuralIntegrity(&rd, metrics)
@@ -380,6 +543,158 @@ func TestSimulateRTrajectory_InstabilityThenRecovery(t *testing.T) {
 		beforeDefib, afterDefib, beforeDefib-afterDefib)
 }
 
+// TestSimulateRTrajectory_ViolationCeiling verifies an extreme violation
+// saturates r at the ceiling instead of diverging, and recovery from there
+// either terminates below the boundary or stops at maxIterations.
+func TestSimulateRTrajectory_ViolationCeiling(t *testing.T) {
+	violation := REvent{
+		Type:    "violation",
+		Metrics: SystemIntegrityMetrics{MutableSharedState: 1_000_000, ImmutableOpsVerified: 1},
+	}
+	trajectory := SimulateRTrajectory(2.0, []REvent{violation, violation, {Type: "scaling", ScalingRatio: 100}})
+	for i, r := range trajectory.R[1:] {
+		if r != 4.0 {
+			t.Errorf("Step %d: r = %.4f, want the 4.0 ceiling", i+1, r)
+		}
+	}
+
+	// Custom ceiling
+	rd := NewRDynamics(2.0)
+	rd.CeilingR = 3.5
+	applyREvent(&rd, violation)
+	if rd.CurrentR != 3.5 || !rd.InSaturationZone {
+		t.Errorf("r = %.4f (saturated=%v), want 3.5 in saturation", rd.CurrentR, rd.InSaturationZone)
+	}
+
+	// Recovery from the ceiling with good isolation terminates
+	rd = NewRDynamics(2.0)
+	applyREvent(&rd, violation)
+	finalR, iterations := rd.ApplyRecoveryUntilStable(SystemIntegrityMetrics{ImmutableOpsVerified: 100}, 20)
+	if finalR >= 3.0 || iterations >= 20 {
+		t.Errorf("Recovery from the ceiling: r=%.4f after %d iterations, want < 3.0 within 20", finalR, iterations)
+	}
+
+	// Without isolation it stops at maxIterations: restart required
+	rd = NewRDynamics(2.0)
+	applyREvent(&rd, violation)
+	finalR, iterations = rd.ApplyRecoveryUntilStable(violation.Metrics, 20)
+	if iterations != 20 || finalR < 3.0 {
+		t.Errorf("Unisolated recovery: r=%.4f after %d iterations, want ≥ 3.0 after 20", finalR, iterations)
+	}
+
+	t.Logf("✓ Extreme violation capped at r=%.1f", trajectory.R[1])
+}
+
+// TestSimulateRTrajectoryMonteCarlo_ViolationHeavyRoadmap verifies a
+// roadmap dominated by isolation violations is very likely to cross 3.0,
+// while a disciplined one almost never does.
+func TestSimulateRTrajectoryMonteCarlo_ViolationHeavyRoadmap(t *testing.T) {
+	feature := REvent{Type: "scaling", ScalingRatio: 0.20, Description: "Compliant feature"}
+	violation := REvent{
+		Type:        "violation",
+		Metrics:     SystemIntegrityMetrics{ImmutableOpsVerified: 10, MutableSharedState: 2},
+		Description: "Shared mutable state",
+	}
+	refactor := REvent{
+		Type:        "recovery",
+		Metrics:     SystemIntegrityMetrics{ImmutableOpsVerified: 100, IsolationScore: 1.0},
+		Description: "Isolation refactor",
+	}
+
+	tests := []struct {
+		name     string
+		dist     []WeightedEvent
+		minProb  float64
+		maxProb  float64
+		wantRise bool // Median final r above 2.5
+	}{
+		{
+			name:     "Violation-heavy",
+			dist:     []WeightedEvent{{violation, 0.8}, {feature, 0.2}},
+			minProb:  0.9,
+			maxProb:  1.0,
+			wantRise: true,
+		},
+		{
+			name:    "Disciplined",
+			dist:    []WeightedEvent{{feature, 3}, {refactor, 1}},
+			minProb: 0.0,
+			maxProb: 0.05,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc, err := SimulateRTrajectoryMonteCarlo(2.0, tt.dist, 10, 500, rand.New(rand.NewSource(1)))
+			if err != nil {
+				t.Fatalf("Monte Carlo failed: %v", err)
+			}
+
+			if mc.InstabilityProbability < tt.minProb || mc.InstabilityProbability > tt.maxProb {
+				t.Errorf("P(r ≥ 3.0) = %.3f, want in [%.2f, %.2f]", mc.InstabilityProbability, tt.minProb, tt.maxProb)
+			}
+			if len(mc.FinalR) != 500 || len(mc.MedianTrajectory) != 11 {
+				t.Fatalf("Expected 500 finals and 11 median steps, got %d and %d", len(mc.FinalR), len(mc.MedianTrajectory))
+			}
+
+			worst := mc.WorstCase.R[len(mc.WorstCase.R)-1]
+			if worst != mc.FinalR[len(mc.FinalR)-1] {
+				t.Errorf("Worst case final r %.4f should be the largest final r %.4f", worst, mc.FinalR[len(mc.FinalR)-1])
+			}
+			if len(mc.WorstCase.Events) != 10 {
+				t.Errorf("Worst case should record its 10 events, got %d", len(mc.WorstCase.Events))
+			}
+			if high := mc.MedianFinalR > 2.5; high != tt.wantRise {
+				t.Errorf("Median final r %.4f: expected above 2.5=%v", mc.MedianFinalR, tt.wantRise)
+			}
+
+			t.Logf("✓ %s: P(r ≥ 3.0)=%.1f%%, median final r=%.2f, worst=%.2f",
+				tt.name, mc.InstabilityProbability*100, mc.MedianFinalR, worst)
+		})
+	}
+}
+
+// TestSimulateRTrajectoryMonteCarlo_Reproducible verifies seeding and input
+// validation.
+func TestSimulateRTrajectoryMonteCarlo_Reproducible(t *testing.T) {
+	dist := []WeightedEvent{
+		{REvent{Type: "scaling", ScalingRatio: 0.5}, 1},
+		{REvent{Type: "violation", Metrics: SystemIntegrityMetrics{ImmutableOpsVerified: 10, MutableSharedState: 1}}, 1},
+		{REvent{Type: "scaling"}, 0}, // Never sampled
+	}
+
+	a, err := SimulateRTrajectoryMonteCarlo(2.0, dist, 8, 50, rand.New(rand.NewSource(42)))
+	if err != nil {
+		t.Fatalf("Monte Carlo failed: %v", err)
+	}
+	b, _ := SimulateRTrajectoryMonteCarlo(2.0, dist, 8, 50, rand.New(rand.NewSource(42)))
+	for i := range a.FinalR {
+		if a.FinalR[i] != b.FinalR[i] {
+			t.Fatalf("Same seed should reproduce results: trial %d %.6f vs %.6f", i, a.FinalR[i], b.FinalR[i])
+		}
+	}
+	for _, e := range a.WorstCase.Events {
+		if e.Type == "scaling" && e.ScalingRatio == 0 {
+			t.Error("Zero-probability event was sampled")
+		}
+	}
+
+	invalid := []struct {
+		name  string
+		dist  []WeightedEvent
+		steps int
+	}{
+		{"No events", nil, 5},
+		{"Negative probability", []WeightedEvent{{REvent{Type: "scaling"}, -1}}, 5},
+		{"Zero steps", dist, 0},
+	}
+	for _, tt := range invalid {
+		if _, err := SimulateRTrajectoryMonteCarlo(2.0, tt.dist, tt.steps, 10, nil); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
 // TestRDynamics_Philosophy documents the complete r management model.
 func TestRDynamics_Philosophy(t *testing.T) {
 	t.Log("\n=== The Complete R Management Model ===")
@@ -399,18 +714,18 @@ func TestRDynamics_Philosophy(t *testing.T) {
 	t.Log("")
 	t.Log("Phase II: Governing r (Feigenbaum Constraint)")
 	t.Log("  When: Always (preventive)")
-	t.Log("  How: Enforce Law III (Scaling ≤ 1/δ)")
+	t.Log("  How: Enforce Law III (Scaling ≤ δ)")
 	t.Logf("  Result: Δr bounded by %.4f (1/δ)", CriticalityScalingRatio)
 	t.Log("  Formula: r_next = r_current + (scaling_ratio / δ²)")
 	t.Log("")
 	t.Log("Perpetual Structural Integrity (Σ_R):")
 	t.Log("  Σ_R ≡ Enforce { 1 < r_eff(x, ΔC) < 3 }")
-	t.Log("       via     { ΔComplexity/ΔCore ≤ 1/δ }")
+	t.Log("       via     { ΔComplexity/ΔCore ≤ δ }")
 	t.Log("")
 	t.Log("Three-Law Synthesis:")
 	t.Log("  Law I (Isolation):   Suppresses base r (recovery)")
 	t.Log("  Law II (Supervision): Stabilizes r under failure (resilience)")
 	t.Log("  Law III (Scaling):    Bounds r growth rate (Feigenbaum)")
 	t.Log("")
-	t.Logf("Together: r starts low (Law I), stays stable (Law II), grows slowly (Law III/1/δ)")
+	t.Logf("Together: r starts low (Law I), stays stable (Law II), grows slowly (Law III/δ)")
 }
@@ -0,0 +1,98 @@
+package lawbench
+
+import (
+	"container/list"
+	"sync"
+)
+
+// GovernorRegistry holds one Governor per key (route, tenant, ...).
+//
+// A single global governor lets one hot endpoint push r up and shed load for
+// every endpoint. With a registry each key is governed independently, so a
+// misbehaving route is throttled while healthy ones keep serving.
+//
+// Governors are created lazily from a template and evicted least recently
+// used once MaxGovernors is reached. The registry and the governors it
+// returns are safe for concurrent use.
+type GovernorRegistry struct {
+	mu       sync.Mutex
+	template *Governor
+	initialR float64
+	max      int // 0 = unbounded
+
+	governors map[string]*list.Element
+	lru       *list.List // Front = most recently used
+}
+
+// registryEntry is an LRU list element.
+type registryEntry struct {
+	key      string
+	governor *Governor
+}
+
+// NewGovernorRegistry creates a registry whose governors copy template's
+// configuration (thresholds, hysteresis timing, OnTransition callbacks) and
+// start from template's initial r with fresh state.
+//
+// maxGovernors bounds memory for unbounded key spaces (e.g. tenant IDs);
+// 0 means unbounded.
+func NewGovernorRegistry(template *Governor, maxGovernors int) *GovernorRegistry {
+	if template == nil {
+		template = NewGovernor(1.5)
+	}
+	return &GovernorRegistry{
+		template:  template,
+		initialR:  template.rdynamics.InitialR,
+		max:       maxGovernors,
+		governors: make(map[string]*list.Element),
+		lru:       list.New(),
+	}
+}
+
+// For returns the governor for key, creating it on first use.
+// Concurrent calls for the same key return the same instance.
+func (r *GovernorRegistry) For(key string) *Governor {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if elem, ok := r.governors[key]; ok {
+		r.lru.MoveToFront(elem)
+		return elem.Value.(*registryEntry).governor
+	}
+
+	g := r.newGovernor()
+	r.governors[key] = r.lru.PushFront(&registryEntry{key: key, governor: g})
+
+	if r.max > 0 && r.lru.Len() > r.max {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.governors, oldest.Value.(*registryEntry).key)
+	}
+
+	return g
+}
+
+// newGovernor clones the template's configuration with fresh state.
+func (r *GovernorRegistry) newGovernor() *Governor {
+	return r.template.clone(r.initialR)
+}
+
+// Len returns the number of live governors.
+func (r *GovernorRegistry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lru.Len()
+}
+
+// Snapshot returns GetStatistics for every live governor, keyed like For.
+// Intended for a /lawbench status endpoint.
+func (r *GovernorRegistry) Snapshot() map[string]map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make(map[string]map[string]interface{}, len(r.governors))
+	for key, elem := range r.governors {
+		stats[key] = elem.Value.(*registryEntry).governor.GetStatistics()
+	}
+	return stats
+}
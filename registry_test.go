@@ -0,0 +1,121 @@
+package lawbench
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGovernorRegistry_IsolatesKeys(t *testing.T) {
+	reg := NewGovernorRegistry(NewGovernor(1.5), 0)
+
+	hot := reg.For("/api/search")
+	hot.Update(3.3, 0.05, 0.001, 64)
+
+	if action := reg.For("/api/order").Update(1.8, 0.05, 0.001, 4); action.Type != ActionStable {
+		t.Errorf("Healthy route should be STABLE, got %s", action.Type)
+	}
+	if action := reg.For("/api/search").Update(2.5, 0.05, 0.001, 64); action.Type != ActionThrottle {
+		t.Errorf("Hot route should stay throttled, got %s", action.Type)
+	}
+
+	snap := reg.Snapshot()
+	if len(snap) != 2 {
+		t.Fatalf("Expected 2 governors in snapshot, got %d", len(snap))
+	}
+	if snap["/api/search"]["throttles_applied"].(int) != 1 || snap["/api/order"]["throttles_applied"].(int) != 0 {
+		t.Errorf("Throttle counts leaked between routes: %v", snap)
+	}
+}
+
+func TestGovernorRegistry_TemplateConfig(t *testing.T) {
+	template := NewGovernor(2.0)
+	template.throttleMinDuration = 0
+
+	var transitions int
+	template.OnTransition(func(from, to ActionType, action Action) { transitions++ })
+	template.Update(3.5, 0.05, 0.001, 8) // Template state must not leak
+
+	g := NewGovernorRegistry(template, 0).For("tenant-a")
+	if g.rdynamics.CurrentR != 2.0 || g.inThrottleMode {
+		t.Errorf("New governor should start fresh from initial r, got r=%.2f throttle=%v",
+			g.rdynamics.CurrentR, g.inThrottleMode)
+	}
+	if g.throttleMinDuration != 0 {
+		t.Errorf("Expected template hysteresis timing, got %v", g.throttleMinDuration)
+	}
+
+	before := transitions
+	g.Update(2.85, 0.05, 0.001, 8)
+	if transitions != before+1 {
+		t.Errorf("Expected template OnTransition callback to fire")
+	}
+}
+
+func TestGovernorRegistry_LRUEviction(t *testing.T) {
+	reg := NewGovernorRegistry(NewGovernor(1.5), 2)
+
+	a := reg.For("a")
+	reg.For("b")
+	reg.For("a") // a is now most recent
+	reg.For("c") // evicts b
+
+	if reg.Len() != 2 {
+		t.Fatalf("Expected 2 governors, got %d", reg.Len())
+	}
+	if _, ok := reg.Snapshot()["b"]; ok {
+		t.Error("Expected least recently used key b to be evicted")
+	}
+	if reg.For("a") != a {
+		t.Error("Expected a to survive eviction")
+	}
+}
+
+func TestGovernorRegistry_ConcurrentFor(t *testing.T) {
+	reg := NewGovernorRegistry(nil, 0)
+
+	const goroutines = 64
+	got := make([]*Governor, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			got[i] = reg.For("same-key")
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 1; i < goroutines; i++ {
+		if got[i] != got[0] {
+			t.Fatalf("Goroutine %d got a different governor instance", i)
+		}
+	}
+}
+
+// TestGovernorRegistry_ConcurrentUpdateAndSnapshot verifies handlers can
+// update the governors For returns while a status endpoint takes
+// snapshots (run with -race).
+func TestGovernorRegistry_ConcurrentUpdateAndSnapshot(t *testing.T) {
+	reg := NewGovernorRegistry(nil, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			g := reg.For("route")
+			for j := 0; j < 100; j++ {
+				g.Update(2.0+float64(i)*0.1, 0.05, 0.001, j)
+				g.Backpressure()
+			}
+		}(i)
+	}
+	for j := 0; j < 100; j++ {
+		reg.Snapshot()
+	}
+	wg.Wait()
+
+	if got := reg.Snapshot()["route"]["history_length"].(int); got != 801 {
+		t.Errorf("Expected 801 r samples (initial + 800 updates), got %d", got)
+	}
+}
@@ -0,0 +1,72 @@
+package lawbench
+
+import "time"
+
+// ReplaySummary is what a governor would have decided over a recorded r
+// series (see ReplayRTrajectory).
+type ReplaySummary struct {
+	Samples      int                // Length of the replayed series
+	Actions      map[ActionType]int // Decisions per type, one per sample
+	ThrottleTime time.Duration      // Time spent in ActionThrottle (samples × interval)
+	Transitions  []ReplayTransition // Decision type changes, in order
+}
+
+// ReplayTransition is one change of decision type during a replay.
+type ReplayTransition struct {
+	Index int           // Sample index in the replayed series
+	At    time.Duration // Offset from the first sample (Index × interval)
+	From  ActionType
+	To    ActionType
+	R     float64 // r at the transition
+}
+
+// ReplayRTrajectory feeds a captured r time-series through a copy of g,
+// one sample every interval, and summarizes the decisions: counts per
+// ActionType, total time throttled and the transition timeline.
+//
+// The copy keeps g's configuration (thresholds, stability model, throttle
+// hysteresis) and starts fresh at rs[0]; g itself and its OnTransition
+// subscribers are untouched. Time is simulated, so an hour-long incident
+// replays instantly and hysteresis sees the recorded spacing. Use it to A/B
+// governor settings against the same incident:
+//
+//	strict := NewGovernor(2.0, WithStabilityModel(StabilityModel{MinR: 1, MaxR: 2.5}))
+//	before := ReplayRTrajectory(NewGovernor(2.0), incident, 10*time.Second)
+//	after := ReplayRTrajectory(strict, incident, 10*time.Second)
+func ReplayRTrajectory(g *Governor, rs []float64, interval time.Duration) ReplaySummary {
+	summary := ReplaySummary{
+		Samples: len(rs),
+		Actions: make(map[ActionType]int),
+	}
+	if len(rs) == 0 {
+		return summary
+	}
+
+	replay := g.clone(rs[0])
+	replay.transitionHandlers = nil
+
+	var start time.Time // Simulated clock: only offsets matter
+	replay.lastCheck = start
+
+	index := 0
+	replay.OnTransition(func(from, to ActionType, action Action) {
+		summary.Transitions = append(summary.Transitions, ReplayTransition{
+			Index: index,
+			At:    time.Duration(index) * interval,
+			From:  from,
+			To:    to,
+			R:     action.Metrics.EstimatedCoupling,
+		})
+	})
+
+	for i, r := range rs {
+		index = i
+		action := replay.transition(replay.evaluateR(r, start.Add(time.Duration(i)*interval)))
+		summary.Actions[action.Type]++
+		if action.Type == ActionThrottle {
+			summary.ThrottleTime += interval
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,80 @@
+package lawbench
+
+import (
+	"testing"
+	"time"
+)
+
+// TestReplayRTrajectory_SpikeAndRecover verifies a spike through saturation
+// and back produces exactly one throttle entry and one exit, with the exit
+// held back by hysteresis until the minimum throttle duration has passed.
+func TestReplayRTrajectory_SpikeAndRecover(t *testing.T) {
+	rs := []float64{
+		1.5, 1.5, 1.5, 1.5, 1.5, // Baseline
+		2.5, 2.9, 3.2, 3.4, 3.1, // Spike into saturation (entry at index 7)
+		2.6, 1.8, 1.5, 1.5, 1.5, // Recovery: r < 2.0 from index 11
+		1.5, 1.5, 1.5, 1.5, 1.5,
+	}
+	interval := 10 * time.Second
+
+	g := NewGovernor(1.5)
+	var notified int
+	g.OnTransition(func(from, to ActionType, action Action) { notified++ })
+
+	summary := ReplayRTrajectory(g, rs, interval)
+
+	var entries, exits []ReplayTransition
+	for _, tr := range summary.Transitions {
+		if tr.To == ActionThrottle {
+			entries = append(entries, tr)
+		}
+		if tr.From == ActionThrottle {
+			exits = append(exits, tr)
+		}
+	}
+	if len(entries) != 1 || len(exits) != 1 {
+		t.Fatalf("Expected one throttle entry and one exit, got %d and %d: %+v", len(entries), len(exits), summary.Transitions)
+	}
+	if entries[0].Index != 7 || entries[0].R != 3.2 {
+		t.Errorf("Throttle should start at index 7 (r=3.2), got %+v", entries[0])
+	}
+
+	// Hysteresis: 60s minimum, so the exit is at index 13 even though r < 2.0 at 11
+	if exits[0].Index != 13 || exits[0].At != 130*time.Second {
+		t.Errorf("Throttle should end at index 13 (130s), got %+v", exits[0])
+	}
+	if want := 6 * interval; summary.ThrottleTime != want {
+		t.Errorf("ThrottleTime = %v, want %v", summary.ThrottleTime, want)
+	}
+
+	total := 0
+	for _, n := range summary.Actions {
+		total += n
+	}
+	if total != len(rs) || summary.Samples != len(rs) {
+		t.Errorf("Expected %d decisions, got %d (%v)", len(rs), total, summary.Actions)
+	}
+
+	if notified != 0 || len(g.rdynamics.History) != 1 {
+		t.Errorf("Replay should not touch g: %d notifications, history %v", notified, g.rdynamics.History)
+	}
+
+	t.Logf("✓ %v, throttled %v", summary.Actions, summary.ThrottleTime)
+}
+
+// TestReplayRTrajectory_ABThresholds verifies the same series replays
+// differently under a stricter stability model.
+func TestReplayRTrajectory_ABThresholds(t *testing.T) {
+	rs := []float64{2.0, 2.4, 2.6, 2.7, 2.6, 2.4, 2.0}
+
+	loose := ReplayRTrajectory(NewGovernor(2.0), rs, time.Second)
+	strict := ReplayRTrajectory(NewGovernor(2.0, WithStabilityModel(StabilityModel{MinR: 1, MaxR: 2.5})), rs, time.Second)
+
+	if loose.Actions[ActionThrottle] != 0 {
+		t.Errorf("Default thresholds should not throttle below 2.8, got %v", loose.Actions)
+	}
+	if strict.Actions[ActionThrottle] == 0 {
+		t.Errorf("MaxR=2.5 should throttle at r=2.6, got %v", strict.Actions)
+	}
+	t.Logf("✓ Default: %v; MaxR=2.5: %v", loose.Actions, strict.Actions)
+}
@@ -0,0 +1,180 @@
+package lawbench
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Live retrograde detection defaults, used when the corresponding field is
+// zero.
+const (
+	// DefaultRetrogradeMinGain is the smallest marginal gain per added node,
+	// as a fraction of the average per-node throughput below it, that still
+	// counts as scaling. Below 5% the nodes cost more than they return.
+	DefaultRetrogradeMinGain = 0.05
+
+	// DefaultRetrogradeSmoothing is the EWMA weight of a new sample at a
+	// node count already observed.
+	DefaultRetrogradeSmoothing = 0.3
+
+	// DefaultRetrogradeMaxAge is how long a node count's throughput stays
+	// valid without a new sample. Capacity drifts with deploys and traffic
+	// mix, so a peak measured hours ago says little about the current one.
+	DefaultRetrogradeMaxAge = 10 * time.Minute
+)
+
+// LiveRetrogradeDetector flags retrograde scaling from live (N, throughput)
+// samples, without α and β from an offline USL fit (compare IsRetrograde).
+//
+// Samples at the same N are smoothed by EWMA. The system is retrograde when
+// the current N (the last one observed) gains too little over the best
+// smaller N: the marginal throughput per added node is below MinGain of the
+// per-node throughput there, or negative. It needs samples at two node
+// counts before it can flag anything, and recovers on its own once the
+// autoscaler steps back below the peak. A node count not observed for
+// MaxAge is forgotten.
+//
+// Throughput only measures capacity while the nodes are saturated. Below
+// saturation it tracks demand, which is flat across N, so an
+// under-utilized fleet that scales out looks retrograde. Feed Observe only
+// samples taken at high utilization (or rising latency), e.g. the ones that
+// made the autoscaler scale out.
+//
+// Tunables are exported fields; zero means the default. Safe for
+// concurrent use.
+type LiveRetrogradeDetector struct {
+	MinGain   float64       // Marginal gain threshold (default: DefaultRetrogradeMinGain)
+	Smoothing float64       // EWMA weight per sample (default: DefaultRetrogradeSmoothing)
+	MaxAge    time.Duration // Sample lifetime per N (default: DefaultRetrogradeMaxAge)
+
+	mu         sync.Mutex
+	throughput map[int]retrogradeSample // Smoothed throughput per N
+	current    int                      // Last observed N
+	now        func() time.Time         // nil = time.Now
+}
+
+// retrogradeSample is the smoothed throughput at one N.
+type retrogradeSample struct {
+	throughput float64
+	at         time.Time // Last observed
+}
+
+// NewLiveRetrogradeDetector creates a detector with default tunables.
+func NewLiveRetrogradeDetector() *LiveRetrogradeDetector {
+	return &LiveRetrogradeDetector{}
+}
+
+// Observe records the throughput measured with n nodes. Samples with n ≤ 0
+// or a negative or non-finite throughput are ignored.
+func (d *LiveRetrogradeDetector) Observe(n int, throughput float64) {
+	if n <= 0 || throughput < 0 || !isFinite(throughput) {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.throughput == nil {
+		d.throughput = make(map[int]retrogradeSample)
+	}
+	now := d.clock()
+	d.expire(now)
+	if prev, ok := d.throughput[n]; ok {
+		smoothing := d.Smoothing
+		if smoothing <= 0 || smoothing > 1 {
+			smoothing = DefaultRetrogradeSmoothing
+		}
+		throughput = prev.throughput + smoothing*(throughput-prev.throughput)
+	}
+	d.throughput[n] = retrogradeSample{throughput: throughput, at: now}
+	d.current = n
+}
+
+// InRetrograde reports whether adding nodes up to the current N stopped
+// paying off.
+func (d *LiveRetrogradeDetector) InRetrograde() bool {
+	return d.MarginalGain() < d.minGain()
+}
+
+// MarginalGain returns the throughput gained per node from the best
+// smaller N to the current one, as a fraction of the per-node throughput
+// at that smaller N: about 1 for linear scaling, 0 when flat, negative
+// when retrograde. Returns +Inf until a smaller N has been observed.
+func (d *LiveRetrogradeDetector) MarginalGain() float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.expire(d.clock())
+	sample, ok := d.throughput[d.current]
+	if !ok {
+		return math.Inf(1)
+	}
+	current := sample.throughput
+
+	baseN, base := 0, math.Inf(-1)
+	for n, s := range d.throughput {
+		if n < d.current && s.throughput > base {
+			baseN, base = n, s.throughput
+		}
+	}
+	if baseN == 0 || base <= 0 {
+		return math.Inf(1)
+	}
+
+	perNode := base / float64(baseN)
+	return (current - base) / float64(d.current-baseN) / perNode
+}
+
+// PeakN returns the node count with the highest observed throughput (0 if
+// nothing was observed), the live estimate of N_peak.
+func (d *LiveRetrogradeDetector) PeakN() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.expire(d.clock())
+	ns := make([]int, 0, len(d.throughput))
+	for n := range d.throughput {
+		ns = append(ns, n)
+	}
+	sort.Ints(ns) // Ties go to the smaller N: same throughput, fewer nodes
+
+	peak := 0
+	for _, n := range ns {
+		if peak == 0 || d.throughput[n].throughput > d.throughput[peak].throughput {
+			peak = n
+		}
+	}
+	return peak
+}
+
+// minGain returns MinGain or its default.
+func (d *LiveRetrogradeDetector) minGain() float64 {
+	if d.MinGain <= 0 {
+		return DefaultRetrogradeMinGain
+	}
+	return d.MinGain
+}
+
+// expire forgets node counts not observed within MaxAge. Caller must hold
+// the lock.
+func (d *LiveRetrogradeDetector) expire(now time.Time) {
+	maxAge := d.MaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultRetrogradeMaxAge
+	}
+	for n, s := range d.throughput {
+		if now.Sub(s.at) > maxAge {
+			delete(d.throughput, n)
+		}
+	}
+}
+
+// clock returns the detector's current time.
+func (d *LiveRetrogradeDetector) clock() time.Time {
+	if d.now != nil {
+		return d.now()
+	}
+	return time.Now()
+}
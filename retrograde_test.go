@@ -0,0 +1,104 @@
+package lawbench
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestLiveRetrogradeDetector_RiseThenFall verifies a throughput curve that
+// rises then falls is flagged only past its peak, agreeing with the USL
+// model away from the peak, and clears after scaling back.
+func TestLiveRetrogradeDetector_RiseThenFall(t *testing.T) {
+	const lambda, alpha, beta = 1000.0, 0.05, 0.01 // N_peak ≈ 9.7
+
+	d := NewLiveRetrogradeDetector()
+	if d.InRetrograde() {
+		t.Fatal("Empty detector should not be retrograde")
+	}
+
+	for _, n := range []int{1, 2, 4, 6, 8, 10, 12, 16, 20} {
+		d.Observe(n, EstimateThroughput(n, lambda, alpha, beta))
+
+		got := d.InRetrograde()
+		if n <= 6 && got {
+			t.Errorf("N=%d: flagged retrograde while still scaling (gain %.3f)", n, d.MarginalGain())
+		}
+		if n >= 12 && !got {
+			t.Errorf("N=%d: throughput falling but not flagged (gain %.3f)", n, d.MarginalGain())
+		}
+		if (n <= 6 || n >= 12) && got != IsRetrograde(n, alpha, beta) {
+			t.Errorf("N=%d: live %v disagrees with USL IsRetrograde", n, got)
+		}
+
+		t.Logf("  N=%-2d: %7.1f ops/s, gain %+.3f, retrograde=%v",
+			n, EstimateThroughput(n, lambda, alpha, beta), d.MarginalGain(), got)
+	}
+
+	if peak := d.PeakN(); peak != 10 {
+		t.Errorf("PeakN = %d, want 10", peak)
+	}
+
+	// Autoscaler steps back below the peak
+	d.Observe(8, EstimateThroughput(8, lambda, alpha, beta))
+	if d.InRetrograde() {
+		t.Errorf("Back at N=8 should not be retrograde (gain %.3f)", d.MarginalGain())
+	}
+
+	t.Logf("✓ Live N_peak=%d (USL %.1f)", d.PeakN(), CalculatePeakCapacity(alpha, beta))
+}
+
+// TestLiveRetrogradeDetector_SmoothingAndInputs verifies repeated samples
+// are smoothed, invalid ones ignored, and a single N never flags.
+func TestLiveRetrogradeDetector_SmoothingAndInputs(t *testing.T) {
+	d := &LiveRetrogradeDetector{Smoothing: 0.5}
+	d.Observe(4, 400)
+	d.Observe(0, 1e9)
+	d.Observe(4, math.NaN())
+	d.Observe(4, -1)
+	if d.InRetrograde() || !math.IsInf(d.MarginalGain(), 1) {
+		t.Errorf("A single N should not flag, gain %.3f", d.MarginalGain())
+	}
+
+	d.Observe(8, 500)
+	d.Observe(8, 900) // Smoothed: 500 + 0.5×400 = 700
+	if want := (700.0 - 400) / 4 / 100; math.Abs(d.MarginalGain()-want) > 1e-12 {
+		t.Errorf("MarginalGain = %.4f, want %.4f", d.MarginalGain(), want)
+	}
+
+	d.Observe(12, 710) // +10 over 4 nodes: 2.5 per node vs 58 average
+	if !d.InRetrograde() {
+		t.Errorf("Flat throughput should flag retrograde, gain %.4f", d.MarginalGain())
+	}
+}
+
+// TestLiveRetrogradeDetector_MaxAge verifies a stale peak is forgotten: after
+// capacity improves, scaling past the old peak is judged on fresh samples.
+func TestLiveRetrogradeDetector_MaxAge(t *testing.T) {
+	clock := time.Unix(0, 0)
+	d := &LiveRetrogradeDetector{MaxAge: time.Minute}
+	d.now = func() time.Time { return clock }
+
+	d.Observe(4, 400)
+	d.Observe(8, 810) // Old capacity: N=8 peak
+
+	// A deploy halves per-node cost; the old N=8 sample ages out
+	clock = clock.Add(2 * time.Minute)
+	d.Observe(10, 900)
+	if d.InRetrograde() || d.PeakN() != 10 {
+		t.Errorf("Stale samples should be forgotten: gain %.3f, peak %d", d.MarginalGain(), d.PeakN())
+	}
+
+	d.Observe(12, 1700)
+	if d.InRetrograde() {
+		t.Errorf("Fresh scaling flagged retrograde, gain %.3f", d.MarginalGain())
+	}
+
+	// Without fresh samples the current N expires too
+	clock = clock.Add(2 * time.Minute)
+	if !math.IsInf(d.MarginalGain(), 1) || d.PeakN() != 0 {
+		t.Errorf("All samples stale: gain %.3f, peak %d", d.MarginalGain(), d.PeakN())
+	}
+
+	t.Logf("✓ Samples older than %v are forgotten", d.MaxAge)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 )
 
@@ -18,9 +19,81 @@ type LawVerified struct {
 }
 
 // RuntimeLawChecker validates unknown types at runtime using reflection.
+//
+// Per-type reflection results are cached, so only the first CheckType or
+// SafeMerge of a given type pays for them. The caches are safe for
+// concurrent use; Register is not and should happen before serving traffic.
 type RuntimeLawChecker struct {
+	// MaxVerificationAge, when positive, makes ValidateBoundary reject
+	// proofs whose TestedAt is older than this (see CheckTypeFresh).
+	MaxVerificationAge time.Duration
+
+	// SpotCheckLaws makes SafeMergeVerified re-check claimed laws on the
+	// actual operands. Doubles merge cost for Commutative types.
+	SpotCheckLaws bool
+
 	// Registry of verified types (populated at test time)
 	verified map[string]LawVerified
+
+	// Reflection caches
+	types sync.Map // reflect.Type → *typeInfo
+	plans sync.Map // mergePlanKey → *mergePlan
+}
+
+// typeInfo is the cached reflection result for one type.
+type typeInfo struct {
+	name          string // reflect.Type.String()
+	embeddedField int    // Index of the embedded LawVerified field (-1 = none)
+	isPtr         bool   // Embedded field is reached through a pointer
+}
+
+// mergePlanKey identifies a (merge function type, operand type) pair.
+type mergePlanKey struct {
+	fn, operand reflect.Type
+}
+
+// mergePlan is everything SafeMerge needs to know about a merge of
+// same-typed operands, resolved with a single cache lookup.
+type mergePlan struct {
+	operand *typeInfo
+	sigErr  error // nil = mergeFn is func(T, T) T
+}
+
+var (
+	lawVerifiedType = reflect.TypeOf(LawVerified{})
+	lawsFieldIndex  = lawVerifiedFieldIndex("Laws")
+	testedAtIndex   = lawVerifiedFieldIndex("TestedAt")
+)
+
+func lawVerifiedFieldIndex(name string) int {
+	f, _ := lawVerifiedType.FieldByName(name)
+	return f.Index[0]
+}
+
+// typeInfoFor returns the cached reflection result for t, computing it on
+// first use.
+func (r *RuntimeLawChecker) typeInfoFor(t reflect.Type) *typeInfo {
+	if cached, ok := r.types.Load(t); ok {
+		return cached.(*typeInfo)
+	}
+
+	info := &typeInfo{name: t.String(), embeddedField: -1}
+	st := t
+	if st.Kind() == reflect.Ptr {
+		st = st.Elem()
+		info.isPtr = true
+	}
+	if st.Kind() == reflect.Struct {
+		for i := 0; i < st.NumField(); i++ {
+			if st.Field(i).Type == lawVerifiedType {
+				info.embeddedField = i
+				break
+			}
+		}
+	}
+
+	actual, _ := r.types.LoadOrStore(t, info)
+	return actual.(*typeInfo)
 }
 
 // NewRuntimeLawChecker creates a checker with an empty registry.
@@ -50,15 +123,38 @@ func (r *RuntimeLawChecker) CheckType(v interface{}, requiredLaws []string) erro
 		return fmt.Errorf("nil value cannot be verified")
 	}
 
-	typeName := t.String()
+	return r.checkType(v, r.typeInfoFor(t), requiredLaws, 0)
+}
+
+// CheckTypeFresh is CheckType that also rejects proofs older than maxAge
+// (by TestedAt), so law verification has to be re-run periodically, e.g.
+// every release cycle. maxAge ≤ 0 disables the age check.
+func (r *RuntimeLawChecker) CheckTypeFresh(v interface{}, requiredLaws []string, maxAge time.Duration) error {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return fmt.Errorf("nil value cannot be verified")
+	}
+
+	return r.checkType(v, r.typeInfoFor(t), requiredLaws, maxAge)
+}
+
+// checkType is CheckType with the type's reflection info already resolved.
+// maxAge > 0 also enforces proof freshness.
+func (r *RuntimeLawChecker) checkType(v interface{}, info *typeInfo, requiredLaws []string, maxAge time.Duration) error {
+	typeName := info.name
 
 	// Check if type is in registry
+	var laws []string
+	var testedAt time.Time
 	verified, ok := r.verified[typeName]
-	if !ok {
+	if ok {
+		laws = verified.Laws
+		testedAt = verified.TestedAt
+	} else {
 		// Type not verified - check if it embeds LawVerified
-		if embed := r.extractEmbedded(v); embed != nil {
-			verified = *embed
-			ok = true
+		laws, ok = embeddedLaws(v, info)
+		if ok && maxAge > 0 {
+			testedAt = embeddedTestedAt(v, info)
 		}
 	}
 
@@ -66,38 +162,97 @@ func (r *RuntimeLawChecker) CheckType(v interface{}, requiredLaws []string) erro
 		return fmt.Errorf("type %s not in verified registry (did it pass lawtest?)", typeName)
 	}
 
-	// Check if it implements required laws
+	if maxAge > 0 {
+		if err := requireFresh(typeName, testedAt, maxAge); err != nil {
+			return err
+		}
+	}
+
+	return requireLaws(typeName, laws, requiredLaws)
+}
+
+// requireFresh checks that a proof was produced within maxAge.
+func requireFresh(typeName string, testedAt time.Time, maxAge time.Duration) error {
+	if testedAt.IsZero() {
+		return fmt.Errorf("type %s verification has no TestedAt (max age %v)", typeName, maxAge)
+	}
+
+	age := time.Since(testedAt)
+	if age > maxAge {
+		return fmt.Errorf("type %s verification is stale: tested at %s (%v ago, max age %v) - re-run lawtest",
+			typeName, testedAt.Format(time.RFC3339), age.Round(time.Second), maxAge)
+	}
+	return nil
+}
+
+// requireLaws checks that laws covers every required law.
+func requireLaws(typeName string, laws, requiredLaws []string) error {
 	for _, required := range requiredLaws {
-		if !contains(verified.Laws, required) {
+		if !contains(laws, required) {
 			return fmt.Errorf("type %s missing required law: %s (has: %v)",
-				typeName, required, verified.Laws)
+				typeName, required, laws)
 		}
 	}
-
 	return nil
 }
 
-// extractEmbedded checks if value embeds LawVerified struct.
-func (r *RuntimeLawChecker) extractEmbedded(v interface{}) *LawVerified {
+// embeddedLaws reads the Laws of v's embedded LawVerified, if any, without
+// copying the whole struct.
+func embeddedLaws(v interface{}, info *typeInfo) ([]string, bool) {
+	if info.embeddedField < 0 {
+		return nil, false
+	}
+
+	val := reflect.ValueOf(v)
+	if info.isPtr {
+		if val.IsNil() {
+			return nil, false
+		}
+		val = val.Elem()
+	}
+
+	return val.Field(info.embeddedField).Field(lawsFieldIndex).Interface().([]string), true
+}
+
+// embeddedTestedAt reads TestedAt of v's embedded LawVerified. v must embed
+// one (embeddedLaws returned ok).
+func embeddedTestedAt(v interface{}, info *typeInfo) time.Time {
 	val := reflect.ValueOf(v)
-	if val.Kind() == reflect.Ptr {
+	if info.isPtr {
 		val = val.Elem()
 	}
-	if val.Kind() != reflect.Struct {
-		return nil
+	return val.Field(info.embeddedField).Field(testedAtIndex).Interface().(time.Time)
+}
+
+// mergePlanFor returns the cached plan for merging operands of type operand
+// with a function of type fnType, computing it on first use. Signature
+// failures are cached too.
+func (r *RuntimeLawChecker) mergePlanFor(fnType, operand reflect.Type) *mergePlan {
+	key := mergePlanKey{fn: fnType, operand: operand}
+	if cached, ok := r.plans.Load(key); ok {
+		return cached.(*mergePlan)
 	}
 
-	// Look for embedded LawVerified field
-	typ := val.Type()
-	for i := 0; i < val.NumField(); i++ {
-		field := typ.Field(i)
-		if field.Type == reflect.TypeOf(LawVerified{}) {
-			lv := val.Field(i).Interface().(LawVerified)
-			return &lv
-		}
+	plan := &mergePlan{
+		operand: r.typeInfoFor(operand),
+		sigErr:  checkMergeSignature(fnType, operand),
 	}
+	actual, _ := r.plans.LoadOrStore(key, plan)
+	return actual.(*mergePlan)
+}
 
-	return nil
+// checkMergeSignature verifies mergeFn is func(T, T) T for operand type T.
+func checkMergeSignature(fnType, operand reflect.Type) error {
+	var err error
+	switch {
+	case fnType.Kind() != reflect.Func:
+		err = fmt.Errorf("mergeFn must be a function, got %s", fnType.Kind())
+	case fnType.NumIn() != 2 || fnType.NumOut() != 1:
+		err = fmt.Errorf("mergeFn must have signature func(T, T) T, got %s", fnType)
+	case fnType.In(0) != operand || fnType.In(1) != operand || fnType.Out(0) != operand:
+		err = fmt.Errorf("mergeFn signature mismatch: expected func(%s, %s) %s", operand, operand, operand)
+	}
+	return err
 }
 
 // SafeMerge attempts to merge two values using a merge function.
@@ -105,8 +260,10 @@ func (r *RuntimeLawChecker) extractEmbedded(v interface{}) *LawVerified {
 // Returns error if types are incompatible or unverified.
 //
 // PERFORMANCE WARNING: This uses reflection (slow). Suitable for CONTROL PLANE only.
-// For DATA PLANE (event folding, hot path), use code generation or Go generics.
-// Reflection overhead: ~1000ns per call vs ~1ns for direct call.
+// For DATA PLANE (event folding, hot path), use SafeMergeG (Go generics).
+// Reflection overhead: ~1000ns per call vs ~1ns for direct call. Type and
+// signature checks are cached after the first call per type, but the
+// reflective call itself remains.
 // Violates Power conservation (T × S × P) if used in tight loops.
 func (r *RuntimeLawChecker) SafeMerge(
 	ctx context.Context,
@@ -114,32 +271,34 @@ func (r *RuntimeLawChecker) SafeMerge(
 	mergeFn interface{}, // func(A, A) A
 	requiredLaws []string,
 ) (interface{}, error) {
-	// Validate inputs
-	if err := r.CheckType(a, requiredLaws); err != nil {
-		return nil, fmt.Errorf("first argument: %w", err)
-	}
-	if err := r.CheckType(b, requiredLaws); err != nil {
-		return nil, fmt.Errorf("second argument: %w", err)
-	}
-
-	// Validate types match
 	ta := reflect.TypeOf(a)
 	tb := reflect.TypeOf(b)
-	if ta != tb {
-		return nil, fmt.Errorf("type mismatch: %s != %s", ta, tb)
+	fnVal := reflect.ValueOf(mergeFn)
+
+	if ta == nil || ta != tb || !fnVal.IsValid() {
+		// Report the first problem in argument order
+		if err := r.CheckType(a, requiredLaws); err != nil {
+			return nil, fmt.Errorf("first argument: %w", err)
+		}
+		if err := r.CheckType(b, requiredLaws); err != nil {
+			return nil, fmt.Errorf("second argument: %w", err)
+		}
+		if ta != tb {
+			return nil, fmt.Errorf("type mismatch: %s != %s", ta, tb)
+		}
+		return nil, fmt.Errorf("mergeFn must be a function, got nil")
 	}
 
-	// Validate merge function signature
-	fnVal := reflect.ValueOf(mergeFn)
-	fnType := fnVal.Type()
-	if fnType.Kind() != reflect.Func {
-		return nil, fmt.Errorf("mergeFn must be a function, got %s", fnType.Kind())
+	// Validate inputs and merge function signature (one cache lookup)
+	plan := r.mergePlanFor(fnVal.Type(), ta)
+	if err := r.checkType(a, plan.operand, requiredLaws, 0); err != nil {
+		return nil, fmt.Errorf("first argument: %w", err)
 	}
-	if fnType.NumIn() != 2 || fnType.NumOut() != 1 {
-		return nil, fmt.Errorf("mergeFn must have signature func(T, T) T, got %s", fnType)
+	if err := r.checkType(b, plan.operand, requiredLaws, 0); err != nil {
+		return nil, fmt.Errorf("second argument: %w", err)
 	}
-	if fnType.In(0) != ta || fnType.In(1) != ta || fnType.Out(0) != ta {
-		return nil, fmt.Errorf("mergeFn signature mismatch: expected func(%s, %s) %s", ta, ta, ta)
+	if plan.sigErr != nil {
+		return nil, plan.sigErr
 	}
 
 	// Execute merge
@@ -148,6 +307,85 @@ func (r *RuntimeLawChecker) SafeMerge(
 	return results[0].Interface(), nil
 }
 
+// Verified pairs a value with its lawtest proof for the generic, reflection-free
+// merge path (SafeMergeG).
+type Verified[T any] struct {
+	LawVerified
+	Value T
+}
+
+// SafeMergeG is the DATA PLANE counterpart of SafeMerge.
+//
+// Laws are validated the same way (registry entry for T, falling back to the
+// embedded proof), and a proof whose TypeName is not T's (as reflect prints
+// it, e.g. "lawbench.Counter") is rejected, so a proof for one type cannot
+// vouch for another. The merge itself is a direct generic
+// call: no reflect.Value.Call, no boxing, no allocation on success. Types
+// and the merge signature are checked by the compiler instead of at runtime.
+//
+// Example:
+//
+//	a := Verified[Counter]{LawVerified: proof, Value: Counter{N: 1}}
+//	b := Verified[Counter]{LawVerified: proof, Value: Counter{N: 2}}
+//	sum, err := SafeMergeG(checker, a, b, MergeCounter, []string{"Associative"})
+func SafeMergeG[T any](checker *RuntimeLawChecker, a, b Verified[T], merge func(T, T) T, laws []string) (T, error) {
+	typeName := reflect.TypeOf((*T)(nil)).Elem().String()
+	if err := checker.checkProof(typeName, a.LawVerified, laws); err != nil {
+		var zero T
+		return zero, fmt.Errorf("first argument: %w", err)
+	}
+	if err := checker.checkProof(typeName, b.LawVerified, laws); err != nil {
+		var zero T
+		return zero, fmt.Errorf("second argument: %w", err)
+	}
+
+	return merge(a.Value, b.Value), nil
+}
+
+// checkProof validates an embedded proof for the type named typeName,
+// preferring that type's registry entry.
+func (r *RuntimeLawChecker) checkProof(typeName string, proof LawVerified, requiredLaws []string) error {
+	if proof.TypeName != typeName {
+		return fmt.Errorf("proof for type %q cannot vouch for %s", proof.TypeName, typeName)
+	}
+
+	laws := proof.Laws
+	if verified, ok := r.verified[typeName]; ok {
+		laws = verified.Laws
+	} else if len(laws) == 0 {
+		return fmt.Errorf("type %q not in verified registry (did it pass lawtest?)", typeName)
+	}
+
+	return requireLaws(typeName, laws, requiredLaws)
+}
+
+// SafeMergeVerified is SafeMerge with optional defense in depth at the
+// boundary: when SpotCheckLaws is set and the type claims "Commutative",
+// it also computes merge(b, a) and rejects the merge if the two results
+// differ (reflect.DeepEqual). That catches a proof that went stale after a
+// code change. Associativity needs three operands, so it is not checked here.
+func (r *RuntimeLawChecker) SafeMergeVerified(
+	ctx context.Context,
+	a, b interface{},
+	mergeFn interface{}, // func(A, A) A
+	requiredLaws []string,
+) (interface{}, error) {
+	result, err := r.SafeMerge(ctx, a, b, mergeFn, requiredLaws)
+	if err != nil || !r.SpotCheckLaws {
+		return result, err
+	}
+
+	if r.CheckType(a, []string{"Commutative"}) == nil {
+		swapped := reflect.ValueOf(mergeFn).Call([]reflect.Value{reflect.ValueOf(b), reflect.ValueOf(a)})[0].Interface()
+		if !reflect.DeepEqual(result, swapped) {
+			return nil, fmt.Errorf("type %s claims Commutative but merge(a, b) != merge(b, a) - re-run lawtest",
+				reflect.TypeOf(a))
+		}
+	}
+
+	return result, nil
+}
+
 // MustMerge is like SafeMerge but panics on error.
 // Use when you've already validated types at system boundary.
 func (r *RuntimeLawChecker) MustMerge(
@@ -165,6 +403,7 @@ func (r *RuntimeLawChecker) MustMerge(
 
 // ValidateBoundary checks untrusted input at system boundary.
 // This is the key insight: use reflection to test compatibility at runtime!
+// If MaxVerificationAge is set, stale proofs are rejected as well.
 //
 // Example:
 //
@@ -178,7 +417,7 @@ func (r *RuntimeLawChecker) MustMerge(
 //	    return processConfig(raw)
 //	}
 func (r *RuntimeLawChecker) ValidateBoundary(v interface{}, requiredLaws []string) error {
-	return r.CheckType(v, requiredLaws)
+	return r.CheckTypeFresh(v, requiredLaws, r.MaxVerificationAge)
 }
 
 // contains checks if slice contains string.
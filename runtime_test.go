@@ -2,6 +2,9 @@ package lawbench
 
 import (
 	"context"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -229,6 +232,342 @@ func TestRuntimeLawChecker_EmbeddedDetection(t *testing.T) {
 	t.Log("✓ Embedded LawVerified detected and validated")
 }
 
+// TestRuntimeLawChecker_SafeMerge_CachedSignatureErrors verifies cached
+// signature checks still reject bad merge functions on every call.
+func TestRuntimeLawChecker_SafeMerge_CachedSignatureErrors(t *testing.T) {
+	checker := NewRuntimeLawChecker()
+	proof := LawVerified{TypeName: "lawbench.VerifiedConfig", Laws: []string{"Associative"}}
+	a := VerifiedConfig{LawVerified: proof}
+	b := VerifiedConfig{LawVerified: proof}
+
+	tests := []struct {
+		name    string
+		mergeFn interface{}
+		wantErr string
+	}{
+		{"not a function", 42, "must be a function"},
+		{"nil", nil, "must be a function"},
+		{"wrong arity", func(a VerifiedConfig) VerifiedConfig { return a }, "func(T, T) T"},
+		{"wrong types", func(a, b int) int { return a }, "signature mismatch"},
+	}
+
+	for _, tt := range tests {
+		for call := 0; call < 2; call++ { // Second call is served from the cache
+			_, err := checker.SafeMerge(context.Background(), a, b, tt.mergeFn, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s (call %d): expected error containing %q, got %v", tt.name, call+1, tt.wantErr, err)
+			}
+		}
+	}
+}
+
+// TestRuntimeLawChecker_SafeMerge_Concurrent verifies the reflection caches
+// are safe under concurrent SafeMerge calls (run with -race).
+func TestRuntimeLawChecker_SafeMerge_Concurrent(t *testing.T) {
+	checker := NewRuntimeLawChecker()
+	proof := LawVerified{TypeName: "lawbench.VerifiedConfig", Laws: []string{"Associative"}}
+	checker.Register(proof)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				a := &VerifiedConfig{LawVerified: proof, Data: map[string]string{"g": "a"}}
+				b := &VerifiedConfig{LawVerified: proof, Data: map[string]string{"g": "b"}}
+				if _, err := checker.SafeMerge(context.Background(), *a, *b, MergeConfig, []string{"Associative"}); err != nil {
+					errs <- err
+					return
+				}
+				// Pointer type takes the embedded-extraction path
+				if err := checker.CheckType(a, []string{"Associative"}); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Concurrent SafeMerge failed: %v", err)
+	}
+}
+
+// verifiedCounter is a minimal verified type, so benchmarks measure the
+// reflection overhead rather than the merge itself.
+type verifiedCounter struct {
+	LawVerified
+	N int
+}
+
+func mergeCounter(a, b verifiedCounter) verifiedCounter {
+	a.N += b.N
+	return a
+}
+
+// BenchmarkRuntimeLawChecker_SafeMerge compares the first SafeMerge of a type
+// (fresh checker, cold caches) with repeated merges of the same type.
+func BenchmarkRuntimeLawChecker_SafeMerge(b *testing.B) {
+	proof := LawVerified{TypeName: "lawbench.verifiedCounter", Laws: []string{"Associative"}}
+	x := verifiedCounter{LawVerified: proof, N: 1}
+	ctx := context.Background()
+	laws := []string{"Associative"}
+
+	b.Run("Cold", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			checker := NewRuntimeLawChecker()
+			if _, err := checker.SafeMerge(ctx, x, x, mergeCounter, laws); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Cached", func(b *testing.B) {
+		b.ReportAllocs()
+		checker := NewRuntimeLawChecker()
+		for i := 0; i < b.N; i++ {
+			if _, err := checker.SafeMerge(ctx, x, x, mergeCounter, laws); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// TestRuntimeLawChecker_CheckTypeFresh verifies stale proofs are rejected,
+// for both registered and embedded proofs.
+func TestRuntimeLawChecker_CheckTypeFresh(t *testing.T) {
+	const maxAge = 30 * 24 * time.Hour // One release cycle
+	laws := []string{"Associative"}
+
+	tests := []struct {
+		name     string
+		testedAt time.Time
+		register bool
+		wantErr  string
+	}{
+		{"recent embedded", time.Now().Add(-time.Hour), false, ""},
+		{"recent registered", time.Now().Add(-time.Hour), true, ""},
+		{"stale embedded", time.Now().AddDate(-3, 0, 0), false, "stale"},
+		{"stale registered", time.Now().AddDate(-3, 0, 0), true, "stale"},
+		{"never tested", time.Time{}, false, "no TestedAt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewRuntimeLawChecker()
+			proof := LawVerified{TypeName: "lawbench.VerifiedConfig", Laws: laws, TestedAt: tt.testedAt}
+			if tt.register {
+				checker.Register(proof)
+			}
+			config := VerifiedConfig{LawVerified: proof}
+			if tt.register {
+				config.LawVerified = LawVerified{} // Registry entry is authoritative
+			}
+
+			err := checker.CheckTypeFresh(config, laws, maxAge)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected fresh proof to pass, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if !tt.testedAt.IsZero() && !strings.Contains(err.Error(), tt.testedAt.Format(time.RFC3339)) {
+				t.Errorf("Error should name TestedAt: %v", err)
+			}
+			t.Logf("✓ Rejected: %v", err)
+
+			// Without an age limit the same proof is accepted
+			if err := checker.CheckType(config, laws); err != nil {
+				t.Errorf("CheckType should ignore age, got %v", err)
+			}
+		})
+	}
+}
+
+// TestRuntimeLawChecker_ValidateBoundary_MaxVerificationAge verifies the
+// checker-level default applies at the boundary.
+func TestRuntimeLawChecker_ValidateBoundary_MaxVerificationAge(t *testing.T) {
+	checker := NewRuntimeLawChecker()
+	stale := VerifiedConfig{LawVerified: LawVerified{
+		TypeName: "lawbench.VerifiedConfig",
+		Laws:     []string{"Associative"},
+		TestedAt: time.Now().AddDate(-1, 0, 0),
+	}}
+
+	if err := checker.ValidateBoundary(stale, []string{"Associative"}); err != nil {
+		t.Errorf("No MaxVerificationAge: expected pass, got %v", err)
+	}
+
+	checker.MaxVerificationAge = 90 * 24 * time.Hour
+	if err := checker.ValidateBoundary(stale, []string{"Associative"}); err == nil {
+		t.Error("MaxVerificationAge=90d: expected a year-old proof to be rejected")
+	}
+}
+
+// TestRuntimeLawChecker_SafeMergeVerified_SpotCheck verifies the opt-in
+// commutativity spot-check catches a merge function that breaks its proof.
+func TestRuntimeLawChecker_SafeMergeVerified_SpotCheck(t *testing.T) {
+	proof := LawVerified{
+		TypeName: "lawbench.VerifiedConfig",
+		Laws:     []string{"Associative", "Commutative"},
+		TestedAt: time.Now(),
+	}
+	a := VerifiedConfig{LawVerified: proof, Data: map[string]string{"k": "a"}}
+	b := VerifiedConfig{LawVerified: proof, Data: map[string]string{"k": "b"}}
+
+	// Commutative: keep the larger value per key
+	maxMerge := func(x, y VerifiedConfig) VerifiedConfig {
+		out := VerifiedConfig{LawVerified: x.LawVerified, Data: map[string]string{}}
+		for _, d := range []map[string]string{x.Data, y.Data} {
+			for k, v := range d {
+				if v > out.Data[k] {
+					out.Data[k] = v
+				}
+			}
+		}
+		return out
+	}
+
+	tests := []struct {
+		name      string
+		spotCheck bool
+		mergeFn   func(x, y VerifiedConfig) VerifiedConfig
+		wantErr   bool
+	}{
+		{"right-wins, spot-check off", false, MergeConfig, false},
+		{"right-wins, spot-check on", true, MergeConfig, true},
+		{"max-wins, spot-check on", true, maxMerge, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewRuntimeLawChecker()
+			checker.Register(proof)
+			checker.SpotCheckLaws = tt.spotCheck
+
+			_, err := checker.SafeMergeVerified(context.Background(), a, b, tt.mergeFn, []string{"Associative"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), "Commutative") {
+					t.Errorf("Error should name the broken law: %v", err)
+				}
+				t.Logf("✓ Caught: %v", err)
+			}
+		})
+	}
+}
+
+// TestSafeMergeG_MatchesSafeMerge verifies the generic path produces the same
+// result as the reflective SafeMerge for VerifiedConfig.
+func TestSafeMergeG_MatchesSafeMerge(t *testing.T) {
+	checker := NewRuntimeLawChecker()
+	proof := LawVerified{
+		TypeName: "lawbench.VerifiedConfig",
+		Laws:     []string{"Associative", "Commutative", "Idempotent"},
+		TestedAt: time.Now(),
+	}
+	checker.Register(proof)
+
+	a := VerifiedConfig{LawVerified: proof, Data: map[string]string{"a": "1", "b": "2"}}
+	b := VerifiedConfig{LawVerified: proof, Data: map[string]string{"b": "3", "c": "4"}}
+	laws := []string{"Associative"}
+
+	reflective, err := checker.SafeMerge(context.Background(), a, b, MergeConfig, laws)
+	if err != nil {
+		t.Fatalf("SafeMerge failed: %v", err)
+	}
+
+	generic, err := SafeMergeG(checker,
+		Verified[VerifiedConfig]{LawVerified: proof, Value: a},
+		Verified[VerifiedConfig]{LawVerified: proof, Value: b},
+		MergeConfig, laws)
+	if err != nil {
+		t.Fatalf("SafeMergeG failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(reflective.(VerifiedConfig), generic) {
+		t.Errorf("Results differ:\n  SafeMerge:  %+v\n  SafeMergeG: %+v", reflective, generic)
+	}
+
+	t.Logf("✓ SafeMergeG == SafeMerge: %v", generic.Data)
+}
+
+// TestSafeMergeG_Rejects verifies SafeMergeG enforces laws like CheckType,
+// and only accepts proofs for the merged type.
+func TestSafeMergeG_Rejects(t *testing.T) {
+	registered := LawVerified{TypeName: "string", Laws: []string{"Associative"}}
+
+	tests := []struct {
+		name     string
+		register bool
+		proof    LawVerified
+		wantErr  string
+	}{
+		{"unverified", false, LawVerified{TypeName: "string"}, "not in verified registry"},
+		{"missing law (embedded)", false, LawVerified{TypeName: "string", Laws: []string{"Commutative"}}, "missing required law"},
+		{"registry overrides embedded", true, LawVerified{TypeName: "string", Laws: []string{"Associative", "Idempotent"}}, "missing required law: Idempotent"},
+		{"proof for another type", true, LawVerified{TypeName: "lawbench.VerifiedConfig", Laws: []string{"Associative", "Idempotent"}}, "cannot vouch for string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewRuntimeLawChecker()
+			if tt.register {
+				checker.Register(registered)
+			}
+			v := Verified[string]{LawVerified: tt.proof, Value: "a"}
+			_, err := SafeMergeG(checker, v, v, func(a, b string) string { return a + b }, []string{"Associative", "Idempotent"})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// BenchmarkSafeMergeG compares the generic path with reflective SafeMerge
+// on the same minimal type.
+func BenchmarkSafeMergeG(b *testing.B) {
+	proof := LawVerified{TypeName: "lawbench.verifiedCounter", Laws: []string{"Associative"}}
+	checker := NewRuntimeLawChecker()
+	laws := []string{"Associative"}
+	x := verifiedCounter{LawVerified: proof, N: 1}
+	v := Verified[verifiedCounter]{LawVerified: proof, Value: x}
+
+	b.Run("Generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := SafeMergeG(checker, v, v, mergeCounter, laws); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Reflective", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := checker.SafeMerge(context.Background(), x, x, mergeCounter, laws); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Direct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x = mergeCounter(x, v.Value)
+		}
+	})
+}
+
 // ExampleRuntimeLawChecker demonstrates real-world usage.
 func ExampleRuntimeLawChecker() {
 	// Setup: Register verified types (done once at startup)
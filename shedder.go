@@ -0,0 +1,222 @@
+package lawbench
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Shedder decides whether to admit a single request.
+//
+// Shedders are consulted on the request path and must be safe for
+// concurrent use.
+type Shedder interface {
+	ShouldAdmit(now time.Time) bool
+}
+
+// AdjustableShedder is a Shedder whose shed fraction follows the governor.
+type AdjustableShedder interface {
+	Shedder
+	SetShedFraction(fraction float64)
+}
+
+// Admit is the admission-control helper for middleware.
+//
+// Requests are always admitted when the action suggests no shedding
+// (ActionStable, ActionWarning). Otherwise an AdjustableShedder is first
+// tuned to action.ShedFraction, then asked for a decision:
+//
+//	action := governor.Update(r, alpha, beta, inFlight)
+//	if !lawbench.Admit(action, shedder, time.Now()) {
+//	    http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+//	    return
+//	}
+func Admit(action Action, shedder Shedder, now time.Time) bool {
+	if action.ShedFraction <= 0 {
+		return true
+	}
+	if adjustable, ok := shedder.(AdjustableShedder); ok {
+		adjustable.SetShedFraction(action.ShedFraction)
+	}
+	return shedder.ShouldAdmit(now)
+}
+
+// clampFraction limits a shed fraction to [0, 1].
+func clampFraction(fraction float64) float64 {
+	return math.Max(0, math.Min(1, fraction))
+}
+
+// newRand returns the package's default generator for APIs that accept an
+// optional *rand.Rand: a private, clock-seeded source, so lawbench never
+// draws from (or perturbs) the global math/rand state.
+func newRand() *rand.Rand {
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// RandomShedder drops each request independently with probability fraction.
+type RandomShedder struct {
+	mu       sync.Mutex
+	fraction float64
+	rng      *rand.Rand
+}
+
+// NewRandomShedder creates a shedder that drops the given fraction of requests.
+func NewRandomShedder(fraction float64) *RandomShedder {
+	return NewRandomShedderWithRand(fraction, nil)
+}
+
+// NewRandomShedderWithRand is NewRandomShedder drawing from rng, so a seeded
+// generator replays the same admit/drop sequence. A nil rng uses a
+// clock-seeded default. The shedder serializes access to rng; don't share it
+// with other goroutines.
+func NewRandomShedderWithRand(fraction float64, rng *rand.Rand) *RandomShedder {
+	if rng == nil {
+		rng = newRand()
+	}
+	return &RandomShedder{
+		fraction: clampFraction(fraction),
+		rng:      rng,
+	}
+}
+
+// ShouldAdmit admits with probability 1 - fraction.
+func (s *RandomShedder) ShouldAdmit(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.Float64() >= s.fraction
+}
+
+// SetShedFraction updates the drop probability.
+func (s *RandomShedder) SetShedFraction(fraction float64) {
+	s.mu.Lock()
+	s.fraction = clampFraction(fraction)
+	s.mu.Unlock()
+}
+
+// TokenBucketShedder admits at most rate requests per second with bursts up to burst.
+//
+// Unlike RandomShedder it caps absolute load rather than a fraction of it,
+// so it ignores ShedFraction: use it when the safe rate is known (e.g. from
+// USLCoefficients.PeakThroughput).
+type TokenBucketShedder struct {
+	mu     sync.Mutex
+	rate   float64 // Tokens per second
+	burst  float64 // Bucket capacity
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucketShedder creates a full bucket.
+func NewTokenBucketShedder(rate float64, burst int) *TokenBucketShedder {
+	return &TokenBucketShedder{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// ShouldAdmit refills the bucket for the time elapsed since the last call and
+// consumes one token if available.
+func (s *TokenBucketShedder) ShouldAdmit(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.last.IsZero() {
+		if elapsed := now.Sub(s.last).Seconds(); elapsed > 0 {
+			s.tokens = math.Min(s.burst, s.tokens+elapsed*s.rate)
+		}
+	}
+	s.last = now
+
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
+
+// PriorityShedder sheds low-priority request classes before high-priority ones.
+//
+// Classes are numbered 0 (most important) to classes-1 (least important).
+// Assuming equal traffic per class, the shed fraction is spread from the
+// bottom up: class c is dropped with probability
+//
+//	p(c) = clamp(fraction × classes - (classes-1-c), 0, 1)
+//
+// With 5 classes and fraction 0.2, class 4 is dropped entirely and classes
+// 0-3 are untouched. Class 0 is only shed at fraction 1.0.
+type PriorityShedder struct {
+	mu       sync.Mutex
+	classes  int
+	fraction float64
+	rng      *rand.Rand
+}
+
+// NewPriorityShedder creates a shedder for the given number of request classes.
+func NewPriorityShedder(classes int, fraction float64) *PriorityShedder {
+	return NewPriorityShedderWithRand(classes, fraction, nil)
+}
+
+// NewPriorityShedderWithRand is NewPriorityShedder drawing from rng; see
+// NewRandomShedderWithRand.
+func NewPriorityShedderWithRand(classes int, fraction float64, rng *rand.Rand) *PriorityShedder {
+	if classes < 1 {
+		classes = 1
+	}
+	if rng == nil {
+		rng = newRand()
+	}
+	return &PriorityShedder{
+		classes:  classes,
+		fraction: clampFraction(fraction),
+		rng:      rng,
+	}
+}
+
+// SetShedFraction updates the overall fraction of traffic to shed.
+func (s *PriorityShedder) SetShedFraction(fraction float64) {
+	s.mu.Lock()
+	s.fraction = clampFraction(fraction)
+	s.mu.Unlock()
+}
+
+// DropProbability returns the probability that a request of class c is shed.
+func (s *PriorityShedder) DropProbability(class int) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropProbability(class)
+}
+
+func (s *PriorityShedder) dropProbability(class int) float64 {
+	if class < 0 {
+		class = 0
+	}
+	if class >= s.classes {
+		class = s.classes - 1
+	}
+	return clampFraction(s.fraction*float64(s.classes) - float64(s.classes-1-class))
+}
+
+// Class returns a Shedder for requests of the given class.
+// It shares the parent's shed fraction, so tuning the parent (or passing
+// the class shedder to Admit) affects every class.
+func (s *PriorityShedder) Class(class int) AdjustableShedder {
+	return priorityClass{parent: s, class: class}
+}
+
+// priorityClass is a PriorityShedder view bound to one request class.
+type priorityClass struct {
+	parent *PriorityShedder
+	class  int
+}
+
+func (c priorityClass) ShouldAdmit(now time.Time) bool {
+	c.parent.mu.Lock()
+	defer c.parent.mu.Unlock()
+	return c.parent.rng.Float64() >= c.parent.dropProbability(c.class)
+}
+
+func (c priorityClass) SetShedFraction(fraction float64) {
+	c.parent.SetShedFraction(fraction)
+}
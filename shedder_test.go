@@ -0,0 +1,188 @@
+package lawbench
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRandomShedder_AdmitsComplement(t *testing.T) {
+	const trials = 100000
+	s := NewRandomShedderWithRand(0.2, rand.New(rand.NewSource(1)))
+	now := time.Now()
+
+	admitted := 0
+	for i := 0; i < trials; i++ {
+		if s.ShouldAdmit(now) {
+			admitted++
+		}
+	}
+
+	rate := float64(admitted) / trials
+	t.Logf("Admitted %.2f%% at ShedFraction=0.2", rate*100)
+	if math.Abs(rate-0.8) > 0.01 {
+		t.Errorf("Expected ≈80%% admitted, got %.2f%%", rate*100)
+	}
+}
+
+func TestShedders_SeededReproducible(t *testing.T) {
+	decisions := func(seed int64) string {
+		random := NewRandomShedderWithRand(0.3, rand.New(rand.NewSource(seed)))
+		priority := NewPriorityShedderWithRand(4, 0.4, rand.New(rand.NewSource(seed)))
+		now := time.Now()
+
+		var b strings.Builder
+		for i := 0; i < 1000; i++ {
+			fmt.Fprint(&b, random.ShouldAdmit(now), priority.Class(i%4).ShouldAdmit(now))
+		}
+		return b.String()
+	}
+
+	a, b := decisions(7), decisions(7)
+	if a != b {
+		t.Error("Same seed produced different admit/drop sequences")
+	}
+	if a == decisions(8) {
+		t.Error("Different seeds produced identical sequences")
+	}
+}
+
+func TestTokenBucketShedder_LimitsRate(t *testing.T) {
+	s := NewTokenBucketShedder(10, 5) // 10/s, burst 5
+	start := time.Unix(0, 0)
+
+	// Burst drains the bucket
+	for i := 0; i < 5; i++ {
+		if !s.ShouldAdmit(start) {
+			t.Fatalf("Request %d within burst rejected", i)
+		}
+	}
+	if s.ShouldAdmit(start) {
+		t.Error("Expected rejection once burst is exhausted")
+	}
+
+	// 100ms later exactly one token has refilled
+	later := start.Add(100 * time.Millisecond)
+	if !s.ShouldAdmit(later) {
+		t.Error("Expected admission after refill")
+	}
+	if s.ShouldAdmit(later) {
+		t.Error("Expected rejection: only one token refilled")
+	}
+}
+
+func TestPriorityShedder_ShedsLowPriorityFirst(t *testing.T) {
+	s := NewPriorityShedder(5, 0.2)
+
+	if p := s.DropProbability(4); p != 1 {
+		t.Errorf("Lowest class should be fully shed at 0.2, got %.2f", p)
+	}
+	for c := 0; c < 4; c++ {
+		if p := s.DropProbability(c); p != 0 {
+			t.Errorf("Class %d should be untouched at 0.2, got %.2f", c, p)
+		}
+	}
+
+	s.SetShedFraction(0.5)
+	if p := s.DropProbability(2); math.Abs(p-0.5) > 1e-9 {
+		t.Errorf("Class 2 should be half shed at 0.5, got %.2f", p)
+	}
+	if p := s.DropProbability(0); p != 0 {
+		t.Errorf("Critical class should never be shed below 1.0, got %.2f", p)
+	}
+
+	now := time.Now()
+	if !s.Class(0).ShouldAdmit(now) {
+		t.Error("Critical class rejected")
+	}
+	if s.Class(4).ShouldAdmit(now) {
+		t.Error("Lowest class admitted at 0.5")
+	}
+}
+
+func TestGovernor_ShedFraction(t *testing.T) {
+	tests := []struct {
+		r        float64
+		min, max float64
+	}{
+		{2.5, 0, 0},
+		{2.85, 0, 0},
+		{2.9, 0.15, 0.15},
+		{2.95, 0.225, 0.225},
+		{3.0, 0.5, 0.5},
+		{3.5, 0.55, 0.65},
+		{4.0, 0.7, 0.7},
+	}
+
+	for _, tt := range tests {
+		g := NewGovernor(2.0)
+		action := g.Update(tt.r, 0.05, 0.001, 8)
+		if action.ShedFraction < tt.min-1e-9 || action.ShedFraction > tt.max+1e-9 {
+			t.Errorf("r=%.2f (%s): ShedFraction %.3f outside [%.2f, %.2f]",
+				tt.r, action.Type, action.ShedFraction, tt.min, tt.max)
+		}
+	}
+}
+
+// TestGovernor_ShedFractionMonotonic verifies the shed fraction never falls
+// as r rises through the pacing and throttle zones, and stops at the cap.
+func TestGovernor_ShedFractionMonotonic(t *testing.T) {
+	prev := 0.0
+	for r := 2.0; r <= 5.0; r += 0.01 {
+		action := NewGovernor(2.0).Update(r, 0.05, 0.001, 8)
+		if action.ShedFraction < prev-1e-12 {
+			t.Fatalf("r=%.2f (%s): ShedFraction %.4f fell below %.4f", r, action.Type, action.ShedFraction, prev)
+		}
+		if action.ShedFraction > DefaultMaxShedFraction+1e-12 {
+			t.Fatalf("r=%.2f: ShedFraction %.4f above cap %.2f", r, action.ShedFraction, DefaultMaxShedFraction)
+		}
+		prev = action.ShedFraction
+	}
+	if prev != DefaultMaxShedFraction {
+		t.Errorf("ShedFraction at r=5.0 is %.4f, want the cap %.2f", prev, DefaultMaxShedFraction)
+	}
+}
+
+// TestGovernor_WithShedCurve verifies a custom curve and cap replace the
+// default fractions.
+func TestGovernor_WithShedCurve(t *testing.T) {
+	flat := func(r, warning, saturation float64) float64 {
+		if r < saturation {
+			return 0.1
+		}
+		return 1.0
+	}
+	g := NewGovernor(2.0, WithShedCurve(flat), WithMaxShedFraction(0.9))
+
+	if action := g.Update(2.95, 0.05, 0.001, 8); action.ShedFraction != 0.1 {
+		t.Errorf("Pacing ShedFraction %.3f, want 0.1 from the custom curve", action.ShedFraction)
+	}
+	if action := g.Update(3.2, 0.05, 0.001, 8); action.ShedFraction != 0.9 {
+		t.Errorf("Throttle ShedFraction %.3f, want the 0.9 cap", action.ShedFraction)
+	}
+}
+
+func TestAdmit(t *testing.T) {
+	now := time.Now()
+	s := NewRandomShedder(1.0)
+
+	// No shedding suggested: admit regardless of shedder state
+	if !Admit(Action{Type: ActionStable}, s, now) {
+		t.Error("Stable action should always admit")
+	}
+
+	// Admit retunes the shedder from the action
+	Admit(Action{Type: ActionPacing, ShedFraction: 0.2}, s, now)
+	admitted := 0
+	for i := 0; i < 10000; i++ {
+		if Admit(Action{Type: ActionPacing, ShedFraction: 0.2}, s, now) {
+			admitted++
+		}
+	}
+	if rate := float64(admitted) / 10000; math.Abs(rate-0.8) > 0.03 {
+		t.Errorf("Expected ≈80%% admitted after retune, got %.2f%%", rate*100)
+	}
+}
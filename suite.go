@@ -0,0 +1,128 @@
+package lawbench
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+)
+
+// RunSuite runs each named operation with cfg and fits the USL to its
+// results, answering "which design scales best?" in one call:
+//
+//	coeffs, err := lawbench.RunSuite(ctx, map[string]lawbench.Operation{
+//	    "mutex":   mutexOp,
+//	    "rwmutex": rwMutexOp,
+//	    "atomic":  atomicOp,
+//	}, cfg)
+//	lawbench.PrintComparison(t, coeffs)
+//
+// Operations run one at a time in name order, so they never compete for
+// CPU. On the first failure RunSuite returns the coefficients fitted so far
+// and an error naming the operation.
+func RunSuite(ctx context.Context, ops map[string]Operation, cfg Config) (map[string]USLCoefficients, error) {
+	names := make([]string, 0, len(ops))
+	for name := range ops {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	coeffs := make(map[string]USLCoefficients, len(ops))
+	for _, name := range names {
+		results, err := Run(ctx, ops[name], cfg)
+		if err != nil {
+			return coeffs, fmt.Errorf("%s: %w", name, err)
+		}
+
+		c, err := FitUSL(results)
+		if err != nil {
+			return coeffs, fmt.Errorf("%s: %w", name, err)
+		}
+		coeffs[name] = c
+	}
+
+	return coeffs, nil
+}
+
+// SuiteRank is one operation's place in a RankSuite comparison.
+type SuiteRank struct {
+	Name   string
+	Coeffs USLCoefficients
+
+	PeakConcurrency float64 // N_peak (+Inf when β ≤ 0)
+	Efficiency      float64 // Efficiency at the target N (0 when ranking by N_peak)
+}
+
+// RankSuite orders operations from best to worst scaling.
+//
+// With targetN > 0 they are ranked by efficiency at targetN, the right
+// question when the production concurrency is known. Otherwise they are
+// ranked by N_peak; operations without a peak (β ≤ 0) tie at +Inf and are
+// ordered by PeakThroughput, so an unbounded but serialized design (α ≈ 1)
+// still ranks below a lock-free one. Remaining ties go by name.
+func RankSuite(coeffs map[string]USLCoefficients, targetN int) []SuiteRank {
+	ranks := make([]SuiteRank, 0, len(coeffs))
+	for name, c := range coeffs {
+		rank := SuiteRank{Name: name, Coeffs: c, PeakConcurrency: c.PeakConcurrency()}
+		if targetN > 0 {
+			rank.Efficiency = c.Efficiency(targetN)
+		}
+		ranks = append(ranks, rank)
+	}
+
+	sort.Slice(ranks, func(i, j int) bool {
+		a, b := ranks[i], ranks[j]
+		if targetN > 0 && a.Efficiency != b.Efficiency {
+			return a.Efficiency > b.Efficiency
+		}
+		if targetN <= 0 && a.PeakConcurrency != b.PeakConcurrency {
+			return a.PeakConcurrency > b.PeakConcurrency
+		}
+		if targetN <= 0 {
+			if pa, pb := a.Coeffs.PeakThroughput(), b.Coeffs.PeakThroughput(); pa != pb {
+				return pa > pb
+			}
+		}
+		return a.Name < b.Name
+	})
+
+	return ranks
+}
+
+// PrintComparison tabulates λ, α, β and N_peak for each operation (e.g.
+// from RunSuite), best N_peak first.
+func PrintComparison(t *testing.T, coeffs map[string]USLCoefficients) {
+	t.Helper()
+	printComparison(t, RankSuite(coeffs, 0), 0)
+}
+
+// PrintComparisonAt is PrintComparison ranked by efficiency at targetN.
+func PrintComparisonAt(t *testing.T, coeffs map[string]USLCoefficients, targetN int) {
+	t.Helper()
+	printComparison(t, RankSuite(coeffs, targetN), targetN)
+}
+
+func printComparison(t *testing.T, ranks []SuiteRank, targetN int) {
+	t.Helper()
+
+	if targetN > 0 {
+		t.Logf("\n=== USL Comparison (ranked by efficiency at N=%d) ===", targetN)
+	} else {
+		t.Logf("\n=== USL Comparison (ranked by N_peak) ===")
+	}
+	t.Logf("  #   %-20s %12s  %10s  %10s  %8s  %10s", "Operation", "λ (ops/s)", "α", "β", "N_peak", "Efficiency")
+	t.Logf("  --  %-20s %12s  %10s  %10s  %8s  %10s", "---------", "---------", "-", "-", "------", "----------")
+	for i, r := range ranks {
+		peak := "∞"
+		if !math.IsInf(r.PeakConcurrency, 1) {
+			peak = fmt.Sprintf("%.1f", r.PeakConcurrency)
+		}
+		efficiency := "-"
+		if targetN > 0 {
+			efficiency = fmt.Sprintf("%.1f%%", r.Efficiency*100)
+		}
+		t.Logf("  %-3d %-20s %12.2f  %10.6f  %10.6f  %8s  %10s",
+			i+1, r.Name, r.Coeffs.Lambda, r.Coeffs.Alpha, r.Coeffs.Beta, peak, efficiency)
+	}
+}
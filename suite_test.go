@@ -0,0 +1,116 @@
+package lawbench
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestRunSuite_LockFreeRanksAboveContended verifies a suite of a lock-free
+// and a fully serialized operation fits both and ranks the lock-free one
+// first at the top measured level.
+func TestRunSuite_LockFreeRanksAboveContended(t *testing.T) {
+	var mu sync.Mutex
+	ops := map[string]Operation{
+		"contended": func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			time.Sleep(time.Millisecond)
+			return nil
+		},
+		"lock-free": func(ctx context.Context) error {
+			time.Sleep(time.Millisecond)
+			return nil
+		},
+	}
+
+	cfg := DefaultConfig()
+	cfg.Duration = 100 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{1, 2, 4}
+
+	coeffs, err := RunSuite(context.Background(), ops, cfg)
+	if err != nil {
+		t.Fatalf("RunSuite failed: %v", err)
+	}
+	if len(coeffs) != 2 {
+		t.Fatalf("Expected 2 fits, got %d", len(coeffs))
+	}
+
+	ranks := RankSuite(coeffs, 4)
+	if ranks[0].Name != "lock-free" {
+		t.Errorf("Expected lock-free first, got %s (%.2f) over %s (%.2f)",
+			ranks[0].Name, ranks[0].Efficiency, ranks[1].Name, ranks[1].Efficiency)
+	}
+	if coeffs["contended"].Alpha < 0.5 {
+		t.Errorf("Serialized op should fit α near 1, got %.4f", coeffs["contended"].Alpha)
+	}
+
+	PrintComparisonAt(t, coeffs, 4)
+}
+
+// TestRankSuite_ByPeak verifies N_peak ranking, the PeakThroughput
+// tie-break for unbounded fits, and efficiency ranking at a target N.
+func TestRankSuite_ByPeak(t *testing.T) {
+	coeffs := map[string]USLCoefficients{
+		"coordinated": {Lambda: 1000, Alpha: 0.02, Beta: 0.01},  // N_peak ≈ 9.9
+		"lock-free":   {Lambda: 1000, Alpha: 0.0, Beta: 0.0},    // No peak, no ceiling
+		"serialized":  {Lambda: 1000, Alpha: 0.9, Beta: 0.0},    // No peak, ceiling λ/α
+		"sharded":     {Lambda: 1000, Alpha: 0.01, Beta: 0.001}, // N_peak ≈ 31.5
+	}
+
+	want := []string{"lock-free", "serialized", "sharded", "coordinated"}
+	ranks := RankSuite(coeffs, 0)
+	for i, name := range want {
+		if ranks[i].Name != name {
+			t.Errorf("Rank %d: got %s, want %s", i+1, ranks[i].Name, name)
+		}
+	}
+	if !math.IsInf(ranks[0].PeakConcurrency, 1) || ranks[0].Efficiency != 0 {
+		t.Errorf("Lock-free rank = %+v, want N_peak=+Inf and no efficiency", ranks[0])
+	}
+
+	// At N=8 the serialized design is far less efficient than either peaked one
+	want = []string{"lock-free", "sharded", "coordinated", "serialized"}
+	ranks = RankSuite(coeffs, 8)
+	for i, name := range want {
+		if ranks[i].Name != name {
+			t.Errorf("Rank %d at N=8: got %s (%.3f), want %s", i+1, ranks[i].Name, ranks[i].Efficiency, name)
+		}
+	}
+
+	PrintComparison(t, coeffs)
+}
+
+// TestRunSuite_ReportsFailingOperation verifies an error names the
+// operation and keeps the fits completed before it.
+func TestRunSuite_ReportsFailingOperation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Duration = 10 * time.Millisecond
+	cfg.Warmup = 0
+	cfg.Levels = []int{1, 2}
+
+	ops := map[string]Operation{
+		"a": func(ctx context.Context) error { return nil },
+		"b": func(ctx context.Context) error { return nil },
+	}
+
+	// Two levels are too few to fit, so the first operation fails
+	coeffs, err := RunSuite(context.Background(), ops, cfg)
+	if err == nil {
+		t.Fatal("Expected a fit error with two levels")
+	}
+	if len(coeffs) != 0 || !strings.HasPrefix(err.Error(), "a: ") {
+		t.Errorf("Expected failure on a with no fits, got %v (%d fits)", err, len(coeffs))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := RunSuite(ctx, ops, cfg); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+package lawbench
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// TDigestRecorder is a t-digest (Dunning & Ertl) sketch of latencies.
+//
+// Samples are clustered into weighted centroids whose maximum size shrinks
+// towards the tails (∝ q(1−q)), so extreme quantiles stay nearly exact while
+// the middle of the distribution is summarized coarsely. This is exactly
+// the trade-off tail divergence needs: P99/P999 are what matter.
+//
+// Record is amortized O(1): samples land in a buffer that is merged into
+// the centroids when full. Percentile is a binary search over the
+// centroids, of which there are O(δ·log n) for compression δ, so memory
+// grows only logarithmically with the number of samples.
+//
+// Mean and Stddev are exact (tracked from running sums, not from centroids).
+type TDigestRecorder struct {
+	compression float64
+
+	centroids  []centroid // Merged, sorted by mean
+	cumulative []float64  // cumulative[i] = weight before centroids[i] + half its weight
+	buffer     []centroid // Unmerged samples
+
+	total    int64
+	min, max float64
+	sum      float64 // Σx (ns)
+	sumSq    float64 // Σx² (ns²)
+}
+
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// DefaultTDigestCompression gives rank error well under 1% around the
+// median and near-exact tails.
+const DefaultTDigestCompression = 100
+
+// NewTDigestRecorder creates a t-digest with the given compression δ
+// (≤ 0 means DefaultTDigestCompression). Larger δ is more accurate and uses
+// more memory.
+func NewTDigestRecorder(compression float64) *TDigestRecorder {
+	if compression <= 0 {
+		compression = DefaultTDigestCompression
+	}
+
+	return &TDigestRecorder{
+		compression: compression,
+		buffer:      make([]centroid, 0, int(5*compression)),
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// Record adds a sample.
+func (d *TDigestRecorder) Record(latency time.Duration) {
+	v := float64(latency)
+	d.buffer = append(d.buffer, centroid{mean: v, weight: 1})
+	d.total++
+	d.sum += v
+	d.sumSq += v * v
+	d.min = math.Min(d.min, v)
+	d.max = math.Max(d.max, v)
+
+	if len(d.buffer) == cap(d.buffer) {
+		d.compress()
+	}
+}
+
+// Merge folds another TDigestRecorder into this one.
+func (d *TDigestRecorder) Merge(other LatencyRecorder) {
+	o := other.(*TDigestRecorder)
+	d.buffer = append(d.buffer, o.centroids...)
+	d.buffer = append(d.buffer, o.buffer...)
+	d.total += o.total
+	d.sum += o.sum
+	d.sumSq += o.sumSq
+	d.min = math.Min(d.min, o.min)
+	d.max = math.Max(d.max, o.max)
+	d.compress()
+}
+
+// Count returns the number of samples recorded.
+func (d *TDigestRecorder) Count() int64 {
+	return d.total
+}
+
+// Mean returns the exact average latency.
+func (d *TDigestRecorder) Mean() time.Duration {
+	if d.total == 0 {
+		return 0
+	}
+	return time.Duration(d.sum / float64(d.total))
+}
+
+// Stddev returns the exact population standard deviation.
+func (d *TDigestRecorder) Stddev() time.Duration {
+	if d.total == 0 {
+		return 0
+	}
+	mean := d.sum / float64(d.total)
+	variance := d.sumSq/float64(d.total) - mean*mean
+	if variance < 0 {
+		variance = 0 // Rounding
+	}
+	return time.Duration(math.Sqrt(variance))
+}
+
+// Percentile returns the p-th percentile using the same nearest-rank
+// convention as SliceRecorder, interpolating linearly between centroids.
+func (d *TDigestRecorder) Percentile(p float64) time.Duration {
+	if d.total == 0 {
+		return 0
+	}
+	d.compress()
+
+	// Center of the target sample in cumulative-weight space
+	target := float64(nearestRankIndex(int(d.total), p)) + 0.5
+
+	n := len(d.centroids)
+	first, last := d.cumulative[0], d.cumulative[n-1]
+	switch {
+	case target <= first:
+		return time.Duration(interpolate(0, d.min, first, d.centroids[0].mean, target))
+	case target >= last:
+		return time.Duration(interpolate(last, d.centroids[n-1].mean, float64(d.total), d.max, target))
+	}
+
+	// First centroid whose center is past the target
+	i := sort.SearchFloat64s(d.cumulative, target)
+	if d.cumulative[i] == target {
+		return time.Duration(d.centroids[i].mean)
+	}
+	return time.Duration(interpolate(
+		d.cumulative[i-1], d.centroids[i-1].mean,
+		d.cumulative[i], d.centroids[i].mean,
+		target,
+	))
+}
+
+// fresh returns an empty digest with the same compression.
+func (d *TDigestRecorder) fresh() LatencyRecorder {
+	return NewTDigestRecorder(d.compression)
+}
+
+// sameConfig reports whether other is a TDigestRecorder with the same
+// compression.
+func (d *TDigestRecorder) sameConfig(other LatencyRecorder) bool {
+	o, ok := other.(*TDigestRecorder)
+	return ok && o.compression == d.compression
+}
+
+// compress merges the buffer into the centroids.
+//
+// Adjacent centroids (in sorted order) are combined while the result stays
+// under the size bound 4·N·q(1−q)/δ at both of its edges, so centroids near
+// q=0 and q=1 stay singletons.
+func (d *TDigestRecorder) compress() {
+	if len(d.buffer) == 0 {
+		return
+	}
+
+	all := append(d.buffer, d.centroids...)
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].mean < all[j].mean
+	})
+
+	total := float64(d.total)
+	merged := make([]centroid, 0, len(d.centroids)+1)
+	cur := all[0]
+	weightSoFar := 0.0
+
+	for _, c := range all[1:] {
+		proposed := cur.weight + c.weight
+		q0 := weightSoFar / total
+		q2 := (weightSoFar + proposed) / total
+		limit := total * math.Min(q0*(1-q0), q2*(1-q2)) * 4 / d.compression
+
+		if proposed <= limit {
+			cur.mean += (c.mean - cur.mean) * c.weight / proposed
+			cur.weight = proposed
+			continue
+		}
+
+		merged = append(merged, cur)
+		weightSoFar += cur.weight
+		cur = c
+	}
+	merged = append(merged, cur)
+
+	d.centroids = merged
+	d.buffer = d.buffer[:0]
+
+	d.cumulative = d.cumulative[:0]
+	weightSoFar = 0
+	for _, c := range d.centroids {
+		d.cumulative = append(d.cumulative, weightSoFar+c.weight/2)
+		weightSoFar += c.weight
+	}
+}
+
+// interpolate returns the y at x on the line through (x0, y0) and (x1, y1).
+func interpolate(x0, y0, x1, y1, x float64) float64 {
+	if x1 == x0 {
+		return y0
+	}
+	return y0 + (y1-y0)*(x-x0)/(x1-x0)
+}
@@ -0,0 +1,164 @@
+package lawbench
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+// TestTDigestRecorder_RankAccuracy verifies t-digest percentiles stay within
+// a small rank error of the exact sample, tightest in the tails.
+func TestTDigestRecorder_RankAccuracy(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	digest := NewTDigestRecorder(DefaultTDigestCompression)
+	exact := make([]time.Duration, 0, 100000)
+
+	for i := 0; i < 100000; i++ {
+		lat := time.Duration(math.Exp(rng.NormFloat64()) * float64(time.Millisecond))
+		digest.Record(lat)
+		exact = append(exact, lat)
+	}
+	sort.Slice(exact, func(i, j int) bool { return exact[i] < exact[j] })
+
+	tests := []struct {
+		p       float64
+		maxRank float64 // Allowed |q̂ − q|
+	}{
+		{0.50, 0.01},
+		{0.90, 0.005},
+		{0.99, 0.001},
+		{0.999, 0.0002},
+	}
+
+	for _, tt := range tests {
+		got := digest.Percentile(tt.p)
+		// Rank of the reported value in the exact sample
+		rank := float64(sort.Search(len(exact), func(i int) bool { return exact[i] >= got })) / float64(len(exact))
+		if math.Abs(rank-tt.p) > tt.maxRank {
+			t.Errorf("P%g: reported %v sits at rank %.5f, want within %.4f", tt.p*100, got, rank, tt.maxRank)
+		}
+		t.Logf("  P%-5g digest=%v exact=%v rank=%.5f", tt.p*100, got, exact[nearestRankIndex(len(exact), tt.p)], rank)
+	}
+
+	// The q(1−q) size bound yields O(δ·log n) centroids
+	if limit := DefaultTDigestCompression * math.Log(float64(len(exact))); float64(len(digest.centroids)) > limit {
+		t.Errorf("Expected at most %.0f centroids, got %d", limit, len(digest.centroids))
+	}
+	t.Logf("✓ 100k samples summarized in %d centroids", len(digest.centroids))
+}
+
+// TestTDigestRecorder_MergeAndMoments verifies Merge and exact mean/stddev.
+func TestTDigestRecorder_MergeAndMoments(t *testing.T) {
+	a := NewTDigestRecorder(0)
+	b := NewTDigestRecorder(0)
+	exact := NewSliceRecorder()
+
+	for i := 1; i <= 2000; i++ {
+		lat := time.Duration(i) * time.Microsecond
+		if i%2 == 0 {
+			a.Record(lat)
+		} else {
+			b.Record(lat)
+		}
+		exact.Record(lat)
+	}
+	a.Merge(b)
+
+	if a.Count() != 2000 {
+		t.Errorf("Expected 2000 samples after merge, got %d", a.Count())
+	}
+	if a.Mean() != exact.Mean() {
+		t.Errorf("Mean should be exact: got %v, want %v", a.Mean(), exact.Mean())
+	}
+	if d := a.Stddev() - exact.Stddev(); d < -time.Nanosecond || d > time.Nanosecond {
+		t.Errorf("Stddev should be exact: got %v, want %v", a.Stddev(), exact.Stddev())
+	}
+	if got := a.Percentile(1.0); got != 2*time.Millisecond {
+		t.Errorf("P100 should be the max (2ms), got %v", got)
+	}
+	if got := a.Percentile(0); got != time.Microsecond {
+		t.Errorf("P0 should be the min (1µs), got %v", got)
+	}
+}
+
+// TestTailDivergenceTracker_TDigestAgreesWithExact verifies the t-digest
+// tracker reproduces the exact tracker's tail ratio and estimated r on
+// power-law data, within the digest's rank accuracy.
+func TestTailDivergenceTracker_TDigestAgreesWithExact(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	exact := NewTailDivergenceTracker(10000)
+	digest := NewTailDivergenceTrackerWithRecorder(NewTDigestRecorder(DefaultTDigestCompression))
+	samples := make([]time.Duration, 0, 10000)
+
+	// Same shape as the power-law regime test: 98% fast, 2% black swans
+	for i := 0; i < 10000; i++ {
+		lat := time.Duration(1+rng.Intn(10)) * time.Millisecond
+		if rng.Float64() < 0.02 {
+			lat = time.Duration(100+rng.Intn(9900)) * time.Millisecond
+		}
+		exact.Record(lat)
+		digest.Record(lat)
+		samples = append(samples, lat)
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	// Bound each quantile by the exact values ε away in rank
+	const eps = 0.005
+	at := func(q float64) time.Duration { return samples[nearestRankIndex(len(samples), q)] }
+	ratioLo := float64(at(0.99-eps)) / float64(at(0.50+eps))
+	ratioHi := float64(at(0.99+eps)) / float64(at(0.50-eps))
+
+	exactStats := exact.GetStats()
+	digestStats := digest.GetStats()
+
+	if digestStats.TailDivergenceRatio < ratioLo || digestStats.TailDivergenceRatio > ratioHi {
+		t.Errorf("t-digest tail ratio %.2f outside [%.2f, %.2f] (exact %.2f)",
+			digestStats.TailDivergenceRatio, ratioLo, ratioHi, exactStats.TailDivergenceRatio)
+	}
+
+	// EstimateR is monotonic in the ratio, so the same bounds carry over
+	rLo := estimateRFromRatio(ratioLo, DefaultGaussianMaxRatio, DefaultPowerLawMinRatio)
+	rHi := estimateRFromRatio(ratioHi, DefaultGaussianMaxRatio, DefaultPowerLawMinRatio)
+	if digestStats.EstimatedR < rLo || digestStats.EstimatedR > rHi {
+		t.Errorf("t-digest r %.2f outside [%.2f, %.2f] (exact %.2f)",
+			digestStats.EstimatedR, rLo, rHi, exactStats.EstimatedR)
+	}
+
+	if digestStats.IsPowerLaw != exactStats.IsPowerLaw {
+		t.Errorf("Regime disagrees: digest power law=%v, exact=%v", digestStats.IsPowerLaw, exactStats.IsPowerLaw)
+	}
+	if digestStats.Mean != exactStats.Mean {
+		t.Errorf("Mean should be exact: digest %v, exact %v", digestStats.Mean, exactStats.Mean)
+	}
+
+	t.Logf("✓ Tail ratio: exact %.2f, t-digest %.2f", exactStats.TailDivergenceRatio, digestStats.TailDivergenceRatio)
+	t.Logf("  Estimated r: exact %.2f, t-digest %.2f", exactStats.EstimatedR, digestStats.EstimatedR)
+}
+
+// BenchmarkTailDivergenceTracker_P99 compares per-request P99 queries on the
+// exact 10k ring buffer (re-sorted after each write) and on a t-digest.
+func BenchmarkTailDivergenceTracker_P99(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	trackers := []struct {
+		name    string
+		tracker *TailDivergenceTracker
+	}{
+		{"Exact", NewTailDivergenceTracker(10000)},
+		{"TDigest", NewTailDivergenceTrackerWithRecorder(NewTDigestRecorder(0))},
+	}
+
+	for _, tc := range trackers {
+		b.Run(tc.name, func(b *testing.B) {
+			for i := 0; i < 10000; i++ {
+				tc.tracker.Record(time.Duration(1+rng.Intn(100)) * time.Millisecond)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tc.tracker.Record(time.Duration(1+rng.Intn(100)) * time.Millisecond)
+				tc.tracker.P99()
+			}
+		})
+	}
+}
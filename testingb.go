@@ -0,0 +1,116 @@
+package lawbench
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// RunB measures op inside a standard Go benchmark, one sub-benchmark per
+// concurrency level, and returns a Result per level for FitUSL.
+//
+// Each level N runs op under b.RunParallel with GOMAXPROCS set to N (and
+// parallelism 1), so N goroutines share b.N iterations. Iteration count and
+// duration follow the usual -benchtime flag; the Result is taken from the
+// final, full-length run. GOMAXPROCS is restored afterwards.
+//
+//	func BenchmarkCache(b *testing.B) {
+//	    results := lawbench.RunB(b, op, []int{1, 2, 4, 8})
+//	    lawbench.ReportUSL(b, results)
+//	}
+func RunB(b *testing.B, op Operation, levels []int) []Result {
+	b.Helper()
+	if len(levels) == 0 {
+		levels = DefaultConfig().Levels
+	}
+	levels, err := NormalizeLevels(levels)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	results := make([]Result, 0, len(levels))
+	for _, n := range levels {
+		var result Result
+		ok := b.Run(fmt.Sprintf("N=%d", n), func(b *testing.B) {
+			result = runParallelLevel(b, op, n)
+		})
+		if ok {
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// runParallelLevel runs one b.N iteration of a RunB level.
+func runParallelLevel(b *testing.B, op Operation, n int) Result {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(n))
+	b.SetParallelism(1)
+
+	var (
+		mu        sync.Mutex
+		latencies = make([]time.Duration, 0, b.N)
+		errors    int64
+	)
+
+	b.ResetTimer()
+	start := time.Now()
+	b.RunParallel(func(pb *testing.PB) {
+		ctx := context.Background()
+		local := make([]time.Duration, 0, 64)
+		var localErrors int64
+
+		for pb.Next() {
+			opStart := time.Now()
+			if err := op(ctx); err != nil {
+				localErrors++
+				continue
+			}
+			local = append(local, time.Since(opStart))
+		}
+
+		mu.Lock()
+		latencies = append(latencies, local...)
+		errors += localErrors
+		mu.Unlock()
+	})
+	elapsed := time.Since(start)
+	b.StopTimer()
+
+	operations := int64(len(latencies))
+	return Result{
+		N:          n,
+		Duration:   elapsed,
+		Operations: operations,
+		Throughput: float64(operations) / elapsed.Seconds(),
+		Latencies:  latencies,
+		Errors:     errors,
+		WallTime:   elapsed,
+	}
+}
+
+// ReportUSL fits results (e.g. from RunB) to the Universal Scalability Law
+// and attaches λ, α and β to b with b.ReportMetric, so `go test -bench`
+// output carries them alongside ns/op:
+//
+//	BenchmarkCache  ...  0.0213 alpha  0.000412 beta  98123 lambda-ops/s
+//
+// The fit error (fewer than three levels) is returned and nothing is
+// reported.
+func ReportUSL(b *testing.B, results []Result) (USLCoefficients, error) {
+	b.Helper()
+
+	coeffs, err := FitUSL(results)
+	if err != nil {
+		return coeffs, err
+	}
+
+	b.ReportMetric(coeffs.Lambda, "lambda-ops/s")
+	b.ReportMetric(coeffs.Alpha, "alpha")
+	b.ReportMetric(coeffs.Beta, "beta")
+
+	return coeffs, nil
+}
@@ -0,0 +1,96 @@
+package lawbench
+
+import (
+	"context"
+	"flag"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// TestReportUSL_Metrics verifies ReportUSL attaches λ, α and β as custom
+// benchmark metrics.
+func TestReportUSL_Metrics(t *testing.T) {
+	coeffs := USLCoefficients{Lambda: 1000, Alpha: 0.05, Beta: 0.001}
+	results := make([]Result, 0, 4)
+	for _, n := range []int{1, 2, 4, 8} {
+		results = append(results, Result{N: n, Throughput: coeffs.PredictThroughput(n)})
+	}
+
+	var fitted USLCoefficients
+	var fitErr error
+	bench := testing.Benchmark(func(b *testing.B) {
+		fitted, fitErr = ReportUSL(b, results)
+	})
+	if fitErr != nil {
+		t.Fatalf("ReportUSL failed: %v", fitErr)
+	}
+
+	want := map[string]float64{
+		"lambda-ops/s": fitted.Lambda,
+		"alpha":        fitted.Alpha,
+		"beta":         fitted.Beta,
+	}
+	for unit, v := range want {
+		got, ok := bench.Extra[unit]
+		if !ok {
+			t.Errorf("Metric %q not reported (got %v)", unit, bench.Extra)
+			continue
+		}
+		if got != v {
+			t.Errorf("Metric %q = %v, want %v", unit, got, v)
+		}
+	}
+
+	short := testing.Benchmark(func(b *testing.B) {
+		_, fitErr = ReportUSL(b, results[:2])
+	})
+	if fitErr == nil || len(short.Extra) != 0 {
+		t.Errorf("Expected a fit error and no metrics with two levels, got %v, %v", fitErr, short.Extra)
+	}
+
+	t.Logf("✓ Reported %v", bench.Extra)
+}
+
+// TestRunB_Levels verifies RunB returns one Result per level from the final
+// b.N run and restores GOMAXPROCS.
+func TestRunB_Levels(t *testing.T) {
+	benchtime := flag.Lookup("test.benchtime")
+	old := benchtime.Value.String()
+	if err := benchtime.Value.Set("200x"); err != nil {
+		t.Fatal(err)
+	}
+	defer benchtime.Value.Set(old)
+
+	procs := runtime.GOMAXPROCS(0)
+	op := func(ctx context.Context) error {
+		time.Sleep(10 * time.Microsecond)
+		return nil
+	}
+
+	var results []Result
+	testing.Benchmark(func(b *testing.B) {
+		results = RunB(b, op, []int{1, 2})
+	})
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if r.N != i+1 {
+			t.Errorf("Result %d: N=%d, want %d", i, r.N, i+1)
+		}
+		if r.Operations != 200 || int64(len(r.Latencies)) != r.Operations {
+			t.Errorf("N=%d: expected 200 operations with latencies, got %d (%d latencies)",
+				r.N, r.Operations, len(r.Latencies))
+		}
+		if r.Throughput <= 0 {
+			t.Errorf("N=%d: throughput %.0f", r.N, r.Throughput)
+		}
+	}
+	if got := runtime.GOMAXPROCS(0); got != procs {
+		t.Errorf("GOMAXPROCS = %d after RunB, want %d", got, procs)
+	}
+
+	t.Logf("✓ N=1: %.0f ops/sec, N=2: %.0f ops/sec", results[0].Throughput, results[1].Throughput)
+}